@@ -16,4 +16,5 @@ var HeatMapCache = cache.New(1*time.Hour, 10*time.Minute)
 var OtpCache = cache.New(5*time.Minute, 10*time.Minute)
 var RateLimiterCache = cache.New(10*time.Minute, 15*time.Minute)
 var PriceActionCache = cache.New(cache.NoExpiration, 0)
-var YahooHistoryCache = cache.New(1*time.Hour, 10*time.Minute)
+var CandleBackfillCache = cache.New(24*time.Hour, 1*time.Hour)
+var IntradayCache = cache.New(5*time.Minute, 10*time.Minute)
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http/cookiejar"
+	"sync"
+	"time"
+
+	"backend/middleware"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	NseUrl       = "https://www.nseindia.com"
+	nseUserAgent = "Mozilla/5.0 (iPhone; CPU iPhone OS 18_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.5 Mobile/15E148 Safari/604.1"
+)
+
+type NseClient struct {
+	client     *resty.Client
+	sfGroup    singleflight.Group
+	lastWarmup time.Time
+	warmupLock sync.RWMutex
+}
+
+func NewNseClient() *NseClient {
+	client := resty.New().
+		SetBaseURL(NseUrl).
+		SetTimeout(30*time.Second).
+		SetHeader("User-Agent", nseUserAgent).
+		SetRetryCount(2).
+		SetRetryWaitTime(1 * time.Second)
+
+	client.OnAfterResponse(middleware.DecompressMiddleware)
+
+	return &NseClient{client: client}
+}
+
+// WarmUp ensures we have a valid session cookie from NSE.
+func (c *NseClient) WarmUp() error {
+	c.warmupLock.RLock()
+	isFresh := time.Since(c.lastWarmup) < 2*time.Minute
+	c.warmupLock.RUnlock()
+
+	if isFresh {
+		return nil
+	}
+
+	_, err, _ := c.sfGroup.Do("nse-session-refresh", func() (any, error) {
+		log.Println("Refreshing NSE session...")
+
+		newJar, _ := cookiejar.New(nil)
+		c.client.SetCookieJar(newJar)
+
+		resp, err := c.client.R().
+			SetHeaders(map[string]string{
+				"Referer":         "https://www.google.com/",
+				"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+				"Accept-Language": "en-US,en;q=0.9",
+			}).
+			Get("/")
+
+		if err != nil || !resp.IsSuccess() {
+			return nil, fmt.Errorf("warmup failed: %v", err)
+		}
+
+		c.warmupLock.Lock()
+		c.lastWarmup = time.Now()
+		c.warmupLock.Unlock()
+
+		return nil, nil
+	})
+	return err
+}
+
+// Get performs a session-backed API call and decodes the JSON body into target.
+func (c *NseClient) Get(ctx context.Context, referer, path string, params map[string]string, target any) error {
+	if err := c.WarmUp(); err != nil {
+		return err
+	}
+
+	req := c.client.R().SetContext(ctx).SetHeaders(map[string]string{
+		"Accept":          "*/*",
+		"Accept-Encoding": "gzip, deflate, br",
+		"Referer":         referer,
+		"sec-fetch-dest":  "empty",
+		"sec-fetch-mode":  "cors",
+		"sec-fetch-site":  "same-origin",
+	})
+
+	if params != nil {
+		req.SetQueryParams(params)
+	}
+
+	resp, err := req.Get(path)
+	if err != nil {
+		return fmt.Errorf("NSE request failed: %w", err)
+	}
+	if !resp.IsSuccess() {
+		return fmt.Errorf("NSE request failed: status %d", resp.StatusCode())
+	}
+
+	if err := json.Unmarshal(resp.Body(), target); err != nil {
+		return fmt.Errorf("decode error: %w", err)
+	}
+
+	return nil
+}
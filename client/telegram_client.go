@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type TelegramClient struct {
+	client *resty.Client
+}
+
+func NewTelegramClient() *TelegramClient {
+	client := resty.New().
+		SetTimeout(10*time.Second).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Accept", "application/json")
+
+	return &TelegramClient{
+		client: client,
+	}
+}
+
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// SendMessage posts an HTML formatted message to a chat through the Bot API at baseURL.
+func (c *TelegramClient) SendMessage(ctx context.Context, baseURL, token, chatID, text string) error {
+	var result telegramResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(map[string]any{
+			"chat_id":                  chatID,
+			"text":                     text,
+			"parse_mode":               "HTML",
+			"disable_web_page_preview": true,
+		}).
+		SetResult(&result).
+		SetError(&result).
+		Post(strings.TrimRight(baseURL, "/") + "/bot" + token + "/sendMessage")
+
+	if err != nil {
+		// The token is part of the URL, keep it out of the logs
+		return fmt.Errorf("failed to execute request: %s", strings.ReplaceAll(err.Error(), token, "<token>"))
+	}
+
+	if !resp.IsSuccess() || !result.OK {
+		return fmt.Errorf("telegram api error (status %d): %s", resp.StatusCode(), result.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type WebhookClient struct {
+	client *resty.Client
+}
+
+func NewWebhookClient() *WebhookClient {
+	client := resty.New().
+		SetTimeout(10*time.Second).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "shahbaz-trades-webhooks/1.0")
+
+	return &WebhookClient{
+		client: client,
+	}
+}
+
+// Post sends a raw JSON body to a subscriber. Non-2xx responses are returned without an error.
+func (c *WebhookClient) Post(ctx context.Context, url string, headers map[string]string, body []byte) (*resty.Response, error) {
+	return c.client.R().
+		SetContext(ctx).
+		SetHeaders(headers).
+		SetBody(body).
+		Post(url)
+}
@@ -1,19 +1,19 @@
 package client
 
 import (
-	localCache "backend/cache"
 	"backend/model"
-	"backend/util"
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"slices"
-	"strconv"
+	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
+// ErrChartNotFound is returned when Yahoo has no chart for a symbol, e.g. an unknown or delisted one.
+var ErrChartNotFound = errors.New("yahoo has no chart")
+
 type YahooClient struct {
 	client *resty.Client
 }
@@ -33,57 +33,30 @@ func NewYahooClient() *YahooClient {
 	}
 }
 
-func (y *YahooClient) GetHistoricalData(ctx context.Context, symbol string, timeRange model.YahooTimeRange) ([]model.NSEHistoricalData, error) {
-	cacheKey := "history_" + symbol + "_" + string(timeRange)
-	if val, found := localCache.YahooHistoryCache.Get(cacheKey); found {
-		return val.([]model.NSEHistoricalData), nil
-	}
-
+// GetChart fetches the raw chart payload for an NSE listed symbol.
+// params are passed through as query parameters (range, interval, period1, period2...).
+func (y *YahooClient) GetChart(ctx context.Context, symbol string, params map[string]string) (*model.Result, error) {
 	var chartResponse model.YahooChartResponse
 	resp, err := y.client.R().
-		SetQueryParams(map[string]string{
-			"range":    string(timeRange),
-			"interval": string(model.Range1d),
-		}).SetResult(&chartResponse).
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&chartResponse).
+		SetError(&chartResponse).
 		Get("/" + symbol + ".NS")
 
-	if err != nil || !resp.IsSuccess() || chartResponse.Chart.Error != nil {
-		log.Println("Error calling yahoo api")
-		return nil, fmt.Errorf("Yahoo request failed: %v", err)
+	if err != nil {
+		return nil, fmt.Errorf("yahoo request failed: %w", err)
 	}
-
-	list := make([]model.NSEHistoricalData, 0, 10)
-
-	loc, _ := time.LoadLocation("Asia/Kolkata")
-	timeframes := chartResponse.Chart.Result[0].Timestamp
-	quote := chartResponse.Chart.Result[0].Indicators.Quote[0]
-	open := quote.Open
-	high := quote.High
-	low := quote.Low
-	close := quote.Close
-	volume := quote.Volume
-	for i := range timeframes {
-		if volume[i] > 0 && open[i] != 0 {
-			list = append(list, model.NSEHistoricalData{
-				Symbol:    symbol,
-				Open:      formatToTwo(open[i]),
-				High:      formatToTwo(high[i]),
-				Low:       formatToTwo(low[i]),
-				Close:     formatToTwo(close[i]),
-				Timestamp: time.Unix(timeframes[i], 0).In(loc).Format("02-Jan-2006"),
-			})
-		}
+	chartErr := chartResponse.Chart.Error
+	if resp.StatusCode() == http.StatusNotFound || (chartErr != nil && chartErr.Code == "Not Found") {
+		return nil, fmt.Errorf("%w for %s", ErrChartNotFound, symbol)
 	}
-
-	if len(list) > 0 {
-		slices.Reverse(list)
-		localCache.YahooHistoryCache.Set(cacheKey, list, util.NseCacheExpiryTime())
+	if !resp.IsSuccess() || chartErr != nil {
+		return nil, fmt.Errorf("yahoo request failed: status %d", resp.StatusCode())
+	}
+	if len(chartResponse.Chart.Result) == 0 {
+		return nil, fmt.Errorf("%w for %s", ErrChartNotFound, symbol)
 	}
 
-	return list, nil
-}
-
-func formatToTwo(n float64) float64 {
-	val, _ := strconv.ParseFloat(fmt.Sprintf("%.2f", n), 64)
-	return val
+	return &chartResponse.Chart.Result[0], nil
 }
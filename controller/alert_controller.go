@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlertController struct {
+	alertSvc     service.AlertService
+	isProduction bool
+}
+
+func NewAlertController(s service.AlertService, isProduction bool) *AlertController {
+	return &AlertController{alertSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the user's price and zone alerts and their delivery history.
+func (ctrl *AlertController) RegisterRoutes(router *gin.RouterGroup) {
+	alertGroup := router.Group("/alerts")
+	alertGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		alertGroup.GET("", ctrl.ListAlerts)
+		alertGroup.POST("", ctrl.CreateAlert)
+		alertGroup.GET("/history", ctrl.ListHistory)
+		alertGroup.PATCH("/:id", ctrl.SetActive)
+		alertGroup.DELETE("/:id", ctrl.DeleteAlert)
+	}
+}
+
+// ListAlerts godoc
+// @Summary      List alerts
+// @Description  Returns the user's alerts, newest first.
+// @Tags         Alerts
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.Alert}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /alerts [get]
+func (ctrl *AlertController) ListAlerts(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	alerts, err := ctrl.alertSvc.ListAlerts(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: alerts})
+}
+
+// CreateAlert godoc
+// @Summary      Create an alert
+// @Description  PRICE alerts fire when the latest candle crosses level; NEW_ZONE and ZONE_MITIGATED alerts fire when
+// @Description  a matching order block or FVG is saved or mitigated. Alerts are sent on the user's alert channels
+// @Description  (email by default), at most once per occurrence and not again within the cooldown.
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.AlertRequest  true  "Alert"
+// @Success      201      {object}  model.Response{data=model.Alert}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /alerts [post]
+func (ctrl *AlertController) CreateAlert(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.AlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	alert, err := ctrl.alertSvc.CreateAlert(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		c.JSON(alertStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, model.Response{Success: true, Message: "Alert created", Data: alert})
+}
+
+// SetActive godoc
+// @Summary      Pause or resume an alert
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Alert ID"
+// @Param        request  body      model.AlertActiveRequest  true  "Active flag"
+// @Success      200      {object}  model.Response
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /alerts/{id} [patch]
+func (ctrl *AlertController) SetActive(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.AlertActiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	if err := ctrl.alertSvc.SetActive(c.Request.Context(), user.UserID, c.Param("id"), *req.Active); err != nil {
+		c.JSON(alertStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Alert updated"})
+}
+
+// DeleteAlert godoc
+// @Summary      Delete an alert
+// @Tags         Alerts
+// @Produce      json
+// @Param        id   path      string  true  "Alert ID"
+// @Success      200  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /alerts/{id} [delete]
+func (ctrl *AlertController) DeleteAlert(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	if err := ctrl.alertSvc.DeleteAlert(c.Request.Context(), user.UserID, c.Param("id")); err != nil {
+		c.JSON(alertStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Alert deleted"})
+}
+
+// ListHistory godoc
+// @Summary      Alert history
+// @Description  Returns the user's latest alert firings with their delivery outcome.
+// @Tags         Alerts
+// @Produce      json
+// @Param        limit  query     int  false  "Max entries to return (default 50)"
+// @Success      200    {object}  model.Response{data=[]model.AlertHistory}
+// @Failure      401    {object}  model.Response
+// @Failure      500    {object}  model.Response
+// @Router       /alerts/history [get]
+func (ctrl *AlertController) ListHistory(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	history, err := ctrl.alertSvc.ListHistory(c.Request.Context(), user.UserID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: history})
+}
+
+// alertStatus maps alert service errors to HTTP status codes.
+func alertStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAlertNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidAlert):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
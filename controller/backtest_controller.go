@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"backend/detection"
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+	"backend/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BacktestController struct {
+	backtestSvc  service.BacktestService
+	isProduction bool
+}
+
+func NewBacktestController(s service.BacktestService, isProduction bool) *BacktestController {
+	return &BacktestController{backtestSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the backtest endpoint. Runs replay a lot of history, so it requires a login.
+func (ctrl *BacktestController) RegisterRoutes(router *gin.RouterGroup) {
+	backtestGroup := router.Group("/backtest")
+	backtestGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		backtestGroup.POST("", ctrl.Run)
+	}
+}
+
+// Run godoc
+// @Summary      Backtest order block and FVG entries
+// @Description  Replays stored zones against stored candles. A trade enters at the close of the first valid mitigation
+// @Description  and exits on the stop loss, the target or after maxHoldCandles. Reports win rate, expectancy,
+// @Description  max drawdown and the equity curve.
+// @Tags         PriceAction
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.BacktestRequest  true  "Backtest parameters"
+// @Success      200      {object}  model.Response{data=model.BacktestResult}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /backtest [post]
+func (ctrl *BacktestController) Run(c *gin.Context) {
+	var req model.BacktestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	if !req.Timeframe.OrDefault().IsValid() {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid timeframe: " + string(req.Timeframe)})
+		return
+	}
+	if req.Kind != "" && req.Kind != detection.KindOrderBlock && req.Kind != detection.KindFvg {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid kind: " + req.Kind})
+		return
+	}
+	if req.Direction != "" && !req.Direction.IsValid() {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid direction: " + string(req.Direction)})
+		return
+	}
+	if req.StopLossPct < 0 || req.TargetPct < 0 || req.StopLossPct >= 100 {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid stop loss or target"})
+		return
+	}
+
+	to := time.Now()
+	from := to.AddDate(-1, 0, 0)
+	var err error
+	if req.From != "" {
+		if from, err = util.ParseDateKey(req.From); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid from date"})
+			return
+		}
+	}
+	if req.To != "" {
+		if to, err = util.ParseDateKey(req.To); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid to date"})
+			return
+		}
+		to = to.Add(24*time.Hour - time.Nanosecond)
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "from must be before to"})
+		return
+	}
+
+	result, err := ctrl.backtestSvc.Run(c.Request.Context(), req, from, to)
+	if errors.Is(err, service.ErrIntradayBacktest) {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: result})
+}
@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"backend/middleware"
 	"backend/model"
 	"backend/service"
 
@@ -21,9 +24,11 @@ func NewChartInkController(ci service.ChartInkService, ss service.StrategyServic
 	}
 }
 
-// RegisterRoutes sets up the route group for ChartInk operations.
+// RegisterRoutes sets up the route group for ChartInk operations. Signed-in users can also run the private
+// strategies they own or that are shared with them.
 func (ctrl *ChartInkController) RegisterRoutes(router *gin.RouterGroup) {
 	chartinkGroup := router.Group("/chartink")
+	chartinkGroup.Use(middleware.OptionalAuth())
 	{
 		chartinkGroup.GET("/fetch", ctrl.fetchData)
 		chartinkGroup.GET("/fetchWithMargin", ctrl.fetchWithMargin)
@@ -32,7 +37,8 @@ func (ctrl *ChartInkController) RegisterRoutes(router *gin.RouterGroup) {
 
 // fetchData retrieves raw scanner results from ChartInk.
 // @Summary      Fetch raw ChartInk data
-// @Description  Triggers a scan on ChartInk for the given strategy and returns raw stock data
+// @Description  Triggers a scan on ChartInk for the given strategy and returns raw stock data. While ChartInk is
+// @Description  unavailable the last stored result is returned with stale set.
 // @Tags         ChartInk
 // @Produce      json
 // @Param        strategy  query     string  true  "Name of the strategy to run"  example(Bullish_Engulfing)
@@ -48,7 +54,7 @@ func (ctrl *ChartInkController) fetchData(c *gin.Context) {
 		return
 	}
 
-	strategyDto, exists := ctrl.findStrategy(strategyName)
+	strategyDto, exists := ctrl.strategyService.GetStrategy(strategyName, middleware.GetUserID(c))
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Strategy not found"})
 		return
@@ -65,11 +71,20 @@ func (ctrl *ChartInkController) fetchData(c *gin.Context) {
 
 // fetchWithMargin retrieves scanner results merged with local Margin data.
 // @Summary      Fetch ChartInk data with Margin info
-// @Description  Triggers a scan and maps results with current margin and leverage data
+// @Description  Triggers a scan and maps results with current margin and leverage data. A result under a minute old
+// @Description  is served from cache; an older one is served right away with stale set while it is refreshed in
+// @Description  the background.
 // @Tags         ChartInk
 // @Produce      json
-// @Param        strategy  query     string  true  "Name of the strategy to run" example(Nifty_50_Breakout)
-// @Success      200       {array}   model.StockMarginDto
+// @Param        strategy   query     string  true   "Name of the strategy to run" example(Nifty_50_Breakout)
+// @Param        sort       query     string  false  "margin (default), close, volume, pChange, name, symbol or any scan column"
+// @Param        order      query     string  false  "asc or desc (default)"
+// @Param        minPrice   query     number  false  "Minimum close"
+// @Param        minVolume  query     number  false  "Minimum volume"
+// @Param        minMargin  query     number  false  "Minimum margin"
+// @Param        page       query     int     false  "Page, from 1"
+// @Param        size       query     int     false  "Page size up to 500 (default all stocks)"
+// @Success      200        {object}  model.MarginScan
 // @Failure      400       {object}  map[string]string
 // @Failure      404       {object}  map[string]string
 // @Failure      500       {object}  map[string]string
@@ -81,28 +96,62 @@ func (ctrl *ChartInkController) fetchWithMargin(c *gin.Context) {
 		return
 	}
 
-	strategyDto, exists := ctrl.findStrategy(strategyName)
+	strategyDto, exists := ctrl.strategyService.GetStrategy(strategyName, middleware.GetUserID(c))
 	if !exists {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Strategy not found"})
 		return
 	}
 
-	data, err := ctrl.chartInkService.FetchWithMargin(strategyDto)
+	query, err := parseMarginScanQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	data, err := ctrl.chartInkService.FetchMarginScan(strategyDto, query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidScanQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, data)
 }
 
-// Helper to bridge the logic from your StrategyService map
-func (ctrl *ChartInkController) findStrategy(name string) (model.StrategyDto, bool) {
-	strategies := ctrl.strategyService.GetAllStrategies()
-	for _, s := range strategies {
-		if s.Name == name {
-			return s, true
+// parseMarginScanQuery reads the sorting, filter and paging parameters of fetchWithMargin.
+func parseMarginScanQuery(c *gin.Context) (model.MarginScanQuery, error) {
+	query := model.MarginScanQuery{Sort: c.Query("sort")}
+	switch c.DefaultQuery("order", "desc") {
+	case "asc":
+		query.Ascending = true
+	case "desc":
+	default:
+		return query, errors.New("order must be asc or desc")
+	}
+
+	floats := map[string]*float64{"minPrice": &query.MinPrice, "minVolume": &query.MinVolume, "minMargin": &query.MinMargin}
+	for name, target := range floats {
+		if raw := c.Query(name); raw != "" {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return query, errors.New(name + " must be a number")
+			}
+			*target = v
+		}
+	}
+
+	ints := map[string]*int{"page": &query.Page, "size": &query.Size}
+	for name, target := range ints {
+		if raw := c.Query(name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return query, errors.New(name + " must be an integer")
+			}
+			*target = v
 		}
 	}
-	return model.StrategyDto{}, false
+	return query, nil
 }
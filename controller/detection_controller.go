@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+	"backend/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DetectionController struct {
+	detectionSvc service.DetectionService
+	isProduction bool
+}
+
+func NewDetectionController(s service.DetectionService, isProduction bool) *DetectionController {
+	return &DetectionController{detectionSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up admin-only endpoints for the order block and FVG detection engine.
+// The scheduled scan over all symbols runs as the zone-detection job.
+func (ctrl *DetectionController) RegisterRoutes(router *gin.RouterGroup) {
+	detectionGroup := router.Group("/detection")
+	detectionGroup.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
+	{
+		detectionGroup.POST("/run", ctrl.Detect)
+	}
+}
+
+// Detect godoc
+// @Summary      Detect zones for a symbol
+// @Description  Runs the detection engine over a symbol's stored candles and returns the order blocks and FVGs found.
+// @Description  Set save to write them as price action zones.
+// @Tags         PriceAction (Admin)
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.DetectRequest  true  "Detection range"
+// @Success      200      {object}  model.Response{data=[]detection.Zone}
+// @Failure      400      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /detection/run [post]
+func (ctrl *DetectionController) Detect(c *gin.Context) {
+	var req model.DetectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	tf := req.Timeframe.OrDefault()
+	if !tf.IsValid() {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid timeframe: " + string(req.Timeframe)})
+		return
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -90)
+	var err error
+	if req.From != "" {
+		if from, err = util.ParseDateKey(req.From); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid from date"})
+			return
+		}
+	}
+	if req.To != "" {
+		if to, err = util.ParseDateKey(req.To); err != nil {
+			c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid to date"})
+			return
+		}
+		to = to.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	zones, err := ctrl.detectionSvc.Detect(c.Request.Context(), req.Symbol, tf, from, to, req.Save)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: zones})
+}
@@ -3,13 +3,18 @@ package controller
 import (
 	"net/http"
 
+	"backend/service"
+	"backend/util"
+
 	"github.com/gin-gonic/gin"
 )
 
-type HealthController struct{}
+type HealthController struct {
+	chartInkSvc service.ChartInkService
+}
 
-func NewHealthController() *HealthController {
-	return &HealthController{}
+func NewHealthController(ci service.ChartInkService) *HealthController {
+	return &HealthController{chartInkSvc: ci}
 }
 
 // RegisterRoutes sets up the health check endpoint.
@@ -18,6 +23,7 @@ func (ctrl *HealthController) RegisterRoutes(router *gin.RouterGroup) {
 	// These resolve to [base_path]/health
 	router.GET("/health", ctrl.healthCheck)
 	router.HEAD("/health", ctrl.healthCheck)
+	router.GET("/health/chartink", ctrl.chartInkHealth)
 }
 
 // healthCheck returns the current status of the server.
@@ -38,3 +44,21 @@ func (ctrl *HealthController) healthCheck(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// chartInkHealth reports the ChartInk upstream.
+// @Summary      ChartInk Health
+// @Description  Reports the ChartInk session: circuit breaker state, token status, and request, retry, rate limit
+// @Description  and stale-serve counters since startup. Responds 503 while the circuit is open.
+// @Tags         System
+// @Produce      json
+// @Success      200  {object}  model.ChartInkHealth
+// @Failure      503  {object}  model.ChartInkHealth
+// @Router       /health/chartink [get]
+func (ctrl *HealthController) chartInkHealth(c *gin.Context) {
+	health := ctrl.chartInkSvc.Health()
+	status := http.StatusOK
+	if health.Circuit == util.CircuitOpen {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
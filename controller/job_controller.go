@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	jobSvc       service.JobService
+	isProduction bool
+}
+
+func NewJobController(s service.JobService, isProduction bool) *JobController {
+	return &JobController{jobSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up read-only endpoints for polling background job progress. Jobs carry per-symbol errors, so
+// polling one requires a session and listing them the Admin role.
+func (ctrl *JobController) RegisterRoutes(router *gin.RouterGroup) {
+	jobGroup := router.Group("/jobs")
+	jobGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		jobGroup.GET("", middleware.AdminOnly(), ctrl.ListJobs)
+		jobGroup.GET("/:id", ctrl.GetJob)
+	}
+}
+
+// ListJobs godoc
+// @Summary      List background jobs
+// @Description  Returns recent background jobs with their status and counters (per-symbol errors omitted).
+// @Tags         Jobs
+// @Produce      json
+// @Param        type    query     string  false  "Job type"  example(ob-automation)
+// @Param        status  query     string  false  "Job status"  Enums(PENDING, RUNNING, SUCCESS, FAILED)
+// @Param        limit   query     int     false  "Max jobs to return (default 50)"
+// @Success      200     {object}  model.Response{data=[]model.Job}
+// @Failure      500     {object}  model.Response
+// @Router       /jobs [get]
+func (ctrl *JobController) ListJobs(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	jobs, err := ctrl.jobSvc.ListJobs(c.Request.Context(), c.Query("type"), model.RunStatus(c.Query("status")), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: jobs})
+}
+
+// GetJob godoc
+// @Summary      Get job progress
+// @Description  Returns status, counters and per-symbol errors of a single background job.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  model.Response{data=model.Job}
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /jobs/{id} [get]
+func (ctrl *JobController) GetJob(c *gin.Context) {
+	job, err := ctrl.jobSvc.GetJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrBackgroundJobNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: job})
+}
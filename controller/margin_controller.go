@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"backend/middleware"
 	"backend/model"
 	"backend/service"
 
@@ -11,11 +14,13 @@ import (
 
 type MarginController struct {
 	marginService service.MarginService
+	isProduction  bool
 }
 
-func NewMarginController(ms service.MarginService) *MarginController {
+func NewMarginController(ms service.MarginService, isProduction bool) *MarginController {
 	return &MarginController{
 		marginService: ms,
+		isProduction:  isProduction,
 	}
 }
 
@@ -25,8 +30,18 @@ func (ctrl *MarginController) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		marginGroup.GET("/all", ctrl.getAllMargins)
 		marginGroup.GET("/symbol/:symbol", ctrl.getMargin)
+		marginGroup.GET("/symbol/:symbol/history", ctrl.getMarginHistory)
 		marginGroup.POST("/reload", ctrl.reloadAllMargins) // Changed to POST for action
-		marginGroup.POST("/load-from-csv", ctrl.loadFromCsv)
+
+		// Imports are recorded against the uploader, so they require Admin role and JWT
+		adminGroup := marginGroup.Group("")
+		adminGroup.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
+		{
+			adminGroup.POST("/load-from-csv", ctrl.loadFromCsv)
+			adminGroup.GET("/batches", ctrl.listBatches)
+			adminGroup.GET("/batches/:id", ctrl.getBatchDiff)
+			adminGroup.POST("/batches/:id/rollback", ctrl.rollbackToBatch)
+		}
 	}
 }
 
@@ -82,14 +97,35 @@ func (ctrl *MarginController) reloadAllMargins(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Margins reloaded successfully"})
 }
 
+// getMarginHistory lists how imports changed a symbol's margin.
+// @Summary      Get margin history
+// @Description  Returns the imports that added, removed or changed the margin of a stock symbol, newest first
+// @Tags         Margin
+// @Produce      json
+// @Param        symbol  path      string  true   "Stock Symbol"  example(RELIANCE)
+// @Param        limit   query     int     false  "Max changes to return (default 50)"
+// @Success      200     {array}   model.MarginChange
+// @Failure      500     {object}  map[string]string
+// @Router       /margin/symbol/{symbol}/history [get]
+func (ctrl *MarginController) getMarginHistory(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	changes, err := ctrl.marginService.GetMarginHistory(c.Request.Context(), c.Param("symbol"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
 // loadFromCsv handles CSV file upload for margins.
 // @Summary      Upload Margin CSV
-// @Description  Uploads a CSV file to bulk load or update margin data
+// @Description  Uploads a CSV file to bulk load or update margin data. Symbols missing from the file are removed.
+// @Description  The upload is recorded as a batch with the symbols it added, removed or re-levered.
 // @Tags         Margin
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        file  formData  file  true  "Margin CSV file"
-// @Success      200   {object}  map[string]string
+// @Success      200   {object}  model.MarginBatch
 // @Failure      400   {object}  map[string]string
 // @Failure      500   {object}  map[string]string
 // @Router       /margin/load-from-csv [post]
@@ -107,10 +143,81 @@ func (ctrl *MarginController) loadFromCsv(c *gin.Context) {
 	}
 	defer file.Close()
 
-	if err = ctrl.marginService.LoadFromCsv(c.Request.Context(), fileHeader.Filename, file); err != nil {
+	batch, err := ctrl.marginService.LoadFromCsv(c.Request.Context(), fileHeader.Filename, file, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(marginStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// listBatches lists the margin imports.
+// @Summary      List margin imports
+// @Description  Returns the CSV uploads and rollbacks with their change counts, newest first
+// @Tags         Margin
+// @Produce      json
+// @Param        limit  query     int  false  "Max batches to return (default 50)"
+// @Success      200    {array}   model.MarginBatch
+// @Failure      500    {object}  map[string]string
+// @Router       /margin/batches [get]
+func (ctrl *MarginController) listBatches(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	batches, err := ctrl.marginService.ListBatches(c.Request.Context(), limit)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, batches)
+}
+
+// getBatchDiff returns what a margin import changed.
+// @Summary      Get margin import diff
+// @Description  Returns a margin import with every symbol it added, removed or changed the margin of
+// @Tags         Margin
+// @Produce      json
+// @Param        id   path      string  true  "Batch ID"
+// @Success      200  {object}  model.MarginBatchDiff
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /margin/batches/{id} [get]
+func (ctrl *MarginController) getBatchDiff(c *gin.Context) {
+	diff, err := ctrl.marginService.GetBatchDiff(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(marginStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "CSV data processed successfully"})
+// rollbackToBatch restores the margins of an earlier import.
+// @Summary      Roll back margins
+// @Description  Replaces the margins with the set an earlier import left behind. The rollback is recorded as a new
+// @Description  batch, so it can itself be diffed and rolled back.
+// @Tags         Margin
+// @Produce      json
+// @Param        id   path      string  true  "Batch ID to restore"
+// @Success      200  {object}  model.MarginBatch
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /margin/batches/{id}/rollback [post]
+func (ctrl *MarginController) rollbackToBatch(c *gin.Context) {
+	batch, err := ctrl.marginService.RollbackToBatch(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(marginStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, batch)
+}
+
+// marginStatus maps margin service errors to HTTP status codes.
+func marginStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrMarginBatchNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidMarginImport):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/customerrors"
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationController struct {
+	notificationSvc service.NotificationService
+	isProduction    bool
+}
+
+func NewNotificationController(s service.NotificationService, isProduction bool) *NotificationController {
+	return &NotificationController{notificationSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the authenticated user's notification channels and preferences.
+func (ctrl *NotificationController) RegisterRoutes(router *gin.RouterGroup) {
+	notificationGroup := router.Group("/notifications")
+	notificationGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		notificationGroup.GET("", ctrl.GetSettings)
+		notificationGroup.PUT("/telegram", ctrl.LinkTelegram)
+		notificationGroup.DELETE("/telegram", ctrl.UnlinkTelegram)
+		notificationGroup.PUT("/preferences", ctrl.UpdatePreferences)
+		notificationGroup.POST("/test", ctrl.SendTest)
+	}
+}
+
+// GetSettings godoc
+// @Summary      Notification settings
+// @Description  Returns the linked Telegram chat, the available channels and the channels each event is sent on.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  model.Response{data=model.NotificationSettings}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /notifications [get]
+func (ctrl *NotificationController) GetSettings(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	settings, err := ctrl.notificationSvc.GetSettings(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(notificationStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: settings})
+}
+
+// LinkTelegram godoc
+// @Summary      Link a Telegram chat
+// @Description  Sends a confirmation message to the chat and links it once it got through. The user must have
+// @Description  started a conversation with the bot first.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TelegramLinkRequest  true  "Telegram chat"
+// @Success      200      {object}  model.Response{data=model.NotificationSettings}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      503      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /notifications/telegram [put]
+func (ctrl *NotificationController) LinkTelegram(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.TelegramLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	settings, err := ctrl.notificationSvc.LinkTelegram(c.Request.Context(), user.UserID, req.ChatID)
+	if err != nil {
+		c.JSON(notificationStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Telegram linked", Data: settings})
+}
+
+// UnlinkTelegram godoc
+// @Summary      Unlink Telegram
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  model.Response{data=model.NotificationSettings}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /notifications/telegram [delete]
+func (ctrl *NotificationController) UnlinkTelegram(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	settings, err := ctrl.notificationSvc.UnlinkTelegram(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(notificationStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Telegram unlinked", Data: settings})
+}
+
+// UpdatePreferences godoc
+// @Summary      Route notifications
+// @Description  Sets the channels each event (ALERTS, MITIGATIONS, AUTOMATION) is sent on. Events left out keep their
+// @Description  default: alerts by email, everything else off. An empty list turns an event off.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.NotificationPrefsRequest  true  "Channels per event"
+// @Success      200      {object}  model.Response{data=model.NotificationSettings}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /notifications/preferences [put]
+func (ctrl *NotificationController) UpdatePreferences(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.NotificationPrefsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	settings, err := ctrl.notificationSvc.UpdatePreferences(c.Request.Context(), user.UserID, req.Preferences)
+	if err != nil {
+		c.JSON(notificationStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Preferences updated", Data: settings})
+}
+
+// SendTest godoc
+// @Summary      Send a test notification
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.NotificationTestRequest  true  "Channel"
+// @Success      200      {object}  model.Response
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      503      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /notifications/test [post]
+func (ctrl *NotificationController) SendTest(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.NotificationTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	if err := ctrl.notificationSvc.SendTest(c.Request.Context(), user.UserID, req.Channel); err != nil {
+		c.JSON(notificationStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Test notification sent"})
+}
+
+// notificationStatus maps notification service errors to HTTP status codes.
+func notificationStatus(err error) int {
+	switch {
+	case errors.Is(err, customerrors.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidNotification), errors.Is(err, service.ErrChannelNotLinked):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrChannelNotConfigured):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -5,6 +5,7 @@ import (
 
 	"backend/model"
 	"backend/service"
+	"backend/util"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,12 +27,13 @@ func (ctrl *NseController) RegisterRoutes(router *gin.RouterGroup) {
 		nseGroup.GET("/history", ctrl.GetStockHistory)
 		nseGroup.GET("/heatmap", ctrl.GetHeatMap)
 		nseGroup.GET("/allindices", ctrl.GetAllIndices)
+		nseGroup.GET("/providers", ctrl.GetProviderStatus)
 	}
 }
 
 // GetStockHistory handles historical data requests.
 // @Summary      Get Historical Stock Data
-// @Description  Fetches stock history for a specific symbol. Utilizes a 1-hour time cache.
+// @Description  Fetches stock history for a specific symbol from the market data provider chain. Utilizes a 1-hour time cache.
 // @Tags         Stocks
 // @Accept       json
 // @Produce      json
@@ -56,7 +58,20 @@ func (ctrl *NseController) GetStockHistory(c *gin.Context) {
 		return
 	}
 
-	ctrl.handleSuccess(c, "Fetch Success", data)
+	history := make([]model.NSEHistoricalData, 0, len(data))
+	for _, candle := range data {
+		history = append(history, model.NSEHistoricalData{
+			Symbol:    candle.Symbol,
+			Open:      candle.Open,
+			High:      candle.High,
+			Low:       candle.Low,
+			Close:     candle.Close,
+			Volume:    float64(candle.Volume),
+			Timestamp: candle.Time.In(util.MarketLocation()).Format("02-Jan-2006"),
+		})
+	}
+
+	ctrl.handleSuccess(c, "Fetch Success", history)
 }
 
 // GetHeatMap fetches sectoral performance.
@@ -95,6 +110,17 @@ func (ctrl *NseController) GetAllIndices(c *gin.Context) {
 	ctrl.handleSuccess(c, "Fetch Success", data)
 }
 
+// GetProviderStatus reports the market data provider chain.
+// @Summary      Get Market Data Provider Status
+// @Description  Lists the configured market data providers in fallback order with their circuit breaker state.
+// @Tags         Stocks
+// @Produce      json
+// @Success      200     {object}  model.Response{data=[]model.ProviderStatus}
+// @Router       /nse/providers [get]
+func (ctrl *NseController) GetProviderStatus(c *gin.Context) {
+	ctrl.handleSuccess(c, "Fetch Success", ctrl.nseService.ProviderStatus())
+}
+
 // --- Internal Response Helpers ---
 
 func (ctrl *NseController) handleSuccess(c *gin.Context, message string, data any) {
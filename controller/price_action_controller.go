@@ -1,8 +1,12 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	"backend/cache"
 	"backend/middleware"
@@ -14,11 +18,14 @@ import (
 
 type PriceActionController struct {
 	paService    service.PriceActionService
+	schedulerSvc service.SchedulerService
+	jobSvc       service.JobService
 	isProduction bool
 }
 
-func NewPriceActionController(s service.PriceActionService, isProd bool) *PriceActionController {
-	return &PriceActionController{paService: s, isProduction: isProd}
+func NewPriceActionController(s service.PriceActionService, scheduler service.SchedulerService,
+	jobSvc service.JobService, isProd bool) *PriceActionController {
+	return &PriceActionController{paService: s, schedulerSvc: scheduler, jobSvc: jobSvc, isProduction: isProd}
 }
 
 func (ctrl *PriceActionController) RegisterRoutes(router *gin.RouterGroup) {
@@ -32,10 +39,13 @@ func (ctrl *PriceActionController) RegisterRoutes(router *gin.RouterGroup) {
 		{
 			ob.POST("/check", ctrl.CheckOBMitigation)
 			ob.GET("/mitigation", ctrl.GetOBMitigation)
+			ob.POST("/bearish/check", ctrl.CheckBearishOBMitigation)
+			ob.GET("/bearish/mitigation", ctrl.GetBearishOBMitigation)
 			ob.POST("/old/:stopDate", ctrl.AddOlderObController)
 			admin := ob.Group("")
 			admin.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
 			{
+				admin.POST("/cleanup", ctrl.OrderBlockCleanUp)
 				admin.POST("", ctrl.SaveOrderBlock)
 				admin.PATCH("", ctrl.UpdateOrderBlock)
 				admin.DELETE("", ctrl.DeleteOrderBlock)
@@ -47,11 +57,13 @@ func (ctrl *PriceActionController) RegisterRoutes(router *gin.RouterGroup) {
 		{
 			fvg.POST("/check", ctrl.CheckFvgMitigation)
 			fvg.GET("/mitigation", ctrl.GetFvgMitigation)
+			fvg.POST("/bearish/check", ctrl.CheckBearishFvgMitigation)
+			fvg.GET("/bearish/mitigation", ctrl.GetBearishFvgMitigation)
 			fvg.POST("/old/:stopDate", ctrl.AddOlderFvgController)
-			fvg.POST("/cleanup", ctrl.FvgCleanUp)
 			admin := fvg.Group("")
 			admin.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
 			{
+				admin.POST("/cleanup", ctrl.FvgCleanUp)
 				admin.POST("", ctrl.SaveFvg)
 				admin.PATCH("", ctrl.UpdateFvg)
 				admin.DELETE("", ctrl.DeleteFvg)
@@ -64,26 +76,44 @@ func (ctrl *PriceActionController) RegisterRoutes(router *gin.RouterGroup) {
 
 // TriggerAutomation godoc
 // @Summary      Trigger PA Automation
+// @Description  Runs the OB and FVG automation jobs through the scheduler so retries and run history are kept.
+// @Description  If either is already running, neither is started. Poll the returned jobs through /jobs/{id}.
 // @Tags         PriceAction
-// @Success      202      {object}  model.Response
+// @Success      202      {object}  model.Response{data=[]model.Job}
+// @Failure      409      {object}  model.Response
 // @Router       /price-action/automate [post]
 func (ctrl *PriceActionController) TriggerAutomation(c *gin.Context) {
-	bgCtx := context.Background()
-	go func() {
-		_ = ctrl.paService.AutomateOrderBlock(bgCtx, 0)
-		_ = ctrl.paService.AutomateFvg(bgCtx, 0)
-	}()
-	c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "Scanning started"})
+	// Neither job starts if the other is still running
+	jobs, err := ctrl.schedulerSvc.RunAllNow("ob-automation", "fvg-automation")
+	if err != nil {
+		c.JSON(ctrl.runNowStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "Scanning started", Data: jobs})
 }
 
 // GetPABySymbol godoc
 // @Summary      Get PA by Symbol
 // @Tags         PriceAction
-// @Param        symbol   path      string  true  "Symbol"
-// @Success      200      {object}  model.Response
+// @Param        symbol   path      string  true   "Symbol"
+// @Param        status   query     string  false  "Comma separated zone statuses (FRESH,TESTED,MITIGATED,INVALIDATED)"
+// @Success      200      {object}  model.Response{data=model.StockRecord}
+// @Failure      400      {object}  model.Response
 // @Router       /price-action/{symbol} [get]
 func (ctrl *PriceActionController) GetPABySymbol(c *gin.Context) {
-	data, err := ctrl.paService.GetPABySymbol(c.Request.Context(), c.Param("symbol"))
+	var statuses []model.ZoneStatus
+	if raw := c.Query("status"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			status := model.ZoneStatus(strings.ToUpper(strings.TrimSpace(part)))
+			if !status.IsValid() {
+				c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid status: " + part})
+				return
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	data, err := ctrl.paService.GetPABySymbol(c.Request.Context(), c.Param("symbol"), statuses)
 	ctrl.respond(c, data, err)
 }
 
@@ -91,14 +121,15 @@ func (ctrl *PriceActionController) GetPABySymbol(c *gin.Context) {
 
 // SaveOrderBlock godoc
 // @Summary      Save OB
+// @Description  Stores a new order block. A stored one is refused with 409 so its lifecycle is kept; update it instead.
 // @Tags         PriceAction (Admin)
 // @Param        request  body      model.ObRequest  true  "OB Details"
 // @Success      200      {object}  model.Response
+// @Failure      409      {object}  model.Response
 // @Router       /price-action/ob [post]
 func (ctrl *PriceActionController) SaveOrderBlock(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
 	err := ctrl.paService.SaveOrderBlock(c.Request.Context(), req)
@@ -112,9 +143,8 @@ func (ctrl *PriceActionController) SaveOrderBlock(c *gin.Context) {
 // @Success      200      {object}  model.Response
 // @Router       /price-action/ob [patch]
 func (ctrl *PriceActionController) UpdateOrderBlock(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
 	err := ctrl.paService.UpdateOrderBlock(c.Request.Context(), req)
@@ -128,11 +158,11 @@ func (ctrl *PriceActionController) UpdateOrderBlock(c *gin.Context) {
 // @Success      200      {object}  model.Response
 // @Router       /price-action/ob [delete]
 func (ctrl *PriceActionController) DeleteOrderBlock(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
-	err := ctrl.paService.DeleteOrderBlock(c.Request.Context(), req.Symbol, req.Date)
+	err := ctrl.paService.DeleteOrderBlock(c.Request.Context(), req.Symbol, req.Date, req.Timeframe, req.Direction)
 	ctrl.respond(c, nil, err)
 }
 
@@ -141,11 +171,30 @@ func (ctrl *PriceActionController) DeleteOrderBlock(c *gin.Context) {
 // @Tags         PriceAction
 // @Router       /price-action/ob/mitigation [get]
 func (ctrl *PriceActionController) GetOBMitigation(c *gin.Context) {
-	if val, exists := cache.PriceActionCache.Get("ObCache"); exists {
-		c.JSON(http.StatusOK, model.Response{Success: true, Data: val})
-		return
-	}
-	data, err := ctrl.paService.CheckOBMitigation(c.Request.Context())
+	ctrl.cachedMitigation(c, "ObCache", ctrl.paService.CheckOBMitigation)
+}
+
+// GetBearishOBMitigation godoc
+// @Summary      Get Cached Bearish OB Mitigations
+// @Description  Returns stocks trading up into a bearish order block, from cache when available.
+// @Tags         PriceAction
+// @Produce      json
+// @Success      200      {object}  model.Response{data=[]model.ObResponse}
+// @Router       /price-action/ob/bearish/mitigation [get]
+func (ctrl *PriceActionController) GetBearishOBMitigation(c *gin.Context) {
+	ctrl.cachedMitigation(c, "BearishObCache", ctrl.paService.CheckBearishOBMitigation)
+}
+
+// CheckBearishOBMitigation godoc
+// @Summary      Force Refresh Bearish OB Mitigations
+// @Description  Triggers a fresh scan of the bearish mitigation strategy's stocks (mitigation.bearishStrategy, default BEARISH CLOSE 200) against saved bearish Order Blocks.
+// @Tags         PriceAction
+// @Produce      json
+// @Success      200      {object}  model.Response{data=[]model.ObResponse}
+// @Failure      500      {object}  model.Response
+// @Router       /price-action/ob/bearish/check [post]
+func (ctrl *PriceActionController) CheckBearishOBMitigation(c *gin.Context) {
+	data, err := ctrl.paService.CheckBearishOBMitigation(c.Request.Context(), false)
 	ctrl.respond(c, data, err)
 }
 
@@ -158,7 +207,7 @@ func (ctrl *PriceActionController) GetOBMitigation(c *gin.Context) {
 // @Failure      500      {object}  model.Response
 // @Router       /price-action/ob/check [post]
 func (ctrl *PriceActionController) CheckOBMitigation(c *gin.Context) {
-	data, err := ctrl.paService.CheckOBMitigation(c.Request.Context())
+	data, err := ctrl.paService.CheckOBMitigation(c.Request.Context(), false)
 	ctrl.respond(c, data, err)
 }
 
@@ -166,14 +215,15 @@ func (ctrl *PriceActionController) CheckOBMitigation(c *gin.Context) {
 
 // SaveFvg godoc
 // @Summary      Save FVG
+// @Description  Stores a new FVG. A stored one is refused with 409 so its lifecycle is kept; update it instead.
 // @Tags         PriceAction (Admin)
 // @Param        request  body      model.ObRequest  true  "FVG Details"
 // @Success      200      {object}  model.Response
+// @Failure      409      {object}  model.Response
 // @Router       /price-action/fvg [post]
 func (ctrl *PriceActionController) SaveFvg(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
 	err := ctrl.paService.SaveFvg(c.Request.Context(), req)
@@ -187,9 +237,8 @@ func (ctrl *PriceActionController) SaveFvg(c *gin.Context) {
 // @Success      200      {object}  model.Response
 // @Router       /price-action/fvg [patch]
 func (ctrl *PriceActionController) UpdateFvg(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
 	err := ctrl.paService.UpdateFvg(c.Request.Context(), req)
@@ -203,11 +252,11 @@ func (ctrl *PriceActionController) UpdateFvg(c *gin.Context) {
 // @Success      200      {object}  model.Response
 // @Router       /price-action/fvg [delete]
 func (ctrl *PriceActionController) DeleteFvg(c *gin.Context) {
-	var req model.ObRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	req, ok := ctrl.bindZone(c)
+	if !ok {
 		return
 	}
-	err := ctrl.paService.DeleteFvg(c.Request.Context(), req.Symbol, req.Date)
+	err := ctrl.paService.DeleteFvg(c.Request.Context(), req.Symbol, req.Date, req.Timeframe, req.Direction)
 	ctrl.respond(c, nil, err)
 }
 
@@ -216,11 +265,30 @@ func (ctrl *PriceActionController) DeleteFvg(c *gin.Context) {
 // @Tags         PriceAction
 // @Router       /price-action/fvg/mitigation [get]
 func (ctrl *PriceActionController) GetFvgMitigation(c *gin.Context) {
-	if val, exists := cache.PriceActionCache.Get("FvgCache"); exists {
-		c.JSON(http.StatusOK, model.Response{Success: true, Data: val})
-		return
-	}
-	data, err := ctrl.paService.CheckFvgMitigation(c.Request.Context())
+	ctrl.cachedMitigation(c, "FvgCache", ctrl.paService.CheckFvgMitigation)
+}
+
+// GetBearishFvgMitigation godoc
+// @Summary      Get Cached Bearish FVG Mitigations
+// @Description  Returns stocks trading up into a bearish Fair Value Gap, from cache when available.
+// @Tags         PriceAction
+// @Produce      json
+// @Success      200      {object}  model.Response{data=[]model.ObResponse}
+// @Router       /price-action/fvg/bearish/mitigation [get]
+func (ctrl *PriceActionController) GetBearishFvgMitigation(c *gin.Context) {
+	ctrl.cachedMitigation(c, "BearishFvgCache", ctrl.paService.CheckBearishFvgMitigation)
+}
+
+// CheckBearishFvgMitigation godoc
+// @Summary      Force Refresh Bearish FVG Mitigations
+// @Description  Triggers a fresh scan of the bearish mitigation strategy's stocks (mitigation.bearishStrategy, default BEARISH CLOSE 200) against saved bearish Fair Value Gaps.
+// @Tags         PriceAction
+// @Produce      json
+// @Success      200      {object}  model.Response{data=[]model.ObResponse}
+// @Failure      500      {object}  model.Response
+// @Router       /price-action/fvg/bearish/check [post]
+func (ctrl *PriceActionController) CheckBearishFvgMitigation(c *gin.Context) {
+	data, err := ctrl.paService.CheckBearishFvgMitigation(c.Request.Context(), false)
 	ctrl.respond(c, data, err)
 }
 
@@ -233,7 +301,7 @@ func (ctrl *PriceActionController) GetFvgMitigation(c *gin.Context) {
 // @Failure      500      {object}  model.Response
 // @Router       /price-action/fvg/check [post]
 func (ctrl *PriceActionController) CheckFvgMitigation(c *gin.Context) {
-	data, err := ctrl.paService.CheckFvgMitigation(c.Request.Context())
+	data, err := ctrl.paService.CheckFvgMitigation(c.Request.Context(), false)
 	ctrl.respond(c, data, err)
 }
 
@@ -241,23 +309,60 @@ func (ctrl *PriceActionController) CheckFvgMitigation(c *gin.Context) {
 
 func (ctrl *PriceActionController) respond(c *gin.Context, data any, err error) {
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrZoneExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.Response{Success: false, Error: err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, model.Response{Success: true, Data: data})
 }
 
-// AddOlderObController
-// @Summary      Process Historical Order Blocks
-// @Description  Upload a CSV to find and save Order Blocks from a specific stop date backwards.
-// @Tags         PriceAction
-// @Accept       multipart/form-data
-// @Produce      json
-// @Param        file      formData  file    true  "CSV File"
-// @Param        stopDate  path      string  true  "Stop Date (YYYY-MM-DD)"
-// @Success      200       {object}  map[string]string
-// @Router       /price-action/ob/old/{stopDate} [post]
-func (pc *PriceActionController) AddOlderObController(c *gin.Context) {
+// cachedMitigation serves a mitigation list from cache, computing it on a miss.
+func (ctrl *PriceActionController) cachedMitigation(c *gin.Context, cacheKey string,
+	check func(ctx context.Context, record bool) ([]model.ObResponse, error)) {
+	if val, exists := cache.PriceActionCache.Get(cacheKey); exists {
+		c.JSON(http.StatusOK, model.Response{Success: true, Data: val})
+		return
+	}
+	// Reads never change zone lifecycles; the scheduled checks record them
+	data, err := check(c.Request.Context(), false)
+	ctrl.respond(c, data, err)
+}
+
+// bindZone reads an OB/FVG request body, defaulting the timeframe to 1D and the direction to BULLISH.
+// The date, timeframe and direction identify a zone, as a bullish and a bearish one can share a date.
+func (ctrl *PriceActionController) bindZone(c *gin.Context) (model.ObRequest, bool) {
+	var req model.ObRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return req, false
+	}
+	req.Timeframe = req.Timeframe.OrDefault()
+	if !req.Timeframe.IsValid() {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid timeframe: " + string(req.Timeframe)})
+		return req, false
+	}
+	req.Direction = req.Direction.OrDefault()
+	if !req.Direction.IsValid() {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid direction: " + string(req.Direction)})
+		return req, false
+	}
+	return req, true
+}
+
+func (ctrl *PriceActionController) runNowStatus(err error) int {
+	if errors.Is(err, service.ErrJobAlreadyActive) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// submitCsvJob buffers the uploaded CSV and processes it in a background job.
+// The request body is gone once the handler returns, so the file must be read up front.
+func (ctrl *PriceActionController) submitCsvJob(c *gin.Context, jobType string,
+	process func(ctx context.Context, fileName string, file io.Reader, stopDate string, tracker *service.JobTracker) error) {
 	stopDate := c.Param("stopDate")
 	fileHeader, err := c.FormFile("file")
 	if err != nil {
@@ -272,10 +377,37 @@ func (pc *PriceActionController) AddOlderObController(c *gin.Context) {
 	}
 	defer file.Close()
 
-	// Using your service logic
-	pc.paService.AddOlderOb(c.Request.Context(), fileHeader.Filename, file, stopDate)
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Order Block processing completed"})
+	fileName := fileHeader.Filename
+	job, err := ctrl.jobSvc.Submit(jobType, model.TriggerManual, func(ctx context.Context, tracker *service.JobTracker) error {
+		return process(ctx, fileName, bytes.NewReader(content), stopDate, tracker)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "Processing started", Data: job})
+}
+
+// AddOlderObController
+// @Summary      Process Historical Order Blocks
+// @Description  Upload a CSV to find and save Order Blocks from a specific stop date backwards.
+// @Tags         PriceAction
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file      formData  file    true  "CSV File"
+// @Param        stopDate  path      string  true  "Stop Date (YYYY-MM-DD)"
+// @Success      202       {object}  model.Response{data=model.Job}
+// @Failure      400       {object}  map[string]string
+// @Router       /price-action/ob/old/{stopDate} [post]
+func (pc *PriceActionController) AddOlderObController(c *gin.Context) {
+	pc.submitCsvJob(c, "add-older-ob", pc.paService.AddOlderOb)
 }
 
 // AddOlderFvgController
@@ -286,51 +418,47 @@ func (pc *PriceActionController) AddOlderObController(c *gin.Context) {
 // @Produce      json
 // @Param        file      formData  file    true  "CSV File"
 // @Param        stopDate  path      string  true  "Stop Date (YYYY-MM-DD)"
-// @Success      200       {object}  map[string]string
+// @Success      202       {object}  model.Response{data=model.Job}
+// @Failure      400       {object}  map[string]string
 // @Router       /price-action/fvg/old/{stopDate} [post]
 func (pc *PriceActionController) AddOlderFvgController(c *gin.Context) {
-	stopDate := c.Param("stopDate")
-	fileHeader, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file required"})
-		return
-	}
+	pc.submitCsvJob(c, "add-older-fvg", pc.paService.AddOlderFvg)
+}
 
-	file, err := fileHeader.Open()
+// FvgCleanUp handles the removal of mitigated/filled Fair Value Gaps
+// @Summary      Refresh FVG lifecycles
+// @Description  Starts a background maintenance job that replays candle history for all stored symbols and marks active FVGs as tested, mitigated or invalidated. Nothing is deleted.
+// @Tags         PriceAction
+// @Produce      json
+// @Success      202 {object} model.Response{data=model.Job}
+// @Failure      409 {object} model.Response
+// @Failure      500 {object} model.Response
+// @Router       /price-action/fvg/cleanup [post]
+func (pc *PriceActionController) FvgCleanUp(c *gin.Context) {
+	job, err := pc.schedulerSvc.RunNow("fvg-cleanup")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
+		c.JSON(pc.runNowStatus(err), model.Response{Success: false, Error: "Failed to start FVG cleanup: " + err.Error()})
 		return
 	}
-	defer file.Close()
-
-	// Using your service logic
-	pc.paService.AddOlderFvg(c.Request.Context(), fileHeader.Filename, file, stopDate)
 
-	c.JSON(http.StatusOK, gin.H{"message": "FVG processing completed"})
+	c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "FVG cleanup started", Data: job})
 }
 
-// FvgCleanUp handles the removal of mitigated/filled Fair Value Gaps
-// @Summary      Clean up filled FVGs
-// @Description  Triggers a maintenance task that fetches NSE history for all stored symbols and deletes FVGs that have been breached or filled by subsequent price action.
+// OrderBlockCleanUp refreshes order block lifecycle states
+// @Summary      Refresh order block lifecycles
+// @Description  Starts a background maintenance job that replays candle history for all stored symbols and marks active order blocks as tested, mitigated or invalidated. Nothing is deleted.
 // @Tags         PriceAction
 // @Produce      json
-// @Success      200 {object} map[string]string
-// @Failure      500 {object} map[string]string
-// @Router       /price-action/fvg/cleanup [post]
-func (pc *PriceActionController) FvgCleanUp(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	err := pc.paService.FvgCleanUp(ctx)
+// @Success      202 {object} model.Response{data=model.Job}
+// @Failure      409 {object} model.Response
+// @Failure      500 {object} model.Response
+// @Router       /price-action/ob/cleanup [post]
+func (pc *PriceActionController) OrderBlockCleanUp(c *gin.Context) {
+	job, err := pc.schedulerSvc.RunNow("ob-cleanup")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "error",
-			"message": "Failed to complete FVG cleanup: " + err.Error(),
-		})
+		c.JSON(pc.runNowStatus(err), model.Response{Success: false, Error: "Failed to start order block cleanup: " + err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"message": "FVG cleanup task executed successfully",
-	})
+	c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "Order block cleanup started", Data: job})
 }
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScanController struct {
+	scanSvc service.ScanService
+}
+
+func NewScanController(s service.ScanService) *ScanController {
+	return &ScanController{scanSvc: s}
+}
+
+// RegisterRoutes sets up the stored scan snapshots alongside the live ChartInk endpoints. Like those, signed-in
+// users also see their private strategies.
+func (ctrl *ScanController) RegisterRoutes(router *gin.RouterGroup) {
+	scanGroup := router.Group("/scans")
+	scanGroup.Use(middleware.OptionalAuth())
+	{
+		scanGroup.GET("/snapshots", ctrl.ListSnapshots)
+		scanGroup.GET("/snapshots/:id", ctrl.GetSnapshot)
+		scanGroup.GET("/diff", ctrl.Diff)
+		scanGroup.GET("/confluence", ctrl.Confluence)
+	}
+}
+
+// ListSnapshots godoc
+// @Summary      List scan snapshots
+// @Description  Returns a strategy's stored scan results without their stocks, newest first. A snapshot is stored
+// @Description  by the first scan of each day and whenever a scan returns a different set of symbols than the one
+// @Description  before it.
+// @Tags         Scans
+// @Produce      json
+// @Param        strategy  query     string  true   "Strategy name"  example(BULLISH OB 1D)
+// @Param        limit     query     int     false  "Max snapshots to return (default 50)"
+// @Success      200       {object}  model.Response{data=[]model.ScanSnapshot}
+// @Failure      400       {object}  model.Response
+// @Failure      500       {object}  model.Response
+// @Router       /scans/snapshots [get]
+func (ctrl *ScanController) ListSnapshots(c *gin.Context) {
+	strategy := c.Query("strategy")
+	if strategy == "" {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "strategy query parameter is required"})
+		return
+	}
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	snapshots, err := ctrl.scanSvc.ListSnapshots(c.Request.Context(), strategy, limit, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: snapshots})
+}
+
+// GetSnapshot godoc
+// @Summary      Get a scan snapshot
+// @Description  Returns a stored scan result with its stocks.
+// @Tags         Scans
+// @Produce      json
+// @Param        id   path      string  true  "Snapshot ID"
+// @Success      200  {object}  model.Response{data=model.ScanSnapshot}
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /scans/snapshots/{id} [get]
+func (ctrl *ScanController) GetSnapshot(c *gin.Context) {
+	snapshot, err := ctrl.scanSvc.GetSnapshot(c.Request.Context(), c.Param("id"), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(scanStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: snapshot})
+}
+
+// Diff godoc
+// @Summary      Diff two scan snapshots
+// @Description  Lists the stocks that entered and exited a strategy's scan between two snapshots. to defaults to
+// @Description  the latest snapshot and from to the last snapshot of an earlier day, i.e. today versus yesterday.
+// @Tags         Scans
+// @Produce      json
+// @Param        strategy  query     string  true   "Strategy name"  example(BULLISH OB 1D)
+// @Param        from      query     string  false  "Older snapshot ID"
+// @Param        to        query     string  false  "Newer snapshot ID"
+// @Success      200       {object}  model.Response{data=model.ScanDiff}
+// @Failure      400       {object}  model.Response
+// @Failure      404       {object}  model.Response
+// @Failure      500       {object}  model.Response
+// @Router       /scans/diff [get]
+func (ctrl *ScanController) Diff(c *gin.Context) {
+	strategy := c.Query("strategy")
+	if strategy == "" {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "strategy query parameter is required"})
+		return
+	}
+
+	diff, err := ctrl.scanSvc.Diff(c.Request.Context(), strategy, c.Query("from"), c.Query("to"), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(scanStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: diff})
+}
+
+// Confluence godoc
+// @Summary      Multi-strategy confluence
+// @Description  Runs several active strategies concurrently and ranks the margin stocks returned by more than one of
+// @Description  them, most matches first and then by margin, with each stock's fresh or tested order blocks and FVGs.
+// @Description  A strategy whose scan fails is listed under failed and counts as not matching.
+// @Tags         Scans
+// @Produce      json
+// @Param        strategies  query     string  false  "Comma separated strategy names (default all active)"  example(BULLISH OB 1D,BULLISH CLOSE 200)
+// @Param        min         query     int     false  "Minimum strategies a stock must match (default 2)"
+// @Success      200         {object}  model.Response{data=model.Confluence}
+// @Failure      400         {object}  model.Response
+// @Failure      500         {object}  model.Response
+// @Router       /scans/confluence [get]
+func (ctrl *ScanController) Confluence(c *gin.Context) {
+	var names []string
+	if raw := c.Query("strategies"); raw != "" {
+		names = strings.Split(raw, ",")
+	}
+	minMatches, _ := strconv.Atoi(c.Query("min"))
+
+	confluence, err := ctrl.scanSvc.Confluence(c.Request.Context(), names, minMatches, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(scanStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: confluence})
+}
+
+// scanStatus maps scan service errors to HTTP status codes.
+func scanStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSnapshotNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidScanDiff), errors.Is(err, service.ErrInvalidStrategy):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SchedulerController struct {
+	schedulerSvc service.SchedulerService
+	isProduction bool
+}
+
+func NewSchedulerController(s service.SchedulerService, isProduction bool) *SchedulerController {
+	return &SchedulerController{schedulerSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up admin-only endpoints to inspect and trigger scheduled jobs.
+func (ctrl *SchedulerController) RegisterRoutes(router *gin.RouterGroup) {
+	schedulerGroup := router.Group("/scheduler")
+	schedulerGroup.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
+	{
+		schedulerGroup.GET("/jobs", ctrl.ListJobs)
+		schedulerGroup.GET("/runs", ctrl.ListRuns)
+		schedulerGroup.POST("/jobs/:name/run", ctrl.RunNow)
+	}
+}
+
+// ListJobs godoc
+// @Summary      List scheduled jobs
+// @Description  Returns every registered job with its cron schedule, next fire time (IST) and latest run.
+// @Tags         Scheduler
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.ScheduledJobDto}
+// @Failure      500  {object}  model.Response
+// @Router       /scheduler/jobs [get]
+func (ctrl *SchedulerController) ListJobs(c *gin.Context) {
+	jobs, err := ctrl.schedulerSvc.ListJobs(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: jobs})
+}
+
+// ListRuns godoc
+// @Summary      List job run history
+// @Description  Returns persisted job runs, newest first. Optionally filtered by job name.
+// @Tags         Scheduler
+// @Produce      json
+// @Param        job    query     string  false  "Job name"  example(ob-automation)
+// @Param        limit  query     int     false  "Max runs to return (default 50)"
+// @Success      200    {object}  model.Response{data=[]model.SchedulerRun}
+// @Failure      500    {object}  model.Response
+// @Router       /scheduler/runs [get]
+func (ctrl *SchedulerController) ListRuns(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	runs, err := ctrl.schedulerSvc.ListRuns(c.Request.Context(), c.Query("job"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: runs})
+}
+
+// RunNow godoc
+// @Summary      Run a job now
+// @Description  Triggers a scheduled job immediately in the background.
+// @Tags         Scheduler
+// @Produce      json
+// @Param        name  path      string  true  "Job name"  example(fvg-cleanup)
+// @Success      202   {object}  model.Response{data=model.Job}
+// @Failure      404   {object}  model.Response
+// @Failure      409   {object}  model.Response
+// @Router       /scheduler/jobs/{name}/run [post]
+func (ctrl *SchedulerController) RunNow(c *gin.Context) {
+	job, err := ctrl.schedulerSvc.RunNow(c.Param("name"))
+	switch {
+	case errors.Is(err, service.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, model.Response{Success: false, Error: err.Error()})
+	case errors.Is(err, service.ErrJobAlreadyActive):
+		c.JSON(http.StatusConflict, model.Response{Success: false, Error: err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+	default:
+		c.JSON(http.StatusAccepted, model.Response{Success: true, Message: "Job started", Data: job})
+	}
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SizingController struct {
+	sizingSvc    service.SizingService
+	isProduction bool
+}
+
+func NewSizingController(s service.SizingService, isProduction bool) *SizingController {
+	return &SizingController{sizingSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the position sizing calculator, which reads the user's default capital and risk.
+func (ctrl *SizingController) RegisterRoutes(router *gin.RouterGroup) {
+	sizingGroup := router.Group("/sizing")
+	sizingGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		sizingGroup.POST("", ctrl.Size)
+	}
+}
+
+// Size godoc
+// @Summary      Size a position
+// @Description  Returns the quantity whose loss at the stop stays within riskPct of capital, capped by what the
+// @Description  capital can fund at the symbol's margin. Capital and risk default to the user's profile; the stop
+// @Description  defaults to the far side of an order block or FVG.
+// @Tags         Trades
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.SizingRequest  true  "Sizing inputs"
+// @Success      200      {object}  model.Response{data=model.SizingResult}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /sizing [post]
+func (ctrl *SizingController) Size(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.SizingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	result, err := ctrl.sizingSvc.Size(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidSizing):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrZoneNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: result})
+}
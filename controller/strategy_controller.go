@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"backend/middleware"
 	"backend/model"
@@ -26,8 +28,21 @@ func NewStrategyController(ss service.StrategyService, isProduction bool) *Strat
 func (ctrl *StrategyController) RegisterRoutes(router *gin.RouterGroup) {
 	strategyGroup := router.Group("/strategy")
 	{
-		// Public route - typically used by the scanner dashboard
-		strategyGroup.GET("", ctrl.getAllStrategies)
+		// Public route - typically used by the scanner dashboard, signed-in users also see their private strategies
+		strategyGroup.GET("", middleware.OptionalAuth(), ctrl.getAllStrategies)
+
+		// User routes - strategies owned by the signed-in user
+		userGroup := strategyGroup.Group("")
+		userGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+		{
+			userGroup.GET("/mine", ctrl.getUserStrategies)
+			userGroup.POST("/mine", ctrl.saveUserStrategy)
+			userGroup.PUT("/mine", ctrl.saveUserStrategy)
+			userGroup.DELETE("/mine", ctrl.deleteUserStrategy)
+			userGroup.POST("/preview", ctrl.previewScanClause)
+			// Owners share their strategies here; admins can change any strategy's visibility
+			userGroup.PUT("/sharing", ctrl.shareStrategy)
+		}
 
 		// Protected routes - requires Admin role and JWT
 		adminGroup := strategyGroup.Group("")
@@ -38,19 +53,22 @@ func (ctrl *StrategyController) RegisterRoutes(router *gin.RouterGroup) {
 			adminGroup.DELETE("", ctrl.deleteStrategy)
 			adminGroup.POST("/reload", ctrl.reloadAllStrategies)
 			adminGroup.GET("/admin", ctrl.getAllStrategiesAdmin)
+			adminGroup.GET("/versions", ctrl.listVersions)
+			adminGroup.POST("/versions/restore", ctrl.restoreVersion)
 		}
 	}
 }
 
 // getAllStrategies retrieves active trading strategies.
 // @Summary      Get all strategies
-// @Description  Returns a list of all configured active trading strategies
+// @Description  Returns the active public strategies, plus for a signed-in user the ones they own or that are
+// @Description  shared with them
 // @Tags         Strategy
 // @Produce      json
 // @Success      200  {array}  model.StrategyDto
 // @Router       /strategy [get]
 func (ctrl *StrategyController) getAllStrategies(c *gin.Context) {
-	strategies := ctrl.strategyService.GetAllStrategies()
+	strategies := ctrl.strategyService.GetAllStrategies(middleware.GetUserID(c))
 	if strategies == nil {
 		c.JSON(http.StatusOK, []model.StrategyDto{})
 		return
@@ -60,12 +78,14 @@ func (ctrl *StrategyController) getAllStrategies(c *gin.Context) {
 
 // createStrategy adds a new strategy.
 // @Summary      Create a strategy
-// @Description  Saves a new trading strategy configuration to MongoDB
+// @Description  Saves a new trading strategy configuration to MongoDB and records its scan clause as version 1.
+// @Description  The caller becomes its owner.
 // @Tags         Strategy
 // @Accept       json
 // @Produce      json
-// @Param        request  body      model.StrategyDto  true  "Strategy Details"
-// @Success      201      {object}  model.Strategy
+// @Param        request   body      model.StrategyDto  true   "Strategy Details"
+// @Param        validate  query     bool               false  "Dry run the scan clause first and refuse to activate it if it fails"
+// @Success      201      {object}  model.StrategyDto
 // @Failure      400      {object}  map[string]string
 // @Router       /strategy [post]
 func (ctrl *StrategyController) createStrategy(c *gin.Context) {
@@ -75,9 +95,16 @@ func (ctrl *StrategyController) createStrategy(c *gin.Context) {
 		return
 	}
 
-	res, err := ctrl.strategyService.CreateStrategy(c.Request.Context(), request)
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	validate, _ := strconv.ParseBool(c.Query("validate"))
+	res, err := ctrl.strategyService.CreateStrategy(c.Request.Context(), request, user.UserID, validate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(strategyStatus(err), strategyErrorBody(err))
 		return
 	}
 	c.JSON(http.StatusCreated, res)
@@ -85,12 +112,15 @@ func (ctrl *StrategyController) createStrategy(c *gin.Context) {
 
 // updateStrategy modifies an existing strategy.
 // @Summary      Update a strategy
-// @Description  Updates an existing strategy configuration by name/ID
+// @Description  Updates an existing strategy configuration by name/ID. Changing the scan clause records a new
+// @Description  version; owner and creation time are kept.
 // @Tags         Strategy
 // @Accept       json
 // @Produce      json
-// @Param        request  body      model.StrategyDto  true  "Updated Details"
-// @Success      200      {object}  model.Strategy
+// @Param        request   body      model.StrategyDto  true   "Updated Details"
+// @Param        validate  query     bool               false  "Dry run the scan clause first and refuse to activate it if it fails"
+// @Success      200      {object}  model.StrategyDto
+// @Failure      400      {object}  map[string]string
 // @Router       /strategy [put]
 func (ctrl *StrategyController) updateStrategy(c *gin.Context) {
 	var request model.StrategyDto
@@ -99,9 +129,16 @@ func (ctrl *StrategyController) updateStrategy(c *gin.Context) {
 		return
 	}
 
-	res, err := ctrl.strategyService.UpdateStrategy(c.Request.Context(), request)
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	validate, _ := strconv.ParseBool(c.Query("validate"))
+	res, err := ctrl.strategyService.UpdateStrategy(c.Request.Context(), request, user.UserID, validate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(strategyStatus(err), strategyErrorBody(err))
 		return
 	}
 	c.JSON(http.StatusOK, res)
@@ -158,3 +195,237 @@ func (ctrl *StrategyController) getAllStrategiesAdmin(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, strategies)
 }
+
+// getUserStrategies retrieves the caller's own strategies.
+// @Summary      Get my strategies
+// @Description  Returns every strategy the signed-in user owns, including inactive ones
+// @Tags         Strategy
+// @Produce      json
+// @Success      200  {array}   model.StrategyDto
+// @Failure      401  {object}  map[string]string
+// @Router       /strategy/mine [get]
+func (ctrl *StrategyController) getUserStrategies(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+	c.JSON(http.StatusOK, ctrl.strategyService.GetUserStrategies(user.UserID))
+}
+
+// saveUserStrategy creates or updates one of the caller's strategies.
+// @Summary      Save my strategy
+// @Description  Creates a private strategy owned by the signed-in user, or updates one they own. Names are unique
+// @Description  across all users. Visibility is changed through /strategy/sharing.
+// @Tags         Strategy
+// @Accept       json
+// @Produce      json
+// @Param        request   body      model.StrategyDto  true   "Strategy Details"
+// @Param        validate  query     bool               false  "Dry run the scan clause first and refuse to activate it if it fails"
+// @Success      200      {object}  model.StrategyDto
+// @Failure      400      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Router       /strategy/mine [post]
+// @Router       /strategy/mine [put]
+func (ctrl *StrategyController) saveUserStrategy(c *gin.Context) {
+	var request model.StrategyDto
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	validate, _ := strconv.ParseBool(c.Query("validate"))
+	res, err := ctrl.strategyService.SaveUserStrategy(c.Request.Context(), request, user.UserID, validate)
+	if err != nil {
+		c.JSON(strategyStatus(err), strategyErrorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// deleteUserStrategy removes one of the caller's strategies.
+// @Summary      Delete my strategy
+// @Description  Removes a strategy the signed-in user owns
+// @Tags         Strategy
+// @Param        id   query     string  true  "Strategy ID (Name)"
+// @Success      204  "No Content"
+// @Failure      404  {object}  map[string]string
+// @Router       /strategy/mine [delete]
+func (ctrl *StrategyController) deleteUserStrategy(c *gin.Context) {
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Strategy ID is required"})
+		return
+	}
+
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	if err := ctrl.strategyService.DeleteUserStrategy(c.Request.Context(), id, user.UserID); err != nil {
+		c.JSON(strategyStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// previewScanClause dry runs a scan clause.
+// @Summary      Preview a scan clause
+// @Description  Runs a scan clause on ChartInk without saving it, returning the match count and a sample of matches,
+// @Description  or the error ChartInk reported for the clause
+// @Tags         Strategy
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.ScanPreviewRequest  true  "Scan clause"
+// @Success      200      {object}  model.ScanPreview
+// @Failure      400      {object}  map[string]string
+// @Failure      502      {object}  map[string]string
+// @Router       /strategy/preview [post]
+func (ctrl *StrategyController) previewScanClause(c *gin.Context) {
+	var request model.ScanPreviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	preview, err := ctrl.strategyService.PreviewScanClause(c.Request.Context(), request.ScanClause)
+	if err != nil {
+		c.JSON(strategyStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
+
+// shareStrategy changes who can see a strategy.
+// @Summary      Share a strategy
+// @Description  Makes a strategy PRIVATE, SHARED with the users whose emails are listed, or PUBLIC. Only its owner
+// @Description  or an admin can change it.
+// @Tags         Strategy
+// @Accept       json
+// @Produce      json
+// @Param        id       query     string                        true  "Strategy ID (Name)"
+// @Param        request  body      model.StrategySharingRequest  true  "Visibility"
+// @Success      200      {object}  model.StrategyDto
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Router       /strategy/sharing [put]
+func (ctrl *StrategyController) shareStrategy(c *gin.Context) {
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Strategy ID is required"})
+		return
+	}
+
+	var request model.StrategySharingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	res, err := ctrl.strategyService.ShareStrategy(c.Request.Context(), id, request, user.UserID,
+		user.Role == model.RoleAdmin)
+	if err != nil {
+		c.JSON(strategyStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// listVersions retrieves a strategy's scan clause history.
+// @Summary      List strategy versions
+// @Description  Returns every recorded scan clause of a strategy, newest first
+// @Tags         Strategy
+// @Produce      json
+// @Param        id   query     string  true  "Strategy ID (Name)"
+// @Success      200  {array}   model.StrategyVersion
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Router       /strategy/versions [get]
+func (ctrl *StrategyController) listVersions(c *gin.Context) {
+	id := c.Query("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Strategy ID is required"})
+		return
+	}
+
+	versions, err := ctrl.strategyService.ListVersions(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(strategyStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+// restoreVersion rolls a strategy back to an earlier scan clause.
+// @Summary      Restore a strategy version
+// @Description  Makes an earlier scan clause current again. The restore is recorded as a new version, so it can
+// @Description  itself be rolled back. An active strategy is refused if the restored clause fails its dry run.
+// @Tags         Strategy
+// @Produce      json
+// @Param        id       query     string  true  "Strategy ID (Name)"
+// @Param        version  query     int     true  "Version to restore"
+// @Success      200      {object}  model.StrategyDto
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      502      {object}  map[string]string
+// @Router       /strategy/versions/restore [post]
+func (ctrl *StrategyController) restoreVersion(c *gin.Context) {
+	id := c.Query("id")
+	version, err := strconv.Atoi(c.Query("version"))
+	if id == "" || err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Strategy ID and a positive version are required"})
+		return
+	}
+
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User session not found"})
+		return
+	}
+
+	res, err := ctrl.strategyService.RestoreVersion(c.Request.Context(), id, version, user.UserID)
+	if err != nil {
+		c.JSON(strategyStatus(err), strategyErrorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// strategyStatus maps strategy service errors to HTTP status codes.
+func strategyStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrStrategyNotFound), errors.Is(err, service.ErrStrategyVersionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidStrategy):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrStrategyExists):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrScanClauseUnchecked):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// strategyErrorBody adds the failed dry run to the error of a strategy refused for its scan clause.
+func strategyErrorBody(err error) gin.H {
+	body := gin.H{"error": err.Error()}
+	var clauseErr *service.ScanClauseError
+	if errors.As(err, &clauseErr) {
+		body["preview"] = clauseErr.Preview
+	}
+	return body
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/events"
+	"backend/middleware"
+	"backend/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	streamBuffer    = 64
+	streamHeartbeat = 25 * time.Second
+	// streamLifetime closes long-lived streams so the browser reconnects and the auth cookie is checked again
+	streamLifetime = 15 * time.Minute
+)
+
+// streamTypes are the bus events pushed to clients
+var streamTypes = []events.Type{
+	events.ScanCompleted, events.CandlesSynced, events.ZoneDetected, events.ZoneMitigated, events.MitigationChecked,
+	events.AutomationFinished, events.JobProgress, events.AlertTriggered,
+}
+
+type StreamController struct {
+	bus          *events.Bus
+	isProduction bool
+}
+
+func NewStreamController(bus *events.Bus, isProduction bool) *StreamController {
+	return &StreamController{bus: bus, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the Server-Sent Events stream, authenticated by the auth_token cookie.
+func (ctrl *StreamController) RegisterRoutes(router *gin.RouterGroup) {
+	streamGroup := router.Group("/stream")
+	streamGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		streamGroup.GET("", ctrl.Stream)
+	}
+}
+
+// Stream godoc
+// @Summary      Live updates
+// @Description  Server-Sent Events stream of scan refreshes, zone and mitigation changes, automation runs, job progress
+// @Description  and the user's own triggered alerts. Each message's event name is the event type and its data an
+// @Description  events.Event as JSON. A "ping" is sent every 25s, and the stream is closed after 15 minutes so
+// @Description  EventSource reconnects with a fresh session.
+// @Tags         Stream
+// @Produce      text/event-stream
+// @Param        types  query     string  false  "Comma separated event types to receive (default all)"
+// @Success      200    {object}  events.Event
+// @Failure      400    {object}  model.Response
+// @Failure      401    {object}  model.Response
+// @Router       /stream [get]
+func (ctrl *StreamController) Stream(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	wanted := streamTypes
+	if raw := c.Query("types"); raw != "" {
+		wanted = nil
+		for _, t := range strings.Split(raw, ",") {
+			eventType := events.Type(strings.TrimSpace(t))
+			if !slices.Contains(streamTypes, eventType) {
+				c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Unknown event type: " + string(eventType)})
+				return
+			}
+			wanted = append(wanted, eventType)
+		}
+	}
+
+	ctx := c.Request.Context()
+	out := make(chan events.Event, streamBuffer)
+	unsubscribe := ctrl.bus.Subscribe("stream:"+strconv.FormatInt(user.UserID, 10), streamBuffer, func(e events.Event) {
+		if !slices.Contains(wanted, e.Type) {
+			return
+		}
+		// Alerts are private to the user who set them, and scans of private strategies to those who can see them
+		if history, ok := e.Data.(model.AlertHistory); ok && history.UserID != user.UserID {
+			return
+		}
+		if scan, ok := e.Data.(model.ScanEvent); ok && !scan.VisibleTo(user.UserID) {
+			return
+		}
+		select {
+		case out <- e:
+		case <-ctx.Done():
+		}
+	})
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	lifetime := time.NewTimer(streamLifetime)
+	defer lifetime.Stop()
+
+	c.SSEvent("ready", gin.H{"types": wanted})
+	c.Writer.Flush()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e := <-out:
+			c.SSEvent(string(e.Type), e)
+			return true
+		case t := <-heartbeat.C:
+			c.SSEvent("ping", t)
+			return true
+		case <-lifetime.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,308 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TradeController struct {
+	tradeSvc     service.TradeService
+	isProduction bool
+}
+
+func NewTradeController(s service.TradeService, isProduction bool) *TradeController {
+	return &TradeController{tradeSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the trade journal. Every trade belongs to the authenticated user.
+func (ctrl *TradeController) RegisterRoutes(router *gin.RouterGroup) {
+	tradeGroup := router.Group("/trades")
+	tradeGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		tradeGroup.GET("", ctrl.ListTrades)
+		tradeGroup.POST("", ctrl.CreateTrade)
+		tradeGroup.GET("/positions", ctrl.GetPositions)
+		tradeGroup.GET("/pnl", ctrl.GetPnL)
+		tradeGroup.GET("/stats", ctrl.GetStrategyStats)
+		tradeGroup.GET("/:id", ctrl.GetTrade)
+		tradeGroup.PUT("/:id", ctrl.UpdateTrade)
+		tradeGroup.POST("/:id/close", ctrl.CloseTrade)
+		tradeGroup.DELETE("/:id", ctrl.DeleteTrade)
+	}
+}
+
+// ListTrades godoc
+// @Summary      List trades
+// @Description  Returns the user's trades, newest entry first.
+// @Tags         Trades
+// @Produce      json
+// @Param        status  query     string  false  "Trade status"  Enums(OPEN, CLOSED)
+// @Success      200     {object}  model.Response{data=[]model.Trade}
+// @Failure      400     {object}  model.Response
+// @Failure      401     {object}  model.Response
+// @Failure      500     {object}  model.Response
+// @Router       /trades [get]
+func (ctrl *TradeController) ListTrades(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	status := model.TradeStatus(c.Query("status"))
+	if status != "" && status != model.TradeOpen && status != model.TradeClosed {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid status: " + string(status)})
+		return
+	}
+
+	trades, err := ctrl.tradeSvc.ListTrades(c.Request.Context(), user.UserID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: trades})
+}
+
+// GetTrade godoc
+// @Summary      Get a trade
+// @Tags         Trades
+// @Produce      json
+// @Param        id   path      string  true  "Trade ID"
+// @Success      200  {object}  model.Response{data=model.Trade}
+// @Failure      401  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /trades/{id} [get]
+func (ctrl *TradeController) GetTrade(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	trade, err := ctrl.tradeSvc.GetTrade(c.Request.Context(), user.UserID, c.Param("id"))
+	if err != nil {
+		c.JSON(tradeStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: trade})
+}
+
+// CreateTrade godoc
+// @Summary      Record a trade
+// @Description  Opens a trade in the user's journal. Leverage defaults to the symbol's margin.
+// @Tags         Trades
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.TradeRequest  true  "Trade entry"
+// @Success      201      {object}  model.Response{data=model.Trade}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /trades [post]
+func (ctrl *TradeController) CreateTrade(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.TradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	trade, err := ctrl.tradeSvc.CreateTrade(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		c.JSON(tradeStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, model.Response{Success: true, Message: "Trade recorded", Data: trade})
+}
+
+// UpdateTrade godoc
+// @Summary      Edit a trade
+// @Description  Replaces the entry details of a trade. A closed trade keeps its exit and its P&L is recomputed.
+// @Tags         Trades
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string              true  "Trade ID"
+// @Param        request  body      model.TradeRequest  true  "Trade entry"
+// @Success      200      {object}  model.Response{data=model.Trade}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /trades/{id} [put]
+func (ctrl *TradeController) UpdateTrade(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.TradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	trade, err := ctrl.tradeSvc.UpdateTrade(c.Request.Context(), user.UserID, c.Param("id"), req)
+	if err != nil {
+		c.JSON(tradeStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Trade updated", Data: trade})
+}
+
+// CloseTrade godoc
+// @Summary      Close a trade
+// @Description  Records the exit of an open trade and its realised P&L, net of charges.
+// @Tags         Trades
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Trade ID"
+// @Param        request  body      model.CloseTradeRequest  true  "Trade exit"
+// @Success      200      {object}  model.Response{data=model.Trade}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      409      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /trades/{id}/close [post]
+func (ctrl *TradeController) CloseTrade(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.CloseTradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	trade, err := ctrl.tradeSvc.CloseTrade(c.Request.Context(), user.UserID, c.Param("id"), req)
+	if err != nil {
+		c.JSON(tradeStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Trade closed", Data: trade})
+}
+
+// DeleteTrade godoc
+// @Summary      Delete a trade
+// @Tags         Trades
+// @Produce      json
+// @Param        id   path      string  true  "Trade ID"
+// @Success      200  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /trades/{id} [delete]
+func (ctrl *TradeController) DeleteTrade(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	if err := ctrl.tradeSvc.DeleteTrade(c.Request.Context(), user.UserID, c.Param("id")); err != nil {
+		c.JSON(tradeStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Trade deleted"})
+}
+
+// GetPositions godoc
+// @Summary      Open positions
+// @Description  Returns the user's open trades valued at the latest candle, with unrealised P&L.
+// @Tags         Trades
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.Position}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /trades/positions [get]
+func (ctrl *TradeController) GetPositions(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	positions, err := ctrl.tradeSvc.GetPositions(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: positions})
+}
+
+// GetPnL godoc
+// @Summary      P&L summary
+// @Description  Totals realised P&L of closed trades and unrealised P&L of open positions.
+// @Tags         Trades
+// @Produce      json
+// @Success      200  {object}  model.Response{data=model.PnLSummary}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /trades/pnl [get]
+func (ctrl *TradeController) GetPnL(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	summary, err := ctrl.tradeSvc.GetPnL(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: summary})
+}
+
+// GetStrategyStats godoc
+// @Summary      Per-strategy statistics
+// @Description  Groups the user's trades by linked strategy with win rate and average P&L of the closed ones.
+// @Tags         Trades
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.StrategyStats}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /trades/stats [get]
+func (ctrl *TradeController) GetStrategyStats(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	stats, err := ctrl.tradeSvc.GetStrategyStats(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: stats})
+}
+
+// tradeStatus maps trade service errors to HTTP status codes.
+func tradeStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTradeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTradeClosed):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidTrade):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
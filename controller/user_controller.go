@@ -29,6 +29,7 @@ func (ctrl *UserController) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		userGroup.PATCH("/username", ctrl.UpdateUsername)
 		userGroup.PATCH("/theme", ctrl.UpdateTheme)
+		userGroup.PATCH("/trading-defaults", ctrl.UpdateTradingDefaults)
 	}
 }
 
@@ -134,3 +135,54 @@ func (ctrl *UserController) UpdateTheme(c *gin.Context) {
 		Data:    req.Theme,
 	})
 }
+
+// UpdateTradingDefaults godoc
+// @Summary      Update Trading Defaults
+// @Description  Sets the capital and risk % per trade the position sizing calculator starts from
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request body      model.UpdateTradingDefaultsRequest  true  "Capital and risk"
+// @Success      200     {object}  model.Response{data=model.UserDto}
+// @Failure      400     {object}  model.Response
+// @Failure      401     {object}  model.Response
+// @Router       /user/trading-defaults [patch]
+func (ctrl *UserController) UpdateTradingDefaults(c *gin.Context) {
+	var req model.UpdateTradingDefaultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{
+			Success: false,
+			Error:   "Invalid request format",
+		})
+		return
+	}
+
+	userDto, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{
+			Success: false,
+			Error:   "User session not found",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	user, err := ctrl.userSvc.UpdateTradingDefaults(ctx, userDto.UserID, req.DefaultCapital, req.DefaultRiskPct)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{
+			Success: false,
+			Error:   "Internal server error",
+		})
+		return
+	}
+
+	cache.UserAuthCache.Delete(strconv.FormatInt(userDto.UserID, 10))
+
+	c.JSON(http.StatusOK, model.Response{
+		Success: true,
+		Message: "Trading defaults updated",
+		Data:    user.ToDto(),
+	})
+}
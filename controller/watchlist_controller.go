@@ -0,0 +1,358 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/repository"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WatchlistController struct {
+	watchlistSvc service.WatchlistService
+	isProduction bool
+}
+
+func NewWatchlistController(s service.WatchlistService, isProduction bool) *WatchlistController {
+	return &WatchlistController{watchlistSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up the authenticated user's watchlists.
+func (ctrl *WatchlistController) RegisterRoutes(router *gin.RouterGroup) {
+	watchlistGroup := router.Group("/watchlists")
+	watchlistGroup.Use(middleware.AuthMiddleware(ctrl.isProduction))
+	{
+		watchlistGroup.GET("", ctrl.ListWatchlists)
+		watchlistGroup.POST("", ctrl.CreateWatchlist)
+		watchlistGroup.PUT("/order", ctrl.ReorderWatchlists)
+		watchlistGroup.GET("/:id", ctrl.GetWatchlist)
+		watchlistGroup.PUT("/:id", ctrl.RenameWatchlist)
+		watchlistGroup.DELETE("/:id", ctrl.DeleteWatchlist)
+		watchlistGroup.POST("/:id/items", ctrl.AddItem)
+		watchlistGroup.PUT("/:id/items/order", ctrl.ReorderItems)
+		watchlistGroup.PATCH("/:id/items/:symbol", ctrl.UpdateItem)
+		watchlistGroup.DELETE("/:id/items/:symbol", ctrl.RemoveItem)
+	}
+}
+
+// ListWatchlists godoc
+// @Summary      List watchlists
+// @Description  Returns the user's watchlists in their order, without market data.
+// @Tags         Watchlists
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.Watchlist}
+// @Failure      401  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /watchlists [get]
+func (ctrl *WatchlistController) ListWatchlists(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	lists, err := ctrl.watchlistSvc.ListWatchlists(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: lists})
+}
+
+// GetWatchlist godoc
+// @Summary      Get a watchlist
+// @Description  Returns the watchlist's symbols joined with their margin, latest candle and active order blocks and FVGs.
+// @Tags         Watchlists
+// @Produce      json
+// @Param        id   path      string  true  "Watchlist ID"
+// @Success      200  {object}  model.Response{data=model.WatchlistView}
+// @Failure      401  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /watchlists/{id} [get]
+func (ctrl *WatchlistController) GetWatchlist(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	view, err := ctrl.watchlistSvc.GetWatchlist(c.Request.Context(), user.UserID, c.Param("id"))
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: view})
+}
+
+// CreateWatchlist godoc
+// @Summary      Create a watchlist
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.WatchlistRequest  true  "Watchlist name"
+// @Success      201      {object}  model.Response{data=model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      409      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists [post]
+func (ctrl *WatchlistController) CreateWatchlist(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.CreateWatchlist(c.Request.Context(), user.UserID, req.Name)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, model.Response{Success: true, Message: "Watchlist created", Data: list})
+}
+
+// RenameWatchlist godoc
+// @Summary      Rename a watchlist
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Watchlist ID"
+// @Param        request  body      model.WatchlistRequest  true  "Watchlist name"
+// @Success      200      {object}  model.Response{data=model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      409      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists/{id} [put]
+func (ctrl *WatchlistController) RenameWatchlist(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.RenameWatchlist(c.Request.Context(), user.UserID, c.Param("id"), req.Name)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Watchlist renamed", Data: list})
+}
+
+// DeleteWatchlist godoc
+// @Summary      Delete a watchlist
+// @Tags         Watchlists
+// @Produce      json
+// @Param        id   path      string  true  "Watchlist ID"
+// @Success      200  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /watchlists/{id} [delete]
+func (ctrl *WatchlistController) DeleteWatchlist(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	if err := ctrl.watchlistSvc.DeleteWatchlist(c.Request.Context(), user.UserID, c.Param("id")); err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Watchlist deleted"})
+}
+
+// ReorderWatchlists godoc
+// @Summary      Reorder watchlists
+// @Description  Sets the order of the user's watchlists by ID. Lists left out keep their relative order after them.
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.WatchlistOrderRequest  true  "Watchlist IDs in order"
+// @Success      200      {object}  model.Response{data=[]model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists/order [put]
+func (ctrl *WatchlistController) ReorderWatchlists(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	lists, err := ctrl.watchlistSvc.ReorderWatchlists(c.Request.Context(), user.UserID, req.Order)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: lists})
+}
+
+// AddItem godoc
+// @Summary      Add a symbol to a watchlist
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "Watchlist ID"
+// @Param        request  body      model.WatchlistItemRequest  true  "Symbol and note"
+// @Success      200      {object}  model.Response{data=model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists/{id}/items [post]
+func (ctrl *WatchlistController) AddItem(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.AddItem(c.Request.Context(), user.UserID, c.Param("id"), req)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Symbol added", Data: list})
+}
+
+// UpdateItem godoc
+// @Summary      Edit a symbol's note
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "Watchlist ID"
+// @Param        symbol   path      string                      true  "Symbol"
+// @Param        request  body      model.WatchlistItemRequest  true  "Note (symbol is ignored)"
+// @Success      200      {object}  model.Response{data=model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists/{id}/items/{symbol} [patch]
+func (ctrl *WatchlistController) UpdateItem(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.UpdateItem(c.Request.Context(), user.UserID, c.Param("id"), c.Param("symbol"), req.Note)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Note updated", Data: list})
+}
+
+// RemoveItem godoc
+// @Summary      Remove a symbol from a watchlist
+// @Tags         Watchlists
+// @Produce      json
+// @Param        id      path      string  true  "Watchlist ID"
+// @Param        symbol  path      string  true  "Symbol"
+// @Success      200     {object}  model.Response{data=model.Watchlist}
+// @Failure      401     {object}  model.Response
+// @Failure      404     {object}  model.Response
+// @Failure      500     {object}  model.Response
+// @Router       /watchlists/{id}/items/{symbol} [delete]
+func (ctrl *WatchlistController) RemoveItem(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.RemoveItem(c.Request.Context(), user.UserID, c.Param("id"), c.Param("symbol"))
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Symbol removed", Data: list})
+}
+
+// ReorderItems godoc
+// @Summary      Reorder a watchlist's symbols
+// @Description  Sets the display order of the watchlist's symbols; every symbol must be listed exactly once.
+// @Tags         Watchlists
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                       true  "Watchlist ID"
+// @Param        request  body      model.WatchlistOrderRequest  true  "Symbols in order"
+// @Success      200      {object}  model.Response{data=model.Watchlist}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /watchlists/{id}/items/order [put]
+func (ctrl *WatchlistController) ReorderItems(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WatchlistOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	list, err := ctrl.watchlistSvc.ReorderItems(c.Request.Context(), user.UserID, c.Param("id"), req.Order)
+	if err != nil {
+		c.JSON(watchlistStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: list})
+}
+
+// watchlistStatus maps watchlist service errors to HTTP status codes.
+func watchlistStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWatchlistNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidWatchlist):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrDuplicateWatchlist):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/middleware"
+	"backend/model"
+	"backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookSvc   service.WebhookService
+	isProduction bool
+}
+
+func NewWebhookController(s service.WebhookService, isProduction bool) *WebhookController {
+	return &WebhookController{webhookSvc: s, isProduction: isProduction}
+}
+
+// RegisterRoutes sets up admin-only management of outbound webhooks.
+func (ctrl *WebhookController) RegisterRoutes(router *gin.RouterGroup) {
+	webhookGroup := router.Group("/webhooks")
+	webhookGroup.Use(middleware.AuthMiddleware(ctrl.isProduction), middleware.AdminOnly())
+	{
+		webhookGroup.GET("", ctrl.ListWebhooks)
+		webhookGroup.POST("", ctrl.CreateWebhook)
+		webhookGroup.PUT("/:id", ctrl.UpdateWebhook)
+		webhookGroup.DELETE("/:id", ctrl.DeleteWebhook)
+		webhookGroup.GET("/:id/deliveries", ctrl.ListDeliveries)
+		webhookGroup.POST("/:id/test", ctrl.TestWebhook)
+	}
+}
+
+// ListWebhooks godoc
+// @Summary      List webhooks
+// @Description  Returns every outbound webhook, newest first. Secrets are never returned.
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200  {object}  model.Response{data=[]model.Webhook}
+// @Failure      401  {object}  model.Response
+// @Failure      403  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /webhooks [get]
+func (ctrl *WebhookController) ListWebhooks(c *gin.Context) {
+	hooks, err := ctrl.webhookSvc.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: hooks})
+}
+
+// CreateWebhook godoc
+// @Summary      Create a webhook
+// @Description  Subscribes a URL to events. Every delivery is POSTed as JSON with X-Webhook-Event, X-Webhook-Delivery,
+// @Description  X-Webhook-Timestamp and X-Webhook-Signature headers; the signature is "sha256=" followed by the hex
+// @Description  HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. Failed deliveries are retried with backoff.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        request  body      model.WebhookRequest  true  "Webhook"
+// @Success      201      {object}  model.Response{data=model.Webhook}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      403      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /webhooks [post]
+func (ctrl *WebhookController) CreateWebhook(c *gin.Context) {
+	user, ok := middleware.GetUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, model.Response{Success: false, Error: "User session not found"})
+		return
+	}
+
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	hook, err := ctrl.webhookSvc.CreateWebhook(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		c.JSON(webhookStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, model.Response{Success: true, Message: "Webhook created", Data: hook})
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a webhook
+// @Description  Replaces a webhook's URL, events and description. An empty secret keeps the current one.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                true  "Webhook ID"
+// @Param        request  body      model.WebhookRequest  true  "Webhook"
+// @Success      200      {object}  model.Response{data=model.Webhook}
+// @Failure      400      {object}  model.Response
+// @Failure      401      {object}  model.Response
+// @Failure      403      {object}  model.Response
+// @Failure      404      {object}  model.Response
+// @Failure      500      {object}  model.Response
+// @Router       /webhooks/{id} [put]
+func (ctrl *WebhookController) UpdateWebhook(c *gin.Context) {
+	var req model.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.Response{Success: false, Error: "Invalid body"})
+		return
+	}
+
+	hook, err := ctrl.webhookSvc.UpdateWebhook(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		c.JSON(webhookStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Webhook updated", Data: hook})
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook
+// @Description  Removes a webhook together with its delivery log.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  model.Response
+// @Failure      401  {object}  model.Response
+// @Failure      403  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /webhooks/{id} [delete]
+func (ctrl *WebhookController) DeleteWebhook(c *gin.Context) {
+	if err := ctrl.webhookSvc.DeleteWebhook(c.Request.Context(), c.Param("id")); err != nil {
+		c.JSON(webhookStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Message: "Webhook deleted"})
+}
+
+// ListDeliveries godoc
+// @Summary      Webhook delivery log
+// @Description  Returns a webhook's latest deliveries with their attempts and outcome, newest first.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id     path      string  true   "Webhook ID"
+// @Param        limit  query     int     false  "Max entries to return (default 50)"
+// @Success      200    {object}  model.Response{data=[]model.WebhookDelivery}
+// @Failure      401    {object}  model.Response
+// @Failure      403    {object}  model.Response
+// @Failure      404    {object}  model.Response
+// @Failure      500    {object}  model.Response
+// @Router       /webhooks/{id}/deliveries [get]
+func (ctrl *WebhookController) ListDeliveries(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+	deliveries, err := ctrl.webhookSvc.ListDeliveries(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(webhookStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: deliveries})
+}
+
+// TestWebhook godoc
+// @Summary      Send a test event
+// @Description  Sends a webhook.test event, whether or not the webhook is active, and returns the first attempt.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  model.Response{data=model.WebhookDelivery}
+// @Failure      401  {object}  model.Response
+// @Failure      403  {object}  model.Response
+// @Failure      404  {object}  model.Response
+// @Failure      500  {object}  model.Response
+// @Router       /webhooks/{id}/test [post]
+func (ctrl *WebhookController) TestWebhook(c *gin.Context) {
+	delivery, err := ctrl.webhookSvc.TestWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(webhookStatus(err), model.Response{Success: false, Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, model.Response{Success: true, Data: delivery})
+}
+
+// webhookStatus maps webhook service errors to HTTP status codes.
+func webhookStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
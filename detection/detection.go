@@ -0,0 +1,157 @@
+// Package detection finds order blocks and fair value gaps in candle series.
+// It has no I/O: the same candles and rules always produce the same zones.
+package detection
+
+import (
+	"time"
+
+	"backend/model"
+)
+
+const (
+	KindOrderBlock = "OB"
+	KindFvg        = "FVG"
+)
+
+const (
+	defaultMinGapPct          = 0.2
+	defaultMinDisplacementPct = 1.5
+	defaultVolumeLookback     = 20
+)
+
+// Rules are the thresholds a candle pattern must pass to become a zone.
+type Rules struct {
+	// MinGapPct is the smallest FVG as a percent of the displacement candle's close.
+	MinGapPct float64
+	// MinDisplacementPct is the smallest displacement body as a percent of its open.
+	MinDisplacementPct float64
+	// VolumeMultiplier requires displacement volume >= multiplier x the average of the previous
+	// VolumeLookback candles. Zero disables volume confirmation.
+	VolumeMultiplier float64
+	VolumeLookback   int
+}
+
+// RulesFromConfig fills unset thresholds with defaults.
+func RulesFromConfig(cfg model.DetectionConfig) Rules {
+	rules := Rules{
+		MinGapPct:          cfg.MinGapPct,
+		MinDisplacementPct: cfg.MinDisplacementPct,
+		VolumeMultiplier:   cfg.VolumeMultiplier,
+		VolumeLookback:     cfg.VolumeLookback,
+	}
+	if rules.MinGapPct <= 0 {
+		rules.MinGapPct = defaultMinGapPct
+	}
+	if rules.MinDisplacementPct <= 0 {
+		rules.MinDisplacementPct = defaultMinDisplacementPct
+	}
+	if rules.VolumeLookback <= 0 {
+		rules.VolumeLookback = defaultVolumeLookback
+	}
+	return rules
+}
+
+// Zone is a detected order block or FVG.
+type Zone struct {
+	Kind      string          `json:"kind" example:"FVG"`
+	Direction model.Direction `json:"direction" example:"BULLISH"`
+	// Time is the candle the zone is dated on: the order block candle, or the middle candle of an FVG.
+	Time time.Time `json:"time"`
+	// ConfirmedAt is the candle that completed the pattern.
+	ConfirmedAt time.Time `json:"confirmedAt"`
+	High        float64   `json:"high"`
+	Low         float64   `json:"low"`
+}
+
+// DetectFvgs scans ascending candles for three-candle fair value gaps. The middle candle must be a
+// displacement candle in the direction of the gap.
+func DetectFvgs(candles []model.Candle, rules Rules) []Zone {
+	zones := make([]Zone, 0)
+	for i := 1; i+1 < len(candles); i++ {
+		left, mid, right := candles[i-1], candles[i], candles[i+1]
+		if !isDisplacement(candles, i, rules) || mid.Close == 0 {
+			continue
+		}
+
+		switch {
+		case mid.Close > mid.Open && right.Low > left.High:
+			if (right.Low-left.High)/mid.Close*100 < rules.MinGapPct {
+				continue
+			}
+			zones = append(zones, Zone{
+				Kind: KindFvg, Direction: model.DirectionBullish,
+				Time: mid.Time, ConfirmedAt: right.Time,
+				High: right.Low, Low: left.High,
+			})
+		case mid.Close < mid.Open && right.High < left.Low:
+			if (left.Low-right.High)/mid.Close*100 < rules.MinGapPct {
+				continue
+			}
+			zones = append(zones, Zone{
+				Kind: KindFvg, Direction: model.DirectionBearish,
+				Time: mid.Time, ConfirmedAt: right.Time,
+				High: left.Low, Low: right.High,
+			})
+		}
+	}
+	return zones
+}
+
+// DetectOrderBlocks scans ascending candles for the last opposite candle before a displacement:
+// a down candle followed by an up displacement closing above its high (bullish), or the mirror (bearish).
+func DetectOrderBlocks(candles []model.Candle, rules Rules) []Zone {
+	zones := make([]Zone, 0)
+	for i := 0; i+1 < len(candles); i++ {
+		ob, next := candles[i], candles[i+1]
+		if !isDisplacement(candles, i+1, rules) {
+			continue
+		}
+
+		switch {
+		case ob.Close < ob.Open && next.Close > next.Open && next.Close > ob.High:
+			zones = append(zones, Zone{
+				Kind: KindOrderBlock, Direction: model.DirectionBullish,
+				Time: ob.Time, ConfirmedAt: next.Time,
+				High: ob.High, Low: ob.Low,
+			})
+		case ob.Close > ob.Open && next.Close < next.Open && next.Close < ob.Low:
+			zones = append(zones, Zone{
+				Kind: KindOrderBlock, Direction: model.DirectionBearish,
+				Time: ob.Time, ConfirmedAt: next.Time,
+				High: ob.High, Low: ob.Low,
+			})
+		}
+	}
+	return zones
+}
+
+// isDisplacement reports whether candles[i] has a large enough body and, if enabled, enough volume.
+func isDisplacement(candles []model.Candle, i int, rules Rules) bool {
+	c := candles[i]
+	if c.Open == 0 {
+		return false
+	}
+
+	body := c.Close - c.Open
+	if body < 0 {
+		body = -body
+	}
+	if body/c.Open*100 < rules.MinDisplacementPct {
+		return false
+	}
+
+	if rules.VolumeMultiplier <= 0 {
+		return true
+	}
+	// Not enough history to confirm volume
+	if i < rules.VolumeLookback {
+		return false
+	}
+
+	var total int64
+	for _, prev := range candles[i-rules.VolumeLookback : i] {
+		total += prev.Volume
+	}
+	avg := float64(total) / float64(rules.VolumeLookback)
+	return float64(c.Volume) >= rules.VolumeMultiplier*avg
+}
@@ -0,0 +1,215 @@
+package detection
+
+import (
+	"testing"
+	"time"
+
+	"backend/model"
+)
+
+var day0 = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+func candle(day int, open, high, low, close float64, volume int64) model.Candle {
+	return model.Candle{
+		Time: day0.AddDate(0, 0, day), Open: open, High: high, Low: low, Close: close, Volume: volume,
+	}
+}
+
+var defaultRules = RulesFromConfig(model.DetectionConfig{})
+
+func TestRulesFromConfig(t *testing.T) {
+	rules := RulesFromConfig(model.DetectionConfig{MinGapPct: 0.5, VolumeMultiplier: 1.5})
+	want := Rules{MinGapPct: 0.5, MinDisplacementPct: defaultMinDisplacementPct, VolumeMultiplier: 1.5,
+		VolumeLookback: defaultVolumeLookback}
+	if rules != want {
+		t.Fatalf("RulesFromConfig = %+v, want %+v", rules, want)
+	}
+}
+
+func TestDetectFvgs(t *testing.T) {
+	volumeRules := Rules{MinGapPct: 0.2, MinDisplacementPct: 1.5, VolumeMultiplier: 2, VolumeLookback: 2}
+	tests := []struct {
+		name    string
+		candles []model.Candle
+		rules   Rules
+		want    []Zone
+	}{
+		{
+			name: "bullish gap",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 100.5, 0),
+				candle(1, 101, 106, 100.5, 105.5, 0),
+				candle(2, 105.5, 107, 102, 106, 0),
+			},
+			rules: defaultRules,
+			want: []Zone{{Kind: KindFvg, Direction: model.DirectionBullish, Time: day0.AddDate(0, 0, 1),
+				ConfirmedAt: day0.AddDate(0, 0, 2), High: 102, Low: 101}},
+		},
+		{
+			name: "bearish gap",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 99.5, 0),
+				candle(1, 99, 99.5, 94, 94.5, 0),
+				candle(2, 94.5, 98, 93, 94, 0),
+			},
+			rules: defaultRules,
+			want: []Zone{{Kind: KindFvg, Direction: model.DirectionBearish, Time: day0.AddDate(0, 0, 1),
+				ConfirmedAt: day0.AddDate(0, 0, 2), High: 99, Low: 98}},
+		},
+		{
+			name: "gap below min gap percent",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 100.5, 0),
+				candle(1, 101, 106, 100.5, 105.5, 0),
+				candle(2, 105.5, 107, 101.1, 106, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "middle candle is not a displacement",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 100.5, 0),
+				candle(1, 101, 102.5, 100.5, 102, 0),
+				candle(2, 102, 103, 102, 102.5, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "middle candle against the gap",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 100.5, 0),
+				candle(1, 106, 106.5, 100.5, 101.5, 0),
+				candle(2, 105.5, 107, 102, 106, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "volume confirmed",
+			candles: []model.Candle{
+				candle(0, 100, 100.5, 99.5, 100, 100),
+				candle(1, 100, 101, 99, 100.5, 100),
+				candle(2, 101, 106, 100.5, 105.5, 250),
+				candle(3, 105.5, 107, 102, 106, 100),
+			},
+			rules: volumeRules,
+			want: []Zone{{Kind: KindFvg, Direction: model.DirectionBullish, Time: day0.AddDate(0, 0, 2),
+				ConfirmedAt: day0.AddDate(0, 0, 3), High: 102, Low: 101}},
+		},
+		{
+			name: "volume not confirmed",
+			candles: []model.Candle{
+				candle(0, 100, 100.5, 99.5, 100, 100),
+				candle(1, 100, 101, 99, 100.5, 100),
+				candle(2, 101, 106, 100.5, 105.5, 150),
+				candle(3, 105.5, 107, 102, 106, 100),
+			},
+			rules: volumeRules,
+		},
+		{
+			name: "not enough volume history",
+			candles: []model.Candle{
+				candle(0, 100, 101, 99, 100.5, 100),
+				candle(1, 101, 106, 100.5, 105.5, 1000),
+				candle(2, 105.5, 107, 102, 106, 100),
+			},
+			rules: volumeRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertZones(t, DetectFvgs(tt.candles, tt.rules), tt.want)
+		})
+	}
+}
+
+func TestDetectOrderBlocks(t *testing.T) {
+	volumeRules := Rules{MinGapPct: 0.2, MinDisplacementPct: 1.5, VolumeMultiplier: 2, VolumeLookback: 1}
+	tests := []struct {
+		name    string
+		candles []model.Candle
+		rules   Rules
+		want    []Zone
+	}{
+		{
+			name: "bullish order block",
+			candles: []model.Candle{
+				candle(0, 102, 103, 99, 100, 0),
+				candle(1, 100, 106, 99.5, 105.5, 0),
+			},
+			rules: defaultRules,
+			want: []Zone{{Kind: KindOrderBlock, Direction: model.DirectionBullish, Time: day0,
+				ConfirmedAt: day0.AddDate(0, 0, 1), High: 103, Low: 99}},
+		},
+		{
+			name: "bearish order block",
+			candles: []model.Candle{
+				candle(0, 98, 101, 97.5, 100.5, 0),
+				candle(1, 100.5, 101, 94, 95, 0),
+			},
+			rules: defaultRules,
+			want: []Zone{{Kind: KindOrderBlock, Direction: model.DirectionBearish, Time: day0,
+				ConfirmedAt: day0.AddDate(0, 0, 1), High: 101, Low: 97.5}},
+		},
+		{
+			name: "displacement does not close beyond the block",
+			candles: []model.Candle{
+				candle(0, 102, 103, 99, 100, 0),
+				candle(1, 100, 103.5, 99.5, 102.5, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "next candle is not a displacement",
+			candles: []model.Candle{
+				candle(0, 100, 100.8, 99, 99.5, 0),
+				candle(1, 99.5, 101, 99.4, 100.9, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "block candle in the same direction",
+			candles: []model.Candle{
+				candle(0, 99, 103, 98.5, 100, 0),
+				candle(1, 100, 106, 99.5, 105.5, 0),
+			},
+			rules: defaultRules,
+		},
+		{
+			name: "volume confirmed",
+			candles: []model.Candle{
+				candle(0, 102, 103, 99, 100, 100),
+				candle(1, 100, 106, 99.5, 105.5, 250),
+			},
+			rules: volumeRules,
+			want: []Zone{{Kind: KindOrderBlock, Direction: model.DirectionBullish, Time: day0,
+				ConfirmedAt: day0.AddDate(0, 0, 1), High: 103, Low: 99}},
+		},
+		{
+			name: "volume not confirmed",
+			candles: []model.Candle{
+				candle(0, 102, 103, 99, 100, 100),
+				candle(1, 100, 106, 99.5, 105.5, 150),
+			},
+			rules: volumeRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertZones(t, DetectOrderBlocks(tt.candles, tt.rules), tt.want)
+		})
+	}
+}
+
+func assertZones(t *testing.T, got, want []Zone) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d zones %+v, want %d %+v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("zone %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
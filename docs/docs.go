@@ -15,6 +15,278 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/alerts": {
+            "get": {
+                "description": "Returns the user's alerts, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "List alerts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Alert"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "PRICE alerts fire when the latest candle crosses level; NEW_ZONE and ZONE_MITIGATED alerts fire when\na matching order block or FVG is saved or mitigated. Alerts are sent on the user's alert channels\n(email by default), at most once per occurrence and not again within the cooldown.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Create an alert",
+                "parameters": [
+                    {
+                        "description": "Alert",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Alert"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/history": {
+            "get": {
+                "description": "Returns the user's latest alert firings with their delivery outcome.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Alert history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Max entries to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AlertHistory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Delete an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Pause or resume an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Active flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AlertActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
                 "description": "Authenticates user via HttpOnly cookie and JWT",
@@ -370,22 +642,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/chartink/fetch": {
-            "get": {
-                "description": "Triggers a scan on ChartInk for the given strategy and returns raw stock data",
+        "/backtest": {
+            "post": {
+                "description": "Replays stored zones against stored candles. A trade enters at the close of the first valid mitigation\nand exits on the stop loss, the target or after maxHoldCandles. Reports win rate, expectancy,\nmax drawdown and the equity curve.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ChartInk"
+                    "PriceAction"
                 ],
-                "summary": "Fetch raw ChartInk data",
+                "summary": "Backtest order block and FVG entries",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "Bullish_Engulfing",
-                        "description": "Name of the strategy to run",
-                        "name": "strategy",
+                        "description": "Backtest parameters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BacktestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.BacktestResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/chartink/fetch": {
+            "get": {
+                "description": "Triggers a scan on ChartInk for the given strategy and returns raw stock data. While ChartInk is\nunavailable the last stored result is returned with stale set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ChartInk"
+                ],
+                "summary": "Fetch raw ChartInk data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Bullish_Engulfing",
+                        "description": "Name of the strategy to run",
+                        "name": "strategy",
                         "in": "query",
                         "required": true
                     }
@@ -429,7 +765,7 @@ const docTemplate = `{
         },
         "/chartink/fetchWithMargin": {
             "get": {
-                "description": "Triggers a scan and maps results with current margin and leverage data",
+                "description": "Triggers a scan and maps results with current margin and leverage data. A result under a minute old\nis served from cache; an older one is served right away with stale set while it is refreshed in\nthe background.",
                 "produces": [
                     "application/json"
                 ],
@@ -445,16 +781,55 @@ const docTemplate = `{
                         "name": "strategy",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "margin (default), close, volume, pChange, name, symbol or any scan column",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc or desc (default)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum close",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum volume",
+                        "name": "minVolume",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum margin",
+                        "name": "minMargin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size up to 500 (default all stocks)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.StockMarginDto"
-                            }
+                            "$ref": "#/definitions/model.MarginScan"
                         }
                     },
                     "400": {
@@ -585,6 +960,67 @@ const docTemplate = `{
                 }
             }
         },
+        "/detection/run": {
+            "post": {
+                "description": "Runs the detection engine over a symbol's stored candles and returns the order blocks and FVGs found.\nSet save to write them as price action zones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Detect zones for a symbol",
+                "parameters": [
+                    {
+                        "description": "Detection range",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DetectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/detection.Zone"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/email/send": {
             "post": {
                 "description": "Sends a transactional email using the Brevo API provider",
@@ -684,254 +1120,279 @@ const docTemplate = `{
                 }
             }
         },
-        "/margin/all": {
+        "/health/chartink": {
             "get": {
-                "description": "Returns a list of all stock margins from the local memory cache",
+                "description": "Reports the ChartInk session: circuit breaker state, token status, and request, retry, rate limit\nand stale-serve counters since startup. Responds 503 while the circuit is open.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Margin"
+                    "System"
                 ],
-                "summary": "Get all margins",
+                "summary": "ChartInk Health",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Margin"
-                            }
+                            "$ref": "#/definitions/model.ChartInkHealth"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChartInkHealth"
                         }
                     }
                 }
             }
         },
-        "/margin/load-from-csv": {
-            "post": {
-                "description": "Uploads a CSV file to bulk load or update margin data",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/jobs": {
+            "get": {
+                "description": "Returns recent background jobs with their status and counters (per-symbol errors omitted).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Margin"
+                    "Jobs"
                 ],
-                "summary": "Upload Margin CSV",
+                "summary": "List background jobs",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Margin CSV file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "example": "ob-automation",
+                        "description": "Job type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "RUNNING",
+                            "SUCCESS",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Job status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max jobs to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Job"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
             }
         },
-        "/margin/reload": {
-            "post": {
-                "description": "Forces a reload of all margins from MongoDB into the memory cache",
+        "/jobs/{id}": {
+            "get": {
+                "description": "Returns status, counters and per-symbol errors of a single background job.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Margin"
+                    "Jobs"
+                ],
+                "summary": "Get job progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Reload margins",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
             }
         },
-        "/margin/symbol/{symbol}": {
+        "/margin/all": {
             "get": {
-                "description": "Fetches the margin details for a specific stock symbol",
+                "description": "Returns a list of all stock margins from the local memory cache",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Margin"
                 ],
-                "summary": "Get margin by symbol",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "RELIANCE",
-                        "description": "Stock Symbol",
-                        "name": "symbol",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get all margins",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Margin"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Margin"
                             }
                         }
                     }
                 }
             }
         },
-        "/nse/allindices": {
+        "/margin/batches": {
             "get": {
-                "description": "Fetches latest performance data for all indices using the warmup strategy.",
+                "description": "Returns the CSV uploads and rollbacks with their change counts, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stocks"
+                    "Margin"
+                ],
+                "summary": "List margin imports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Max batches to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All NSE Indices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/model.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.AllIndicesResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MarginBatch"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/nse/heatmap": {
+        "/margin/batches/{id}": {
             "get": {
-                "description": "Fetches latest sectoral data. Uses warmup time-cache strategy to avoid NSE rate limits.",
+                "description": "Returns a margin import with every symbol it added, removed or changed the margin of",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stocks"
+                    "Margin"
+                ],
+                "summary": "Get margin import diff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get NSE Sectoral Heatmap",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/model.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.SectorData"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/model.MarginBatchDiff"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/nse/history": {
-            "get": {
-                "description": "Fetches stock history for a specific symbol. Utilizes a 1-hour time cache.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/margin/batches/{id}/rollback": {
+            "post": {
+                "description": "Replaces the margins with the set an earlier import left behind. The rollback is recorded as a new\nbatch, so it can itself be diffed and rolled back.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Stocks"
+                    "Margin"
                 ],
-                "summary": "Get Historical Stock Data",
+                "summary": "Roll back margins",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Stock Symbol (e.g. RELIANCE)",
-                        "name": "symbol",
-                        "in": "query",
+                        "description": "Batch ID to restore",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -939,180 +1400,150 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/model.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/model.NSEHistoricalData"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/model.MarginBatch"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/price-action/automate": {
-            "post": {
-                "tags": [
-                    "PriceAction"
-                ],
-                "summary": "Trigger PA Automation",
-                "responses": {
-                    "202": {
-                        "description": "Accepted",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/price-action/fvg": {
+        "/margin/load-from-csv": {
             "post": {
-                "tags": [
-                    "PriceAction (Admin)"
+                "description": "Uploads a CSV file to bulk load or update margin data. Symbols missing from the file are removed.\nThe upload is recorded as a batch with the symbols it added, removed or re-levered.",
+                "consumes": [
+                    "multipart/form-data"
                 ],
-                "summary": "Save FVG",
-                "parameters": [
-                    {
-                        "description": "FVG Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
-                        }
-                    }
+                "produces": [
+                    "application/json"
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
                 "tags": [
-                    "PriceAction (Admin)"
+                    "Margin"
                 ],
-                "summary": "Delete FVG",
+                "summary": "Upload Margin CSV",
                 "parameters": [
                     {
-                        "description": "Delete Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
-                        }
+                        "type": "file",
+                        "description": "Margin CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "$ref": "#/definitions/model.MarginBatch"
                         }
-                    }
-                }
-            },
-            "patch": {
-                "tags": [
-                    "PriceAction (Admin)"
-                ],
-                "summary": "Update FVG",
-                "parameters": [
-                    {
-                        "description": "Update Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/price-action/fvg/check": {
+        "/margin/reload": {
             "post": {
-                "description": "Triggers a fresh scan of all stocks against saved Fair Value Gaps (FVG) to identify active mitigations. This bypasses the cache and updates it.",
+                "description": "Forces a reload of all margins from MongoDB into the memory cache",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PriceAction"
+                    "Margin"
                 ],
-                "summary": "Force Refresh FVG Mitigations",
+                "summary": "Reload margins",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/model.Response"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/price-action/fvg/cleanup": {
-            "post": {
-                "description": "Triggers a maintenance task that fetches NSE history for all stored symbols and deletes FVGs that have been breached or filled by subsequent price action.",
-                "produces": [
-                    "application/json"
+        "/margin/symbol/{symbol}": {
+            "get": {
+                "description": "Fetches the margin details for a specific stock symbol",
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "PriceAction"
+                    "Margin"
+                ],
+                "summary": "Get margin by symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "RELIANCE",
+                        "description": "Stock Symbol",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Clean up filled FVGs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Margin"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1123,47 +1554,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/price-action/fvg/mitigation": {
+        "/margin/symbol/{symbol}/history": {
             "get": {
-                "tags": [
-                    "PriceAction"
-                ],
-                "summary": "Get Cached FVG Mitigations",
-                "responses": {}
-            }
-        },
-        "/price-action/fvg/old/{stopDate}": {
-            "post": {
-                "description": "Upload a CSV to find and save FVGs from a specific stop date backwards.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Returns the imports that added, removed or changed the margin of a stock symbol, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PriceAction"
+                    "Margin"
                 ],
-                "summary": "Process Historical Fair Value Gaps (FVG)",
+                "summary": "Get margin history",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "CSV File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "Stop Date (YYYY-MM-DD)",
-                        "name": "stopDate",
+                        "example": "RELIANCE",
+                        "description": "Stock Symbol",
+                        "name": "symbol",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max changes to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.MarginChange"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1174,96 +1602,212 @@ const docTemplate = `{
                 }
             }
         },
-        "/price-action/ob": {
-            "post": {
-                "tags": [
-                    "PriceAction (Admin)"
+        "/notifications": {
+            "get": {
+                "description": "Returns the linked Telegram chat, the available channels and the channels each event is sent on.",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Save OB",
-                "parameters": [
-                    {
-                        "description": "OB Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
-                        }
-                    }
+                "tags": [
+                    "Notifications"
                 ],
+                "summary": "Notification settings",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NotificationSettings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications/preferences": {
+            "put": {
+                "description": "Sets the channels each event (ALERTS, MITIGATIONS, AUTOMATION) is sent on. Events left out keep their\ndefault: alerts by email, everything else off. An empty list turns an event off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "PriceAction (Admin)"
+                    "Notifications"
                 ],
-                "summary": "Delete OB",
+                "summary": "Route notifications",
                 "parameters": [
                     {
-                        "description": "Delete Details",
+                        "description": "Channels per event",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
+                            "$ref": "#/definitions/model.NotificationPrefsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NotificationSettings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/notifications/telegram": {
+            "put": {
+                "description": "Sends a confirmation message to the chat and links it once it got through. The user must have\nstarted a conversation with the bot first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "PriceAction (Admin)"
+                    "Notifications"
                 ],
-                "summary": "Update OB",
+                "summary": "Link a Telegram chat",
                 "parameters": [
                     {
-                        "description": "Update Details",
+                        "description": "Telegram chat",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.ObRequest"
+                            "$ref": "#/definitions/model.TelegramLinkRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NotificationSettings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            }
-        },
-        "/price-action/ob/check": {
-            "post": {
-                "description": "Triggers a fresh scan of all stocks against saved Order Blocks to find active mitigations.",
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PriceAction"
+                    "Notifications"
                 ],
-                "summary": "Force Refresh OB Mitigations",
+                "summary": "Unlink Telegram",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.NotificationSettings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
@@ -1277,71 +1821,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/price-action/ob/mitigation": {
-            "get": {
-                "tags": [
-                    "PriceAction"
-                ],
-                "summary": "Get Cached OB Mitigations",
-                "responses": {}
-            }
-        },
-        "/price-action/ob/old/{stopDate}": {
+        "/notifications/test": {
             "post": {
-                "description": "Upload a CSV to find and save Order Blocks from a specific stop date backwards.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PriceAction"
+                    "Notifications"
                 ],
-                "summary": "Process Historical Order Blocks",
+                "summary": "Send a test notification",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "CSV File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Stop Date (YYYY-MM-DD)",
-                        "name": "stopDate",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "Channel",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.NotificationTestRequest"
                         }
                     }
-                }
-            }
-        },
-        "/price-action/{symbol}": {
-            "get": {
-                "tags": [
-                    "PriceAction"
-                ],
-                "summary": "Get PA by Symbol",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Symbol",
-                        "name": "symbol",
-                        "in": "path",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
@@ -1349,66 +1850,119 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
                     }
                 }
             }
         },
-        "/strategy": {
+        "/nse/allindices": {
             "get": {
-                "description": "Returns a list of all configured active trading strategies",
+                "description": "Fetches latest performance data for all indices using the warmup strategy.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Strategy"
+                    "Stocks"
                 ],
-                "summary": "Get all strategies",
+                "summary": "Get All NSE Indices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.StrategyDto"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AllIndicesResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing strategy configuration by name/ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/nse/heatmap": {
+            "get": {
+                "description": "Fetches latest sectoral data. Uses warmup time-cache strategy to avoid NSE rate limits.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Strategy"
-                ],
-                "summary": "Update a strategy",
-                "parameters": [
-                    {
-                        "description": "Updated Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.StrategyDto"
-                        }
-                    }
+                    "Stocks"
                 ],
+                "summary": "Get NSE Sectoral Heatmap",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Strategy"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.SectorData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Saves a new trading strategy configuration to MongoDB",
+            }
+        },
+        "/nse/history": {
+            "get": {
+                "description": "Fetches stock history for a specific symbol from the market data provider chain. Utilizes a 1-hour time cache.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1416,127 +1970,143 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Strategy"
+                    "Stocks"
                 ],
-                "summary": "Create a strategy",
+                "summary": "Get Historical Stock Data",
                 "parameters": [
                     {
-                        "description": "Strategy Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.StrategyDto"
-                        }
+                        "type": "string",
+                        "description": "Stock Symbol (e.g. RELIANCE)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Strategy"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.NSEHistoricalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Removes a strategy from the system using its ID/Name",
-                "tags": [
-                    "Strategy"
-                ],
-                "summary": "Delete a strategy",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Strategy ID (Name)",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     }
                 }
             }
         },
-        "/strategy/admin": {
+        "/nse/providers": {
             "get": {
-                "description": "Returns all trading strategies with full administrative details",
+                "description": "Lists the configured market data providers in fallback order with their circuit breaker state.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Strategy"
+                    "Stocks"
                 ],
-                "summary": "Get all strategies (Admin)",
+                "summary": "Get Market Data Provider Status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.StrategyDto"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ProviderStatus"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/strategy/reload": {
+        "/price-action/automate": {
             "post": {
-                "description": "Syncs the in-memory strategy cache with MongoDB",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Runs the OB and FVG automation jobs through the scheduler so retries and run history are kept.\nIf either is already running, neither is started. Poll the returned jobs through /jobs/{id}.",
                 "tags": [
-                    "Strategy"
+                    "PriceAction"
                 ],
-                "summary": "Reload strategies",
+                "summary": "Trigger PA Automation",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/user/theme": {
-            "patch": {
-                "description": "Updates preference (LIGHT/DARK) for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Job"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/fvg": {
+            "post": {
+                "description": "Stores a new FVG. A stored one is refused with 409 so its lifecycle is kept; update it instead.",
                 "tags": [
-                    "User"
+                    "PriceAction (Admin)"
                 ],
-                "summary": "Update User Theme",
+                "summary": "Save FVG",
                 "parameters": [
                     {
-                        "description": "Theme Preference",
+                        "description": "FVG Details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateThemeRequest"
+                            "$ref": "#/definitions/model.ObRequest"
                         }
                     }
                 ],
@@ -1547,8 +2117,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Delete FVG",
+                "parameters": [
+                    {
+                        "description": "Delete Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ObRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Update FVG",
+                "parameters": [
+                    {
+                        "description": "Update Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ObRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
@@ -1556,30 +2176,92 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/username": {
-            "patch": {
-                "description": "Updates the username and invalidates the auth cache",
-                "consumes": [
+        "/price-action/fvg/bearish/check": {
+            "post": {
+                "description": "Triggers a fresh scan of the bearish mitigation strategy's stocks (mitigation.bearishStrategy, default BEARISH CLOSE 200) against saved bearish Fair Value Gaps.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Force Refresh Bearish FVG Mitigations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ObResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/fvg/bearish/mitigation": {
+            "get": {
+                "description": "Returns stocks trading up into a bearish Fair Value Gap, from cache when available.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "PriceAction"
                 ],
-                "summary": "Update Username",
-                "parameters": [
-                    {
-                        "description": "Target Email and New Username",
-                        "name": "update",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get Cached Bearish FVG Mitigations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserDto"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ObResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
+                }
+            }
+        },
+        "/price-action/fvg/check": {
+            "post": {
+                "description": "Triggers a fresh scan of all stocks against saved Fair Value Gaps (FVG) to identify active mitigations. This bypasses the cache and updates it.",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Force Refresh FVG Mitigations",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1587,309 +2269,5912 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/fvg/cleanup": {
+            "post": {
+                "description": "Starts a background maintenance job that replays candle history for all stored symbols and marks active FVGs as tested, mitigated or invalidated. Nothing is deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Refresh FVG lifecycles",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.AllIndicesResponse": {
-            "type": "object",
-            "properties": {
-                "index": {
-                    "type": "string"
-                },
-                "indexSymbol": {
-                    "type": "string"
-                },
-                "key": {
-                    "type": "string"
-                },
-                "last": {
-                    "type": "number"
-                },
-                "oneWeekAgoVal": {
-                    "type": "number"
-                },
-                "perChange1w": {
+        },
+        "/price-action/fvg/mitigation": {
+            "get": {
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Get Cached FVG Mitigations",
+                "responses": {}
+            }
+        },
+        "/price-action/fvg/old/{stopDate}": {
+            "post": {
+                "description": "Upload a CSV to find and save FVGs from a specific stop date backwards.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Process Historical Fair Value Gaps (FVG)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stop Date (YYYY-MM-DD)",
+                        "name": "stopDate",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob": {
+            "post": {
+                "description": "Stores a new order block. A stored one is refused with 409 so its lifecycle is kept; update it instead.",
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Save OB",
+                "parameters": [
+                    {
+                        "description": "OB Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ObRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Delete OB",
+                "parameters": [
+                    {
+                        "description": "Delete Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ObRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "tags": [
+                    "PriceAction (Admin)"
+                ],
+                "summary": "Update OB",
+                "parameters": [
+                    {
+                        "description": "Update Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ObRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob/bearish/check": {
+            "post": {
+                "description": "Triggers a fresh scan of the bearish mitigation strategy's stocks (mitigation.bearishStrategy, default BEARISH CLOSE 200) against saved bearish Order Blocks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Force Refresh Bearish OB Mitigations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ObResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob/bearish/mitigation": {
+            "get": {
+                "description": "Returns stocks trading up into a bearish order block, from cache when available.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Get Cached Bearish OB Mitigations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ObResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob/check": {
+            "post": {
+                "description": "Triggers a fresh scan of all stocks against saved Order Blocks to find active mitigations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Force Refresh OB Mitigations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob/cleanup": {
+            "post": {
+                "description": "Starts a background maintenance job that replays candle history for all stored symbols and marks active order blocks as tested, mitigated or invalidated. Nothing is deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Refresh order block lifecycles",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/ob/mitigation": {
+            "get": {
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Get Cached OB Mitigations",
+                "responses": {}
+            }
+        },
+        "/price-action/ob/old/{stopDate}": {
+            "post": {
+                "description": "Upload a CSV to find and save Order Blocks from a specific stop date backwards.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Process Historical Order Blocks",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stop Date (YYYY-MM-DD)",
+                        "name": "stopDate",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/price-action/{symbol}": {
+            "get": {
+                "tags": [
+                    "PriceAction"
+                ],
+                "summary": "Get PA by Symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated zone statuses (FRESH,TESTED,MITIGATED,INVALIDATED)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.StockRecord"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scans/confluence": {
+            "get": {
+                "description": "Runs several active strategies concurrently and ranks the margin stocks returned by more than one of\nthem, most matches first and then by margin, with each stock's fresh or tested order blocks and FVGs.\nA strategy whose scan fails is listed under failed and counts as not matching.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "Multi-strategy confluence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BULLISH OB 1D,BULLISH CLOSE 200",
+                        "description": "Comma separated strategy names (default all active)",
+                        "name": "strategies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum strategies a stock must match (default 2)",
+                        "name": "min",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Confluence"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scans/diff": {
+            "get": {
+                "description": "Lists the stocks that entered and exited a strategy's scan between two snapshots. to defaults to\nthe latest snapshot and from to the last snapshot of an earlier day, i.e. today versus yesterday.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "Diff two scan snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BULLISH OB 1D",
+                        "description": "Strategy name",
+                        "name": "strategy",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Older snapshot ID",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newer snapshot ID",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ScanDiff"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scans/snapshots": {
+            "get": {
+                "description": "Returns a strategy's stored scan results without their stocks, newest first. A snapshot is stored\nby the first scan of each day and whenever a scan returns a different set of symbols than the one\nbefore it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "List scan snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "BULLISH OB 1D",
+                        "description": "Strategy name",
+                        "name": "strategy",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max snapshots to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ScanSnapshot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scans/snapshots/{id}": {
+            "get": {
+                "description": "Returns a stored scan result with its stocks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scans"
+                ],
+                "summary": "Get a scan snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Snapshot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.ScanSnapshot"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs": {
+            "get": {
+                "description": "Returns every registered job with its cron schedule, next fire time (IST) and latest run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduler"
+                ],
+                "summary": "List scheduled jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.ScheduledJobDto"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/jobs/{name}/run": {
+            "post": {
+                "description": "Triggers a scheduled job immediately in the background.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduler"
+                ],
+                "summary": "Run a job now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "fvg-cleanup",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Job"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scheduler/runs": {
+            "get": {
+                "description": "Returns persisted job runs, newest first. Optionally filtered by job name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Scheduler"
+                ],
+                "summary": "List job run history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ob-automation",
+                        "description": "Job name",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max runs to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.SchedulerRun"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sizing": {
+            "post": {
+                "description": "Returns the quantity whose loss at the stop stays within riskPct of capital, capped by what the\ncapital can fund at the symbol's margin. Capital and risk default to the user's profile; the stop\ndefaults to the far side of an order block or FVG.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Size a position",
+                "parameters": [
+                    {
+                        "description": "Sizing inputs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SizingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SizingResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy": {
+            "get": {
+                "description": "Returns the active public strategies, plus for a signed-in user the ones they own or that are\nshared with them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Get all strategies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StrategyDto"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing strategy configuration by name/ID. Changing the scan clause records a new\nversion; owner and creation time are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Update a strategy",
+                "parameters": [
+                    {
+                        "description": "Updated Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Dry run the scan clause first and refuse to activate it if it fails",
+                        "name": "validate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a new trading strategy configuration to MongoDB and records its scan clause as version 1.\nThe caller becomes its owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Create a strategy",
+                "parameters": [
+                    {
+                        "description": "Strategy Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Dry run the scan clause first and refuse to activate it if it fails",
+                        "name": "validate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a strategy from the system using its ID/Name",
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Delete a strategy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy ID (Name)",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/strategy/admin": {
+            "get": {
+                "description": "Returns all trading strategies with full administrative details",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Get all strategies (Admin)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StrategyDto"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/mine": {
+            "get": {
+                "description": "Returns every strategy the signed-in user owns, including inactive ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Get my strategies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StrategyDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates a private strategy owned by the signed-in user, or updates one they own. Names are unique\nacross all users. Visibility is changed through /strategy/sharing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Save my strategy",
+                "parameters": [
+                    {
+                        "description": "Strategy Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Dry run the scan clause first and refuse to activate it if it fails",
+                        "name": "validate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a private strategy owned by the signed-in user, or updates one they own. Names are unique\nacross all users. Visibility is changed through /strategy/sharing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Save my strategy",
+                "parameters": [
+                    {
+                        "description": "Strategy Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Dry run the scan clause first and refuse to activate it if it fails",
+                        "name": "validate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a strategy the signed-in user owns",
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Delete my strategy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy ID (Name)",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/preview": {
+            "post": {
+                "description": "Runs a scan clause on ChartInk without saving it, returning the match count and a sample of matches,\nor the error ChartInk reported for the clause",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Preview a scan clause",
+                "parameters": [
+                    {
+                        "description": "Scan clause",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScanPreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScanPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/reload": {
+            "post": {
+                "description": "Syncs the in-memory strategy cache with MongoDB",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Reload strategies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/sharing": {
+            "put": {
+                "description": "Makes a strategy PRIVATE, SHARED with the users whose emails are listed, or PUBLIC. Only its owner\nor an admin can change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Share a strategy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy ID (Name)",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Visibility",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategySharingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/versions": {
+            "get": {
+                "description": "Returns every recorded scan clause of a strategy, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "List strategy versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy ID (Name)",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StrategyVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/strategy/versions/restore": {
+            "post": {
+                "description": "Makes an earlier scan clause current again. The restore is recorded as a new version, so it can\nitself be rolled back. An active strategy is refused if the restored clause fails its dry run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Strategy"
+                ],
+                "summary": "Restore a strategy version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Strategy ID (Name)",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to restore",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StrategyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of scan refreshes, zone and mitigation changes, automation runs, job progress\nand the user's own triggered alerts. Each message's event name is the event type and its data an\nevents.Event as JSON. A \"ping\" is sent every 25s, and the stream is closed after 15 minutes so\nEventSource reconnects with a fresh session.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Live updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated event types to receive (default all)",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades": {
+            "get": {
+                "description": "Returns the user's trades, newest entry first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "List trades",
+                "parameters": [
+                    {
+                        "enum": [
+                            "OPEN",
+                            "CLOSED"
+                        ],
+                        "type": "string",
+                        "description": "Trade status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Trade"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens a trade in the user's journal. Leverage defaults to the symbol's margin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Record a trade",
+                "parameters": [
+                    {
+                        "description": "Trade entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Trade"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/pnl": {
+            "get": {
+                "description": "Totals realised P\u0026L of closed trades and unrealised P\u0026L of open positions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "P\u0026L summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PnLSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/positions": {
+            "get": {
+                "description": "Returns the user's open trades valued at the latest candle, with unrealised P\u0026L.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Open positions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Position"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/stats": {
+            "get": {
+                "description": "Groups the user's trades by linked strategy with win rate and average P\u0026L of the closed ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Per-strategy statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.StrategyStats"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Get a trade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trade ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Trade"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the entry details of a trade. A closed trade keeps its exit and its P\u0026L is recomputed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Edit a trade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trade ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trade entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Trade"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Delete a trade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trade ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}/close": {
+            "post": {
+                "description": "Records the exit of an open trade and its realised P\u0026L, net of charges.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trades"
+                ],
+                "summary": "Close a trade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trade ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trade exit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CloseTradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Trade"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/theme": {
+            "patch": {
+                "description": "Updates preference (LIGHT/DARK) for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User Theme",
+                "parameters": [
+                    {
+                        "description": "Theme Preference",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateThemeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/trading-defaults": {
+            "patch": {
+                "description": "Sets the capital and risk % per trade the position sizing calculator starts from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update Trading Defaults",
+                "parameters": [
+                    {
+                        "description": "Capital and risk",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateTradingDefaultsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UserDto"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/username": {
+            "patch": {
+                "description": "Updates the username and invalidates the auth cache",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update Username",
+                "parameters": [
+                    {
+                        "description": "Target Email and New Username",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists": {
+            "get": {
+                "description": "Returns the user's watchlists in their order, without market data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "List watchlists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Watchlist"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Create a watchlist",
+                "parameters": [
+                    {
+                        "description": "Watchlist name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists/order": {
+            "put": {
+                "description": "Sets the order of the user's watchlists by ID. Lists left out keep their relative order after them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Reorder watchlists",
+                "parameters": [
+                    {
+                        "description": "Watchlist IDs in order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Watchlist"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists/{id}": {
+            "get": {
+                "description": "Returns the watchlist's symbols joined with their margin, latest candle and active order blocks and FVGs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Get a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WatchlistView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Rename a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watchlist name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Delete a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists/{id}/items": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Add a symbol to a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Symbol and note",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists/{id}/items/order": {
+            "put": {
+                "description": "Sets the display order of the watchlist's symbols; every symbol must be listed exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Reorder a watchlist's symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Symbols in order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlists/{id}/items/{symbol}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Remove a symbol from a watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlists"
+                ],
+                "summary": "Edit a symbol's note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watchlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note (symbol is ignored)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WatchlistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Returns every outbound webhook, newest first. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.Webhook"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribes a URL to events. Every delivery is POSTed as JSON with X-Webhook-Event, X-Webhook-Delivery,\nX-Webhook-Timestamp and X-Webhook-Signature headers; the signature is \"sha256=\" followed by the hex\nHMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret. Failed deliveries are retried with backoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "put": {
+                "description": "Replaces a webhook's URL, events and description. An empty secret keeps the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.Webhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a webhook together with its delivery log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Returns a webhook's latest deliveries with their attempts and outcome, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max entries to return (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.WebhookDelivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "description": "Sends a webhook.test event, whether or not the webhook is active, and returns the first attempt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Send a test event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/model.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "detection.Zone": {
+            "type": "object",
+            "properties": {
+                "confirmedAt": {
+                    "description": "ConfirmedAt is the candle that completed the pattern.",
+                    "type": "string"
+                },
+                "direction": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "FVG"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "time": {
+                    "description": "Time is the candle the zone is dated on: the order block candle, or the middle candle of an FVG.",
+                    "type": "string"
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "symbol": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/events.Type"
+                }
+            }
+        },
+        "events.Type": {
+            "type": "string",
+            "enum": [
+                "candles.synced",
+                "zone.detected",
+                "zone.mitigated",
+                "mitigation.checked",
+                "alert.triggered",
+                "scan.completed",
+                "automation.finished",
+                "job.progress"
+            ],
+            "x-enum-varnames": [
+                "CandlesSynced",
+                "ZoneDetected",
+                "ZoneMitigated",
+                "MitigationChecked",
+                "AlertTriggered",
+                "ScanCompleted",
+                "AutomationFinished",
+                "JobProgress"
+            ]
+        },
+        "model.Alert": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "condition": {
+                    "description": "Condition and Level apply to PRICE alerts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlertCondition"
+                        }
+                    ],
+                    "example": "ABOVE"
+                },
+                "cooldownMinutes": {
+                    "description": "CooldownMinutes is the quiet period after the alert fires",
+                    "type": "integer",
+                    "example": 60
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "direction": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind, Direction and Timeframe narrow zone alerts; empty matches any",
+                    "type": "string",
+                    "example": "OB"
+                },
+                "lastTriggeredAt": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "number",
+                    "example": 3000
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "timeframe": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                },
+                "triggerCount": {
+                    "type": "integer"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlertType"
+                        }
+                    ],
+                    "example": "PRICE"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlertActiveRequest": {
+            "type": "object",
+            "required": [
+                "active"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "model.AlertCondition": {
+            "type": "string",
+            "enum": [
+                "ABOVE",
+                "BELOW"
+            ],
+            "x-enum-varnames": [
+                "CrossAbove",
+                "CrossBelow"
+            ]
+        },
+        "model.AlertHistory": {
+            "type": "object",
+            "properties": {
+                "alertId": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dedupKey": {
+                    "description": "DedupKey identifies the occurrence; an alert is delivered at most once per key",
+                    "type": "string",
+                    "example": "price:2025-01-14"
+                },
+                "delivered": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Error is the last failed delivery; a failed firing is retried when the alert is checked again",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "RELIANCE crossed above 3000.00 (high 3012.40)"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "triggeredAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlertType"
+                        }
+                    ],
+                    "example": "PRICE"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AlertRequest": {
+            "type": "object",
+            "required": [
+                "symbol",
+                "type"
+            ],
+            "properties": {
+                "condition": {
+                    "enum": [
+                        "ABOVE",
+                        "BELOW"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlertCondition"
+                        }
+                    ],
+                    "example": "ABOVE"
+                },
+                "cooldownMinutes": {
+                    "description": "CooldownMinutes defaults to 60",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 60
+                },
+                "direction": {
+                    "enum": [
+                        "BULLISH",
+                        "BEARISH"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "OB",
+                        "FVG"
+                    ],
+                    "example": "OB"
+                },
+                "level": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 3000
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "timeframe": {
+                    "enum": [
+                        "15m",
+                        "1h",
+                        "1D",
+                        "1W",
+                        "1M"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                },
+                "type": {
+                    "enum": [
+                        "PRICE",
+                        "NEW_ZONE",
+                        "ZONE_MITIGATED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AlertType"
+                        }
+                    ],
+                    "example": "PRICE"
+                }
+            }
+        },
+        "model.AlertType": {
+            "type": "string",
+            "enum": [
+                "PRICE",
+                "NEW_ZONE",
+                "ZONE_MITIGATED"
+            ],
+            "x-enum-varnames": [
+                "AlertPrice",
+                "AlertNewZone",
+                "AlertZoneMitigated"
+            ]
+        },
+        "model.AllIndicesResponse": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "string"
+                },
+                "indexSymbol": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last": {
+                    "type": "number"
+                },
+                "oneWeekAgoVal": {
+                    "type": "number"
+                },
+                "perChange1w": {
+                    "type": "number"
+                },
+                "perChange30d": {
+                    "type": "number"
+                },
+                "perChange365d": {
+                    "type": "number"
+                },
+                "percentChange": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BacktestRequest": {
+            "type": "object",
+            "properties": {
+                "capital": {
+                    "description": "Capital is the starting equity; defaults to 100000",
+                    "type": "number",
+                    "example": 100000
+                },
+                "direction": {
+                    "description": "Direction is BULLISH, BEARISH or empty for both",
+                    "enum": [
+                        "BULLISH",
+                        "BEARISH"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "from": {
+                    "description": "From and To are YYYY-MM-DD and bound the zone dates; the default range is the last year",
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "kind": {
+                    "description": "Kind is OB, FVG or empty for both",
+                    "type": "string",
+                    "enum": [
+                        "OB",
+                        "FVG"
+                    ],
+                    "example": "OB"
+                },
+                "maxHoldCandles": {
+                    "description": "MaxHoldCandles closes a trade at the close of this many candles after entry; defaults to 20",
+                    "type": "integer",
+                    "example": 20
+                },
+                "positionPct": {
+                    "description": "PositionPct is the share of starting capital committed per trade; defaults to 10",
+                    "type": "number",
+                    "example": 10
+                },
+                "rewardRisk": {
+                    "description": "RewardRisk is the target as a multiple of the stop distance; defaults to 2",
+                    "type": "number",
+                    "example": 2
+                },
+                "stopLossPct": {
+                    "description": "StopLossPct is the stop distance from entry. Zero places the stop at the far side of the zone.",
+                    "type": "number",
+                    "example": 0
+                },
+                "symbols": {
+                    "description": "Symbols limits the run; empty means every symbol with price action",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "RELIANCE",
+                        "TCS"
+                    ]
+                },
+                "targetPct": {
+                    "description": "TargetPct is the target distance from entry. Zero uses RewardRisk instead.",
+                    "type": "number",
+                    "example": 0
+                },
+                "timeframe": {
+                    "description": "Timeframe of the zones and the candles they are replayed on; defaults to 1D. Intraday candles are not\nstored, so 15m and 1h cannot be backtested.",
+                    "enum": [
+                        "1D",
+                        "1W",
+                        "1M"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-12-31"
+                },
+                "useMargin": {
+                    "description": "UseMargin multiplies the position by the symbol's margin (leverage)",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.BacktestResult": {
+            "type": "object",
+            "properties": {
+                "avgRMultiple": {
+                    "type": "number",
+                    "example": 0.36
+                },
+                "breakevens": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "equityCurve": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.EquityPoint"
+                    }
+                },
+                "expectancy": {
+                    "description": "Expectancy is the average PnL per closed trade; AvgRMultiple the same in R",
+                    "type": "number",
+                    "example": 312.4
+                },
+                "finalEquity": {
+                    "type": "number",
+                    "example": 113120.8
+                },
+                "losses": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "maxDrawdown": {
+                    "description": "MaxDrawdown is the largest fall from a peak of the equity curve, absolute and as a percent of that peak",
+                    "type": "number",
+                    "example": 8450
+                },
+                "maxDrawdownPct": {
+                    "type": "number",
+                    "example": 7.62
+                },
+                "openPnl": {
+                    "type": "number",
+                    "example": -240.5
+                },
+                "openTrades": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "skipped": {
+                    "description": "Skipped lists symbols whose candles could not be loaded",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalPnl": {
+                    "description": "TotalPnL is realised by closed trades; OpenPnL is the open trades' PnL at the last close",
+                    "type": "number",
+                    "example": 13120.8
+                },
+                "totalTrades": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "trades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BacktestTrade"
+                    }
+                },
+                "winRate": {
+                    "description": "WinRate is the percent of closed trades with a positive PnL",
+                    "type": "number",
+                    "example": 47.5
+                },
+                "wins": {
+                    "description": "Wins, Losses and Breakevens count the closed trades; OpenTrades are still open at the end of the range",
+                    "type": "integer",
+                    "example": 19
+                }
+            }
+        },
+        "model.BacktestTrade": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "entryDate": {
+                    "type": "string",
+                    "example": "2024-03-12"
+                },
+                "entryPrice": {
+                    "type": "number",
+                    "example": 2910.5
+                },
+                "exitDate": {
+                    "type": "string",
+                    "example": "2024-03-20"
+                },
+                "exitPrice": {
+                    "type": "number",
+                    "example": 2991.5
+                },
+                "exitReason": {
+                    "type": "string",
+                    "enum": [
+                        "STOP_LOSS",
+                        "TARGET",
+                        "TIME",
+                        "OPEN"
+                    ],
+                    "example": "TARGET"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "OB"
+                },
+                "leverage": {
+                    "type": "number",
+                    "example": 5
+                },
+                "pnl": {
+                    "type": "number",
+                    "example": 1377
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "rMultiple": {
+                    "description": "RMultiple is the result in units of the initial stop distance",
+                    "type": "number",
+                    "example": 2
+                },
+                "stopLoss": {
+                    "type": "number",
+                    "example": 2870
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "target": {
+                    "type": "number",
+                    "example": 2991.5
+                },
+                "zoneDate": {
+                    "type": "string",
+                    "example": "2024-03-04"
+                }
+            }
+        },
+        "model.BrevoEmailRequest": {
+            "type": "object",
+            "properties": {
+                "htmlContent": {
+                    "type": "string"
+                },
+                "sender": {
+                    "$ref": "#/definitions/model.Recipient"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Recipient"
+                    }
+                }
+            }
+        },
+        "model.Candle": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChannelType": {
+            "type": "string",
+            "enum": [
+                "EMAIL",
+                "TELEGRAM"
+            ],
+            "x-enum-varnames": [
+                "ChannelEmail",
+                "ChannelTelegram"
+            ]
+        },
+        "model.ChartInkError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid attribute 'closee'"
+                },
+                "statusCode": {
+                    "description": "StatusCode is ChartInk's HTTP status, 200 when the response carried a scan_error",
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "model.ChartInkHealth": {
+            "type": "object",
+            "properties": {
+                "circuit": {
+                    "type": "string",
+                    "example": "CLOSED"
+                },
+                "consecutiveFailures": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "hasToken": {
+                    "type": "boolean"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastFailure": {
+                    "type": "string"
+                },
+                "lastLatencyMs": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "lastSuccess": {
+                    "type": "string"
+                },
+                "openedAt": {
+                    "type": "string"
+                },
+                "rateLimited": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "requests": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "servedStale": {
+                    "description": "ServedStale counts scans answered from the last good result because ChartInk was unavailable",
+                    "type": "integer",
+                    "example": 0
+                },
+                "tokenRefreshedAt": {
+                    "type": "string"
+                },
+                "tokenRefreshes": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.ChartInkResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "json:\"data\" tells the parser to map the JSON key \"data\" to this field",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockData"
+                    }
+                },
+                "fetchedAt": {
+                    "type": "string"
+                },
+                "scan_error": {
+                    "description": "ScanError is set instead of Data when ChartInk could not run the scan clause",
+                    "type": "string"
+                },
+                "stale": {
+                    "description": "Stale is set when the result is served past its freshness, e.g. from the last snapshot while ChartInk is down",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.CloseTradeRequest": {
+            "type": "object",
+            "required": [
+                "exitPrice"
+            ],
+            "properties": {
+                "charges": {
+                    "description": "Charges of the exit leg, added to the trade's charges",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 21.3
+                },
+                "exitDate": {
+                    "description": "ExitDate is YYYY-MM-DD; defaults to today",
+                    "type": "string",
+                    "example": "2025-01-20"
+                },
+                "exitPrice": {
+                    "type": "number",
+                    "example": 2990
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Target hit"
+                }
+            }
+        },
+        "model.Confluence": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "Failed maps strategies whose scan failed to the error, they count as not matching",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConfluenceStock"
+                    }
+                },
+                "strategies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BULLISH OB 1D",
+                        "BULLISH CLOSE 200"
+                    ]
+                }
+            }
+        },
+        "model.ConfluenceStock": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "columns": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "fvg": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Info"
+                    }
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "matches": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderBlocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Info"
+                    }
+                },
+                "pChange": {
+                    "type": "number"
+                },
+                "strategies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BULLISH OB 1D",
+                        "BULLISH CLOSE 200"
+                    ]
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.DetectRequest": {
+            "type": "object",
+            "required": [
+                "symbol"
+            ],
+            "properties": {
+                "from": {
+                    "description": "From and To are YYYY-MM-DD; the default range is the last 90 days",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "save": {
+                    "description": "Save writes the detected zones; otherwise they are only returned",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "timeframe": {
+                    "enum": [
+                        "15m",
+                        "1h",
+                        "1D",
+                        "1W",
+                        "1M"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-03-31"
+                }
+            }
+        },
+        "model.DetectionConfig": {
+            "type": "object",
+            "properties": {
+                "minDisplacementPct": {
+                    "description": "MinDisplacementPct is the smallest displacement candle body, as a percent of its open (default 1.5)",
+                    "type": "number"
+                },
+                "minGapPct": {
+                    "description": "MinGapPct is the smallest FVG, as a percent of the displacement candle's close (default 0.2)",
+                    "type": "number"
+                },
+                "timeframes": {
+                    "description": "Timeframes scanned by the scheduled detection job (default 1D)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Timeframe"
+                    }
+                },
+                "volumeLookback": {
+                    "description": "VolumeLookback is the number of candles averaged for volume confirmation (default 20)",
+                    "type": "integer"
+                },
+                "volumeMultiplier": {
+                    "description": "VolumeMultiplier requires displacement volume of at least this multiple of the average volume; 0 disables the check",
+                    "type": "number"
+                }
+            }
+        },
+        "model.Direction": {
+            "type": "string",
+            "enum": [
+                "BULLISH",
+                "BEARISH"
+            ],
+            "x-enum-varnames": [
+                "DirectionBullish",
+                "DirectionBearish"
+            ]
+        },
+        "model.EquityPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-03-20"
+                },
+                "equity": {
+                    "type": "number",
+                    "example": 101377
+                }
+            }
+        },
+        "model.Info": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "$ref": "#/definitions/model.Direction"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "invalidatedOn": {
+                    "description": "InvalidatedOn is the date price broke through the zone",
+                    "type": "string"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "mitigatedOn": {
+                    "description": "MitigatedOn is the date price first revisited the zone",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ZoneStatus"
+                },
+                "timeframe": {
+                    "$ref": "#/definitions/model.Timeframe"
+                },
+                "touchCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Job": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.JobError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inserted": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.RunStatus"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/model.RunTrigger"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "ob-automation"
+                }
+            }
+        },
+        "model.JobError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Margin": {
+            "type": "object",
+            "properties": {
+                "margin": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MarginBatch": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "changed": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "fileName": {
+                    "type": "string",
+                    "example": "margins.csv"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "importedAt": {
+                    "type": "string"
+                },
+                "removed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "restoredFrom": {
+                    "description": "RestoredFrom is the batch whose margins a rollback restored",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 1179
+                },
+                "uploadedBy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MarginBatchDiff": {
+            "type": "object",
+            "properties": {
+                "batch": {
+                    "$ref": "#/definitions/model.MarginBatch"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MarginChange"
+                    }
+                }
+            }
+        },
+        "model.MarginChange": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "batchId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "newMargin": {
+                    "type": "number",
+                    "example": 4
+                },
+                "oldMargin": {
+                    "type": "number",
+                    "example": 5
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.MarginChangeType"
+                        }
+                    ],
+                    "example": "CHANGED"
+                }
+            }
+        },
+        "model.MarginChangeType": {
+            "type": "string",
+            "enum": [
+                "ADDED",
+                "REMOVED",
+                "CHANGED"
+            ],
+            "x-enum-varnames": [
+                "MarginAdded",
+                "MarginRemoved",
+                "MarginChanged"
+            ]
+        },
+        "model.MarginScan": {
+            "type": "object",
+            "properties": {
+                "ageSeconds": {
+                    "description": "AgeSeconds is how old the result was when served",
+                    "type": "integer",
+                    "example": 75
+                },
+                "fetchedAt": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "stale": {
+                    "description": "Stale is set when the result is past its freshness and a refresh runs in the background, or ChartInk is down",
+                    "type": "boolean"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockMarginDto"
+                    }
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH OB 1D"
+                },
+                "total": {
+                    "description": "Total is the number of stocks left after filtering, before paging",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.MarketDataConfig": {
+            "type": "object",
+            "properties": {
+                "backfillDays": {
+                    "description": "BackfillDays is how much history is loaded into the candle store for a new symbol (default 365)",
+                    "type": "integer"
+                },
+                "fileDir": {
+                    "description": "FileDir holds \u003cSYMBOL\u003e.csv candle files for the local file provider",
+                    "type": "string"
+                },
+                "providers": {
+                    "description": "Providers are tried in order; when empty the chain defaults to yahoo then nse",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProviderConfig"
+                    }
+                }
+            }
+        },
+        "model.MessageResponse": {
+            "description": "Standard response containing status and a descriptive message",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Message provides details about the operation result",
+                    "type": "string",
+                    "example": "Otp sent successfully to user@example.com"
+                },
+                "otpSent": {
+                    "description": "OtpSent indicates if the OTP was successfully triggered",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.MitigationConfig": {
+            "type": "object",
+            "properties": {
+                "bearishStrategy": {
+                    "description": "BearishStrategy is checked against bearish zones (default BEARISH CLOSE 200)",
+                    "type": "string"
+                },
+                "bullishStrategy": {
+                    "description": "BullishStrategy is checked against bullish zones (default BULLISH CLOSE 200)",
+                    "type": "string"
+                }
+            }
+        },
+        "model.MongoEnvConfig": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "type": "string"
+                },
+                "brevoApiKey": {
+                    "type": "string"
+                },
+                "brevoEmail": {
+                    "type": "string"
+                },
+                "debug": {
+                    "type": "boolean"
+                },
+                "detection": {
+                    "$ref": "#/definitions/model.DetectionConfig"
+                },
+                "frontendUrls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "jwtSecret": {
+                    "type": "string"
+                },
+                "leverage": {
+                    "type": "number"
+                },
+                "marketData": {
+                    "$ref": "#/definitions/model.MarketDataConfig"
+                },
+                "mitigation": {
+                    "$ref": "#/definitions/model.MitigationConfig"
+                },
+                "nseHolidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rateLimiter": {
+                    "type": "boolean"
+                },
+                "scheduler": {
+                    "$ref": "#/definitions/model.SchedulerConfig"
+                },
+                "telegram": {
+                    "$ref": "#/definitions/model.TelegramConfig"
+                }
+            }
+        },
+        "model.NSEHistoricalData": {
+            "type": "object",
+            "properties": {
+                "chClosingPrice": {
+                    "type": "number"
+                },
+                "chOpeningPrice": {
+                    "type": "number"
+                },
+                "chSymbol": {
+                    "type": "string"
+                },
+                "chTotTradedQty": {
+                    "type": "number"
+                },
+                "chTradeHighPrice": {
+                    "type": "number"
+                },
+                "chTradeLowPrice": {
+                    "type": "number"
+                },
+                "mtimestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NotificationPrefs": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "array",
+                "items": {
+                    "$ref": "#/definitions/model.ChannelType"
+                }
+            }
+        },
+        "model.NotificationPrefsRequest": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "$ref": "#/definitions/model.NotificationPrefs"
+                }
+            }
+        },
+        "model.NotificationSettings": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChannelType"
+                    },
+                    "example": [
+                        "EMAIL",
+                        "TELEGRAM"
+                    ]
+                },
+                "preferences": {
+                    "$ref": "#/definitions/model.NotificationPrefs"
+                },
+                "telegramChatId": {
+                    "type": "string",
+                    "example": "123456789"
+                }
+            }
+        },
+        "model.NotificationTestRequest": {
+            "type": "object",
+            "required": [
+                "channel"
+            ],
+            "properties": {
+                "channel": {
+                    "enum": [
+                        "EMAIL",
+                        "TELEGRAM"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ChannelType"
+                        }
+                    ],
+                    "example": "TELEGRAM"
+                }
+            }
+        },
+        "model.ObRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "Direction defaults to BULLISH. With the date and timeframe it identifies the zone to update or delete",
+                    "enum": [
+                        "BULLISH",
+                        "BEARISH"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Direction"
+                        }
+                    ],
+                    "example": "BULLISH"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "description": "Timeframe defaults to 1D. Intraday zones use \"YYYY-MM-DD HH:mm\" dates.",
+                    "enum": [
+                        "15m",
+                        "1h",
+                        "1D",
+                        "1W",
+                        "1M"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                }
+            }
+        },
+        "model.ObResponse": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "columns": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "$ref": "#/definitions/model.Direction"
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pChange": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "$ref": "#/definitions/model.Timeframe"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PnLSummary": {
+            "type": "object",
+            "properties": {
+                "charges": {
+                    "type": "number",
+                    "example": 1210.4
+                },
+                "closedTrades": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Position"
+                    }
+                },
+                "realisedPnl": {
+                    "type": "number",
+                    "example": 12450.5
+                },
+                "totalPnl": {
+                    "type": "number",
+                    "example": 12836.2
+                },
+                "unrealisedPnl": {
+                    "type": "number",
+                    "example": 385.7
+                }
+            }
+        },
+        "model.Position": {
+            "type": "object",
+            "properties": {
+                "capitalUsed": {
+                    "description": "CapitalUsed is the entry value divided by leverage",
+                    "type": "number",
+                    "example": 5821
+                },
+                "charges": {
+                    "description": "Charges are brokerage, taxes and fees for both legs",
+                    "type": "number",
+                    "example": 42.6
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entryDate": {
+                    "type": "string",
+                    "example": "2025-01-14"
+                },
+                "entryPrice": {
+                    "type": "number",
+                    "example": 2910.5
+                },
+                "exitCharges": {
+                    "description": "ExitCharges are the part of Charges recorded when the trade was closed",
+                    "type": "number",
+                    "example": 21.3
+                },
+                "exitDate": {
+                    "type": "string",
+                    "example": "2025-01-20"
+                },
+                "exitNotes": {
+                    "type": "string",
+                    "example": "Target hit"
+                },
+                "exitPrice": {
+                    "type": "number",
+                    "example": 2990
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastPrice": {
+                    "type": "number",
+                    "example": 2951.2
+                },
+                "leverage": {
+                    "description": "Leverage defaults to the symbol's margin when the trade is recorded",
+                    "type": "number",
+                    "example": 5
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "priceDate": {
+                    "description": "PriceDate is the date of the candle LastPrice comes from",
+                    "type": "string",
+                    "example": "2025-01-16"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "realisedPnl": {
+                    "description": "RealisedPnL is set when the trade is closed, net of charges",
+                    "type": "number",
+                    "example": 752.4
+                },
+                "side": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeSide"
+                        }
+                    ],
+                    "example": "LONG"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeStatus"
+                        }
+                    ],
+                    "example": "OPEN"
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH CLOSE 200"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "unrealisedPct": {
+                    "type": "number",
+                    "example": 6.63
+                },
+                "unrealisedPnl": {
+                    "description": "UnrealisedPnL is net of the charges paid so far; UnrealisedPct is its return on CapitalUsed",
+                    "type": "number",
+                    "example": 385.7
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "zone": {
+                    "$ref": "#/definitions/model.TradeZone"
+                }
+            }
+        },
+        "model.ProviderConfig": {
+            "type": "object",
+            "properties": {
+                "cooldownSeconds": {
+                    "description": "CooldownSeconds is how long an open circuit skips the provider (default 300)",
+                    "type": "integer"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "failureThreshold": {
+                    "description": "FailureThreshold is the number of consecutive failures that opens the circuit (default 5)",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "enum": [
+                        "yahoo",
+                        "nse",
+                        "file"
+                    ],
+                    "example": "yahoo"
+                },
+                "timeoutSeconds": {
+                    "description": "TimeoutSeconds bounds a single call to the provider (default 10)",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProviderStatus": {
+            "type": "object",
+            "properties": {
+                "circuit": {
+                    "type": "string",
+                    "example": "CLOSED"
+                },
+                "consecutiveFailures": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastFailure": {
+                    "type": "string"
+                },
+                "lastSuccess": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Recipient": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Update successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.RunStatus": {
+            "description": "PENDING, RUNNING, SUCCESS or FAILED",
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RUNNING",
+                "SUCCESS",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "RunStatusPending",
+                "RunStatusRunning",
+                "RunStatusSuccess",
+                "RunStatusFailed"
+            ]
+        },
+        "model.RunTrigger": {
+            "description": "SCHEDULED, MANUAL, RETRY, CATCHUP or CHAINED",
+            "type": "string",
+            "enum": [
+                "SCHEDULED",
+                "MANUAL",
+                "RETRY",
+                "CATCHUP",
+                "CHAINED"
+            ],
+            "x-enum-varnames": [
+                "TriggerScheduled",
+                "TriggerManual",
+                "TriggerRetry",
+                "TriggerCatchUp",
+                "TriggerChained"
+            ]
+        },
+        "model.ScanDiff": {
+            "type": "object",
+            "properties": {
+                "entered": {
+                    "description": "Entered are in To but not in From, with their data from To",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockData"
+                    }
+                },
+                "exited": {
+                    "description": "Exited are in From but not in To, with their data from From",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockData"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/model.ScanSnapshot"
+                },
+                "retained": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "RELIANCE"
+                    ]
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH OB 1D"
+                },
+                "to": {
+                    "$ref": "#/definitions/model.ScanSnapshot"
+                }
+            }
+        },
+        "model.ScanPreview": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of stocks the clause matched",
+                    "type": "integer",
+                    "example": 42
+                },
+                "error": {
+                    "$ref": "#/definitions/model.ChartInkError"
+                },
+                "sample": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockData"
+                    }
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.ScanPreviewRequest": {
+            "type": "object",
+            "required": [
+                "scanClause"
+            ],
+            "properties": {
+                "scanClause": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScanSnapshot": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "runs": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockData"
+                    }
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH OB 1D"
+                },
+                "symbols": {
+                    "description": "Symbols are sorted, which makes comparing two snapshots cheap",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "RELIANCE",
+                        "TCS"
+                    ]
+                },
+                "takenAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduledJobDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "lastRun": {
+                    "$ref": "#/definitions/model.SchedulerRun"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nextRun": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "type": "string"
+                },
+                "tradingDayOnly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.SchedulerConfig": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "schedules": {
+                    "description": "Schedules overrides the default cron expression of a job, keyed by job name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.SchedulerRun": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "jobId": {
+                    "type": "string"
+                },
+                "scheduledFor": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.RunStatus"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/model.RunTrigger"
+                }
+            }
+        },
+        "model.SectorData": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "current": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "index": {
+                    "type": "string"
+                },
+                "indexLongName": {
+                    "type": "string"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "pChange": {
+                    "type": "number"
+                },
+                "timeStamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SizingRequest": {
+            "type": "object",
+            "required": [
+                "symbol"
+            ],
+            "properties": {
+                "capital": {
+                    "description": "Capital and RiskPct default to the user's profile, then to 100000 and 1%",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500000
+                },
+                "entry": {
+                    "description": "Entry defaults to the latest close",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2910.5
+                },
+                "riskPct": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "side": {
+                    "enum": [
+                        "LONG",
+                        "SHORT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeSide"
+                        }
+                    ],
+                    "example": "LONG"
+                },
+                "stopLoss": {
+                    "description": "StopLoss defaults to the far side of Zone: its low for longs, its high for shorts",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "zone": {
+                    "description": "Zone picks the zone the stop is taken from. Without a date, the newest active zone of the kind\n(default OB) on the side's direction is used.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeZone"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.SizingResult": {
+            "type": "object",
+            "properties": {
+                "capital": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "capitalRequired": {
+                    "type": "number",
+                    "example": 71598.3
+                },
+                "cappedByCapital": {
+                    "description": "CappedByCapital is set when the quantity was reduced to what the capital can fund",
+                    "type": "boolean",
+                    "example": false
+                },
+                "entry": {
+                    "type": "number",
+                    "example": 2910.5
+                },
+                "leverage": {
+                    "type": "number",
+                    "example": 5
+                },
+                "maxLoss": {
+                    "description": "MaxLoss is the loss if the stop is hit",
+                    "type": "number",
+                    "example": 4981.5
+                },
+                "positionValue": {
+                    "description": "PositionValue is Quantity x Entry; CapitalRequired is that divided by Leverage",
+                    "type": "number",
+                    "example": 357991.5
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "riskAmount": {
+                    "description": "RiskAmount is Capital x RiskPct, the most the trade may lose",
+                    "type": "number",
+                    "example": 5000
+                },
+                "riskPct": {
+                    "type": "number",
+                    "example": 1
+                },
+                "riskPerShare": {
+                    "type": "number",
+                    "example": 40.5
+                },
+                "side": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeSide"
+                        }
+                    ],
+                    "example": "LONG"
+                },
+                "stopLoss": {
+                    "type": "number",
+                    "example": 2870
+                },
+                "stopSource": {
+                    "type": "string",
+                    "enum": [
+                        "REQUEST",
+                        "ZONE"
+                    ],
+                    "example": "ZONE"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "zone": {
+                    "description": "Zone is the zone the stop came from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeZone"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.StockData": {
+            "type": "object",
+            "properties": {
+                "bsecode": {
+                    "type": "string"
+                },
+                "close": {
                     "type": "number"
                 },
-                "perChange30d": {
-                    "type": "number"
+                "columns": {
+                    "description": "Columns keeps every other column the scan returned, e.g. the row number \"sr\" or custom scan fields",
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "perChange365d": {
+                "name": {
+                    "type": "string"
+                },
+                "nsecode": {
+                    "type": "string"
+                },
+                "per_chg": {
                     "type": "number"
                 },
-                "percentChange": {
+                "volume": {
                     "type": "number"
                 }
             }
         },
-        "model.BrevoEmailRequest": {
+        "model.StockMarginDto": {
             "type": "object",
             "properties": {
-                "htmlContent": {
+                "close": {
+                    "type": "number"
+                },
+                "columns": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "sender": {
-                    "$ref": "#/definitions/model.Recipient"
+                "pChange": {
+                    "type": "number"
                 },
-                "subject": {
+                "symbol": {
                     "type": "string"
                 },
-                "to": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Recipient"
-                    }
+                "volume": {
+                    "type": "number"
                 }
             }
         },
-        "model.ChartInkResponseDto": {
+        "model.StockRecord": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "json:\"data\" tells the parser to map the JSON key \"data\" to this field",
+                "fvg": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.StockData"
+                        "$ref": "#/definitions/model.Info"
                     }
-                }
-            }
-        },
-        "model.Margin": {
-            "type": "object",
-            "properties": {
-                "margin": {
-                    "type": "number"
                 },
-                "name": {
-                    "type": "string"
+                "orderBlocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Info"
+                    }
                 },
                 "symbol": {
                     "type": "string"
                 }
             }
         },
-        "model.MessageResponse": {
-            "description": "Standard response containing status and a descriptive message",
+        "model.StrategyDto": {
             "type": "object",
+            "required": [
+                "name",
+                "scanClause"
+            ],
             "properties": {
-                "message": {
-                    "description": "Message provides details about the operation result",
+                "active": {
+                    "type": "boolean"
+                },
+                "category": {
                     "type": "string",
-                    "example": "Otp sent successfully to user@example.com"
+                    "example": "Price Action"
                 },
-                "otpSent": {
-                    "description": "OtpSent indicates if the OTP was successfully triggered",
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "model.MongoEnvConfig": {
-            "type": "object",
-            "properties": {
-                "apiKey": {
+                "createdAt": {
                     "type": "string"
                 },
-                "brevoApiKey": {
+                "description": {
+                    "type": "string",
+                    "example": "Daily bullish order blocks near a 200 DMA"
+                },
+                "name": {
                     "type": "string"
                 },
-                "brevoEmail": {
+                "owner": {
+                    "description": "Owner, SharedWith, Version and the timestamps are set by the server",
+                    "type": "integer"
+                },
+                "preview": {
+                    "description": "Preview is the dry run of the scan clause, only set in save responses that asked for validation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ScanPreview"
+                        }
+                    ]
+                },
+                "scanClause": {
                     "type": "string"
                 },
-                "debug": {
-                    "type": "boolean"
+                "sharedWith": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "frontendUrls": {
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
+                    },
+                    "example": [
+                        "ob",
+                        "swing"
+                    ]
                 },
-                "jwtSecret": {
+                "timeframe": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "leverage": {
-                    "type": "number"
+                "version": {
+                    "type": "integer"
                 },
-                "rateLimiter": {
-                    "type": "boolean"
+                "visibility": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.StrategyVisibility"
+                        }
+                    ],
+                    "example": "PUBLIC"
                 }
             }
         },
-        "model.NSEHistoricalData": {
+        "model.StrategySharingRequest": {
             "type": "object",
+            "required": [
+                "visibility"
+            ],
             "properties": {
-                "chClosingPrice": {
-                    "type": "number"
+                "sharedWith": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "friend@example.com"
+                    ]
                 },
-                "chOpeningPrice": {
-                    "type": "number"
+                "visibility": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.StrategyVisibility"
+                        }
+                    ],
+                    "example": "SHARED"
+                }
+            }
+        },
+        "model.StrategyStats": {
+            "type": "object",
+            "properties": {
+                "avgLoss": {
+                    "type": "number",
+                    "example": -556.1
                 },
-                "chSymbol": {
-                    "type": "string"
+                "avgPnl": {
+                    "type": "number",
+                    "example": 352.09
                 },
-                "chTradeHighPrice": {
-                    "type": "number"
+                "avgWin": {
+                    "type": "number",
+                    "example": 1120.5
                 },
-                "chTradeLowPrice": {
-                    "type": "number"
+                "breakevens": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "mtimestamp": {
-                    "type": "string"
+                "losses": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "openTrades": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "realisedPnl": {
+                    "type": "number",
+                    "example": 8450.2
+                },
+                "strategy": {
+                    "description": "Strategy is empty for trades not linked to one",
+                    "type": "string",
+                    "example": "BULLISH CLOSE 200"
+                },
+                "trades": {
+                    "description": "Trades counts the closed trades: the wins, losses and breakevens",
+                    "type": "integer",
+                    "example": 24
+                },
+                "winRate": {
+                    "description": "WinRate is the percent of closed trades with a positive realised PnL",
+                    "type": "number",
+                    "example": 54.17
+                },
+                "wins": {
+                    "type": "integer",
+                    "example": 13
                 }
             }
         },
-        "model.ObRequest": {
+        "model.StrategyVersion": {
             "type": "object",
             "properties": {
-                "date": {
+                "createdAt": {
                     "type": "string"
                 },
-                "high": {
-                    "type": "number"
+                "editedBy": {
+                    "description": "EditedBy is the user ID of the admin who saved the clause, 0 for clauses recorded from before versioning",
+                    "type": "integer"
                 },
-                "low": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "symbol": {
+                "restoredFrom": {
+                    "description": "RestoredFrom is the version this one restored, if it was a rollback",
+                    "type": "integer"
+                },
+                "scanClause": {
                     "type": "string"
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH OB 1D"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "model.Recipient": {
+        "model.StrategyVisibility": {
+            "description": "PRIVATE, SHARED or PUBLIC",
+            "type": "string",
+            "enum": [
+                "PRIVATE",
+                "SHARED",
+                "PUBLIC"
+            ],
+            "x-enum-varnames": [
+                "StrategyPrivate",
+                "StrategyShared",
+                "StrategyPublic"
+            ]
+        },
+        "model.TelegramConfig": {
             "type": "object",
             "properties": {
-                "email": {
+                "baseUrl": {
+                    "description": "BaseURL of the Bot API (default https://api.telegram.org); point it at a stub to test locally",
                     "type": "string"
                 },
-                "name": {
+                "botToken": {
                     "type": "string"
                 }
             }
         },
-        "model.Response": {
+        "model.TelegramLinkRequest": {
             "type": "object",
+            "required": [
+                "chatId"
+            ],
             "properties": {
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message": {
+                "chatId": {
                     "type": "string",
-                    "example": "Update successful"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "123456789"
                 }
             }
         },
-        "model.SectorData": {
+        "model.Timeframe": {
+            "type": "string",
+            "enum": [
+                "15m",
+                "1h",
+                "1D",
+                "1W",
+                "1M"
+            ],
+            "x-enum-varnames": [
+                "Timeframe15m",
+                "Timeframe1h",
+                "Timeframe1D",
+                "Timeframe1W",
+                "Timeframe1M"
+            ]
+        },
+        "model.Trade": {
             "type": "object",
             "properties": {
-                "close": {
-                    "type": "number"
+                "charges": {
+                    "description": "Charges are brokerage, taxes and fees for both legs",
+                    "type": "number",
+                    "example": 42.6
                 },
-                "current": {
-                    "type": "number"
+                "createdAt": {
+                    "type": "string"
                 },
-                "high": {
-                    "type": "number"
+                "entryDate": {
+                    "type": "string",
+                    "example": "2025-01-14"
                 },
-                "index": {
-                    "type": "string"
+                "entryPrice": {
+                    "type": "number",
+                    "example": 2910.5
                 },
-                "indexLongName": {
-                    "type": "string"
+                "exitCharges": {
+                    "description": "ExitCharges are the part of Charges recorded when the trade was closed",
+                    "type": "number",
+                    "example": 21.3
                 },
-                "low": {
-                    "type": "number"
+                "exitDate": {
+                    "type": "string",
+                    "example": "2025-01-20"
                 },
-                "open": {
-                    "type": "number"
+                "exitNotes": {
+                    "type": "string",
+                    "example": "Target hit"
                 },
-                "pChange": {
-                    "type": "number"
+                "exitPrice": {
+                    "type": "number",
+                    "example": 2990
                 },
-                "timeStamp": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "model.StockData": {
-            "type": "object",
-            "properties": {
-                "close": {
-                    "type": "number"
                 },
-                "name": {
+                "leverage": {
+                    "description": "Leverage defaults to the symbol's margin when the trade is recorded",
+                    "type": "number",
+                    "example": 5
+                },
+                "notes": {
                     "type": "string"
                 },
-                "nsecode": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "realisedPnl": {
+                    "description": "RealisedPnL is set when the trade is closed, net of charges",
+                    "type": "number",
+                    "example": 752.4
+                },
+                "side": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeSide"
+                        }
+                    ],
+                    "example": "LONG"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeStatus"
+                        }
+                    ],
+                    "example": "OPEN"
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH CLOSE 200"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "zone": {
+                    "$ref": "#/definitions/model.TradeZone"
                 }
             }
         },
-        "model.StockMarginDto": {
+        "model.TradeRequest": {
             "type": "object",
+            "required": [
+                "entryPrice",
+                "quantity",
+                "symbol"
+            ],
             "properties": {
-                "close": {
-                    "type": "number"
+                "charges": {
+                    "description": "Charges of the entry leg; a closed trade keeps its exit charges on top",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 21.3
+                },
+                "entryDate": {
+                    "description": "EntryDate is YYYY-MM-DD; defaults to today",
+                    "type": "string",
+                    "example": "2025-01-14"
+                },
+                "entryPrice": {
+                    "type": "number",
+                    "example": 2910.5
+                },
+                "leverage": {
+                    "description": "Leverage defaults to the symbol's margin, or 1 without one",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Entered on first touch of the daily OB"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "margin": {
-                    "type": "number"
+                "side": {
+                    "enum": [
+                        "LONG",
+                        "SHORT"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TradeSide"
+                        }
+                    ],
+                    "example": "LONG"
                 },
-                "name": {
-                    "type": "string"
+                "strategy": {
+                    "type": "string",
+                    "example": "BULLISH CLOSE 200"
                 },
                 "symbol": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.Strategy": {
-            "type": "object",
-            "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "RELIANCE"
                 },
-                "scanClause": {
-                    "type": "string"
+                "zone": {
+                    "$ref": "#/definitions/model.TradeZone"
                 }
             }
         },
-        "model.StrategyDto": {
-            "type": "object",
-            "required": [
-                "name",
-                "scanClause"
+        "model.TradeSide": {
+            "type": "string",
+            "enum": [
+                "LONG",
+                "SHORT"
             ],
+            "x-enum-varnames": [
+                "SideLong",
+                "SideShort"
+            ]
+        },
+        "model.TradeStatus": {
+            "type": "string",
+            "enum": [
+                "OPEN",
+                "CLOSED"
+            ],
+            "x-enum-varnames": [
+                "TradeOpen",
+                "TradeClosed"
+            ]
+        },
+        "model.TradeZone": {
+            "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-10"
                 },
-                "name": {
-                    "type": "string"
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "OB",
+                        "FVG"
+                    ],
+                    "example": "OB"
                 },
-                "scanClause": {
-                    "type": "string"
+                "timeframe": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Timeframe"
+                        }
+                    ],
+                    "example": "1D"
                 }
             }
         },
@@ -1930,6 +8215,22 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateTradingDefaultsRequest": {
+            "type": "object",
+            "properties": {
+                "defaultCapital": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500000
+                },
+                "defaultRiskPct": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
         "model.UserDto": {
             "type": "object",
             "required": [
@@ -1940,6 +8241,14 @@ const docTemplate = `{
                 "confirmPassword": {
                     "type": "string"
                 },
+                "defaultCapital": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "defaultRiskPct": {
+                    "type": "number",
+                    "example": 1
+                },
                 "email": {
                     "type": "string"
                 },
@@ -2006,6 +8315,331 @@ const docTemplate = `{
                     "example": "123456"
                 }
             }
+        },
+        "model.Watchlist": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Items are kept in display order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WatchlistItem"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Swing"
+                },
+                "position": {
+                    "description": "Position orders a user's watchlists, lowest first",
+                    "type": "integer",
+                    "example": 0
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WatchlistEntry": {
+            "type": "object",
+            "properties": {
+                "addedAt": {
+                    "type": "string"
+                },
+                "candle": {
+                    "description": "Candle is the latest daily candle, including a session still in progress",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Candle"
+                        }
+                    ]
+                },
+                "change": {
+                    "type": "number",
+                    "example": 12.4
+                },
+                "fvg": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Info"
+                    }
+                },
+                "margin": {
+                    "type": "number",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Reliance Industries Ltd"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Waiting for daily OB retest"
+                },
+                "orderBlocks": {
+                    "description": "OrderBlocks and Fvg are the symbol's FRESH and TESTED zones",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Info"
+                    }
+                },
+                "pChange": {
+                    "type": "number",
+                    "example": 0.42
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                }
+            }
+        },
+        "model.WatchlistItem": {
+            "type": "object",
+            "properties": {
+                "addedAt": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Waiting for daily OB retest"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                }
+            }
+        },
+        "model.WatchlistItemRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Waiting for daily OB retest"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "RELIANCE"
+                }
+            }
+        },
+        "model.WatchlistOrderRequest": {
+            "type": "object",
+            "required": [
+                "order"
+            ],
+            "properties": {
+                "order": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "RELIANCE",
+                        "TCS",
+                        "INFY"
+                    ]
+                }
+            }
+        },
+        "model.WatchlistRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Swing"
+                }
+            }
+        },
+        "model.WatchlistView": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WatchlistEntry"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Swing"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "model.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WebhookEvent"
+                    },
+                    "example": [
+                        "ob.mitigated"
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/trades"
+                }
+            }
+        },
+        "model.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.WebhookEvent"
+                        }
+                    ],
+                    "example": "ob.mitigated"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "NextAttemptAt is set while a retry is pending",
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "webhookId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookEvent": {
+            "type": "string",
+            "enum": [
+                "scan.completed",
+                "ob.created",
+                "ob.mitigated",
+                "fvg.created",
+                "fvg.mitigated",
+                "automation.finished",
+                "webhook.test"
+            ],
+            "x-enum-varnames": [
+                "WebhookScanCompleted",
+                "WebhookOBCreated",
+                "WebhookOBMitigated",
+                "WebhookFvgCreated",
+                "WebhookFvgMitigated",
+                "WebhookAutomationFinished",
+                "WebhookTest"
+            ]
+        },
+        "model.WebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.WebhookEvent"
+                    },
+                    "example": [
+                        "ob.mitigated",
+                        "fvg.created"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16,
+                    "example": "change-me-to-a-long-secret"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/trades"
+                }
+            }
+        },
+        "model.ZoneStatus": {
+            "type": "string",
+            "enum": [
+                "FRESH",
+                "TESTED",
+                "MITIGATED",
+                "INVALIDATED"
+            ],
+            "x-enum-varnames": [
+                "ZoneFresh",
+                "ZoneTested",
+                "ZoneMitigated",
+                "ZoneInvalidated"
+            ]
         }
     }
 }`
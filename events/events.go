@@ -0,0 +1,121 @@
+// Package events is an in-process publish/subscribe bus. Publishing never blocks: every subscriber
+// has its own buffered queue, drained by its own goroutine, and events are dropped when it is full.
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Type names what happened
+type Type string
+
+const (
+	// CandlesSynced is published after the candle store is brought up to date
+	CandlesSynced Type = "candles.synced"
+	// ZoneDetected is published when a new order block or FVG is saved; Data is a model.ZoneEvent
+	ZoneDetected Type = "zone.detected"
+	// ZoneMitigated is published when a stored zone is validly mitigated; Data is a model.ZoneEvent
+	ZoneMitigated Type = "zone.mitigated"
+	// MitigationChecked is published after a scheduled mitigation check; Data is a model.MitigationEvent
+	MitigationChecked Type = "mitigation.checked"
+	// AlertTriggered is published when an alert fires; Data is a model.AlertHistory
+	AlertTriggered Type = "alert.triggered"
+	// ScanCompleted is published after a ChartInk scan returns; Data is a model.ScanEvent
+	ScanCompleted Type = "scan.completed"
+	// AutomationFinished is published after an OB or FVG automation run; Data is a model.AutomationEvent
+	AutomationFinished Type = "automation.finished"
+	// JobProgress is published whenever a background job's progress is saved; Data is a model.Job without its errors
+	JobProgress Type = "job.progress"
+)
+
+const defaultBuffer = 256
+
+// Event is a message on the bus
+type Event struct {
+	Type   Type      `json:"type"`
+	Symbol string    `json:"symbol,omitempty"`
+	Time   time.Time `json:"time"`
+	Data   any       `json:"data,omitempty"`
+}
+
+type subscriber struct {
+	name  string
+	queue chan Event
+}
+
+// Bus fans events out to subscribers. A nil *Bus discards everything, so publishers work without one.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[*subscriber]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*subscriber]struct{})}
+}
+
+// Publish delivers e to every subscriber, stamping the time if unset.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		select {
+		case sub.queue <- e:
+		default:
+			log.Printf("events: %s is falling behind, dropped %s", sub.name, e.Type)
+		}
+	}
+}
+
+// Subscribe calls handler for every event published from now on, one at a time. A buffer of zero uses the
+// default. The returned function unsubscribes; events still queued are discarded.
+func (b *Bus) Subscribe(name string, buffer int, handler func(Event)) (unsubscribe func()) {
+	if buffer <= 0 {
+		buffer = defaultBuffer
+	}
+	sub := &subscriber{name: name, queue: make(chan Event, buffer)}
+	done := make(chan struct{})
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case e := <-sub.queue:
+				handle(name, handler, e)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, sub)
+			b.mu.Unlock()
+			close(done)
+		})
+	}
+}
+
+// handle keeps a panicking subscriber from taking down its goroutine.
+func handle(name string, handler func(Event), e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("events: %s panicked handling %s: %v", name, e.Type, r)
+		}
+	}()
+	handler(e)
+}
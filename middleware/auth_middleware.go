@@ -43,6 +43,18 @@ func AuthMiddleware(isProduction bool) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuth sets the user for public routes that show more to signed-in users, and lets anonymous requests through.
+func OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tokenString, err := c.Cookie("auth_token"); err == nil {
+			if claims, err := auth.ValidateToken(tokenString); err == nil {
+				c.Set("user", claims.User)
+			}
+		}
+		c.Next()
+	}
+}
+
 func AdminOnly() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Using a helper makes the middleware much shorter
@@ -72,3 +84,9 @@ func GetUser(c *gin.Context) (model.UserDto, bool) {
 	user, ok := val.(model.UserDto)
 	return user, ok
 }
+
+// GetUserID returns the signed-in user's ID, or 0 for anonymous requests on OptionalAuth routes
+func GetUserID(c *gin.Context) int64 {
+	user, _ := GetUser(c)
+	return user.UserID
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlertType is what an alert watches for
+type AlertType string
+
+const (
+	// AlertPrice fires when price crosses Level in the alert's Condition
+	AlertPrice AlertType = "PRICE"
+	// AlertNewZone fires when an order block or FVG is saved for the symbol
+	AlertNewZone AlertType = "NEW_ZONE"
+	// AlertZoneMitigated fires when a stored zone of the symbol is validly mitigated
+	AlertZoneMitigated AlertType = "ZONE_MITIGATED"
+)
+
+// IsValid reports whether t is a known alert type.
+func (t AlertType) IsValid() bool {
+	return t == AlertPrice || t == AlertNewZone || t == AlertZoneMitigated
+}
+
+// AlertCondition is the side a price alert's level is crossed from
+type AlertCondition string
+
+const (
+	CrossAbove AlertCondition = "ABOVE"
+	CrossBelow AlertCondition = "BELOW"
+)
+
+// Alert is a user's subscription to a symbol
+type Alert struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID int64              `bson:"userId" json:"userId"`
+	Symbol string             `bson:"symbol" json:"symbol" example:"RELIANCE"`
+	Type   AlertType          `bson:"type" json:"type" example:"PRICE"`
+	// Condition and Level apply to PRICE alerts
+	Condition AlertCondition `bson:"condition,omitempty" json:"condition,omitempty" example:"ABOVE"`
+	Level     float64        `bson:"level,omitempty" json:"level,omitempty" example:"3000"`
+	// Kind, Direction and Timeframe narrow zone alerts; empty matches any
+	Kind      string    `bson:"kind,omitempty" json:"kind,omitempty" example:"OB"`
+	Direction Direction `bson:"direction,omitempty" json:"direction,omitempty" example:"BULLISH"`
+	Timeframe Timeframe `bson:"timeframe,omitempty" json:"timeframe,omitempty" example:"1D"`
+	Active    bool      `bson:"active" json:"active"`
+	// CooldownMinutes is the quiet period after the alert fires
+	CooldownMinutes int        `bson:"cooldownMinutes" json:"cooldownMinutes" example:"60"`
+	TriggerCount    int        `bson:"triggerCount" json:"triggerCount"`
+	LastTriggeredAt *time.Time `bson:"lastTriggeredAt,omitempty" json:"lastTriggeredAt,omitempty"`
+	CreatedAt       time.Time  `bson:"createdAt" json:"createdAt"`
+}
+
+// AlertRequest creates an alert
+type AlertRequest struct {
+	Symbol    string         `json:"symbol" binding:"required" example:"RELIANCE"`
+	Type      AlertType      `json:"type" binding:"required" example:"PRICE" enums:"PRICE,NEW_ZONE,ZONE_MITIGATED"`
+	Condition AlertCondition `json:"condition" example:"ABOVE" enums:"ABOVE,BELOW"`
+	Level     float64        `json:"level" binding:"gte=0" example:"3000"`
+	Kind      string         `json:"kind" example:"OB" enums:"OB,FVG"`
+	Direction Direction      `json:"direction" example:"BULLISH" enums:"BULLISH,BEARISH"`
+	Timeframe Timeframe      `json:"timeframe" example:"1D" enums:"15m,1h,1D,1W,1M"`
+	// CooldownMinutes defaults to 60
+	CooldownMinutes int `json:"cooldownMinutes" binding:"gte=0" example:"60"`
+}
+
+// AlertHistory records one firing of an alert
+type AlertHistory struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AlertID primitive.ObjectID `bson:"alertId" json:"alertId"`
+	UserID  int64              `bson:"userId" json:"userId"`
+	Symbol  string             `bson:"symbol" json:"symbol" example:"RELIANCE"`
+	Type    AlertType          `bson:"type" json:"type" example:"PRICE"`
+	Message string             `bson:"message" json:"message" example:"RELIANCE crossed above 3000.00 (high 3012.40)"`
+	// DedupKey identifies the occurrence; an alert is delivered at most once per key
+	DedupKey  string `bson:"dedupKey" json:"dedupKey" example:"price:2025-01-14"`
+	Delivered bool   `bson:"delivered" json:"delivered"`
+	// Error is the last failed delivery; a failed firing is retried when the alert is checked again
+	Error       string    `bson:"error,omitempty" json:"error,omitempty"`
+	Attempts    int       `bson:"attempts" json:"attempts" example:"1"`
+	TriggeredAt time.Time `bson:"triggeredAt" json:"triggeredAt"`
+}
+
+// AlertActiveRequest pauses or resumes an alert
+type AlertActiveRequest struct {
+	Active *bool `json:"active" binding:"required" example:"false"`
+}
@@ -0,0 +1,94 @@
+package model
+
+// Backtest exit reasons
+const (
+	ExitStopLoss = "STOP_LOSS"
+	ExitTarget   = "TARGET"
+	ExitTime     = "TIME"
+	// ExitOpen marks a trade still open at the end of the data, closed at the last price
+	ExitOpen = "OPEN"
+)
+
+// BacktestRequest replays stored zones against stored candles.
+type BacktestRequest struct {
+	// Symbols limits the run; empty means every symbol with price action
+	Symbols []string `json:"symbols" example:"RELIANCE,TCS"`
+	// Kind is OB, FVG or empty for both
+	Kind string `json:"kind" example:"OB" enums:"OB,FVG"`
+	// Direction is BULLISH, BEARISH or empty for both
+	Direction Direction `json:"direction" example:"BULLISH" enums:"BULLISH,BEARISH"`
+	// Timeframe of the zones and the candles they are replayed on; defaults to 1D. Intraday candles are not
+	// stored, so 15m and 1h cannot be backtested.
+	Timeframe Timeframe `json:"timeframe" example:"1D" enums:"1D,1W,1M"`
+	// From and To are YYYY-MM-DD and bound the zone dates; the default range is the last year
+	From string `json:"from" example:"2024-01-01"`
+	To   string `json:"to" example:"2024-12-31"`
+
+	// StopLossPct is the stop distance from entry. Zero places the stop at the far side of the zone.
+	StopLossPct float64 `json:"stopLossPct" example:"0"`
+	// TargetPct is the target distance from entry. Zero uses RewardRisk instead.
+	TargetPct float64 `json:"targetPct" example:"0"`
+	// RewardRisk is the target as a multiple of the stop distance; defaults to 2
+	RewardRisk float64 `json:"rewardRisk" example:"2"`
+	// MaxHoldCandles closes a trade at the close of this many candles after entry; defaults to 20
+	MaxHoldCandles int `json:"maxHoldCandles" example:"20"`
+
+	// Capital is the starting equity; defaults to 100000
+	Capital float64 `json:"capital" example:"100000"`
+	// PositionPct is the share of starting capital committed per trade; defaults to 10
+	PositionPct float64 `json:"positionPct" example:"10"`
+	// UseMargin multiplies the position by the symbol's margin (leverage)
+	UseMargin bool `json:"useMargin" example:"true"`
+}
+
+// BacktestTrade is one simulated entry and exit
+type BacktestTrade struct {
+	Symbol     string    `json:"symbol" example:"RELIANCE"`
+	Kind       string    `json:"kind" example:"OB"`
+	Direction  Direction `json:"direction" example:"BULLISH"`
+	ZoneDate   string    `json:"zoneDate" example:"2024-03-04"`
+	EntryDate  string    `json:"entryDate" example:"2024-03-12"`
+	EntryPrice float64   `json:"entryPrice" example:"2910.5"`
+	StopLoss   float64   `json:"stopLoss" example:"2870"`
+	Target     float64   `json:"target" example:"2991.5"`
+	ExitDate   string    `json:"exitDate" example:"2024-03-20"`
+	ExitPrice  float64   `json:"exitPrice" example:"2991.5"`
+	ExitReason string    `json:"exitReason" example:"TARGET" enums:"STOP_LOSS,TARGET,TIME,OPEN"`
+	Quantity   int       `json:"quantity" example:"17"`
+	Leverage   float64   `json:"leverage" example:"5"`
+	PnL        float64   `json:"pnl" example:"1377"`
+	// RMultiple is the result in units of the initial stop distance
+	RMultiple float64 `json:"rMultiple" example:"2"`
+}
+
+// EquityPoint is the equity after the trades closed on a date
+type EquityPoint struct {
+	Date   string  `json:"date" example:"2024-03-20"`
+	Equity float64 `json:"equity" example:"101377"`
+}
+
+// BacktestResult summarises a backtest run
+type BacktestResult struct {
+	TotalTrades int `json:"totalTrades" example:"42"`
+	// Wins, Losses and Breakevens count the closed trades; OpenTrades are still open at the end of the range
+	Wins       int `json:"wins" example:"19"`
+	Losses     int `json:"losses" example:"21"`
+	Breakevens int `json:"breakevens" example:"1"`
+	OpenTrades int `json:"openTrades" example:"1"`
+	// WinRate is the percent of closed trades with a positive PnL
+	WinRate float64 `json:"winRate" example:"47.5"`
+	// Expectancy is the average PnL per closed trade; AvgRMultiple the same in R
+	Expectancy   float64 `json:"expectancy" example:"312.4"`
+	AvgRMultiple float64 `json:"avgRMultiple" example:"0.36"`
+	// TotalPnL is realised by closed trades; OpenPnL is the open trades' PnL at the last close
+	TotalPnL    float64 `json:"totalPnl" example:"13120.8"`
+	OpenPnL     float64 `json:"openPnl" example:"-240.5"`
+	FinalEquity float64 `json:"finalEquity" example:"113120.8"`
+	// MaxDrawdown is the largest fall from a peak of the equity curve, absolute and as a percent of that peak
+	MaxDrawdown    float64         `json:"maxDrawdown" example:"8450"`
+	MaxDrawdownPct float64         `json:"maxDrawdownPct" example:"7.62"`
+	EquityCurve    []EquityPoint   `json:"equityCurve"`
+	Trades         []BacktestTrade `json:"trades"`
+	// Skipped lists symbols whose candles could not be loaded
+	Skipped []string `json:"skipped,omitempty"`
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChartInkError is a scan ChartInk refused or failed to run
+type ChartInkError struct {
+	// StatusCode is ChartInk's HTTP status, 200 when the response carried a scan_error
+	StatusCode int    `json:"statusCode" example:"200"`
+	Message    string `json:"message" example:"Invalid attribute 'closee'"`
+}
+
+func (e *ChartInkError) Error() string {
+	return fmt.Sprintf("chartink api error: %d %s", e.StatusCode, e.Message)
+}
+
+// Rejected reports whether ChartInk refused the scan clause itself, rather than failing to run it.
+func (e *ChartInkError) Rejected() bool {
+	switch e.StatusCode {
+	case 419, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode < http.StatusInternalServerError
+}
+
+// ScanPreview is the result of a dry run of a scan clause, which is neither cached nor snapshotted
+type ScanPreview struct {
+	Valid bool `json:"valid" example:"true"`
+	// Count is the number of stocks the clause matched
+	Count  int            `json:"count" example:"42"`
+	Sample []StockData    `json:"sample"`
+	Error  *ChartInkError `json:"error,omitempty"`
+}
+
+// ScanPreviewRequest is a scan clause to dry run
+type ScanPreviewRequest struct {
+	ScanClause string `json:"scanClause" binding:"required"`
+}
+
+// ChartInkHealth reports the ChartInk session and its upstream's health since startup
+type ChartInkHealth struct {
+	Circuit             string     `json:"circuit" example:"CLOSED"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastFailure         *time.Time `json:"lastFailure,omitempty"`
+	LastSuccess         *time.Time `json:"lastSuccess,omitempty"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	HasToken            bool       `json:"hasToken"`
+	TokenRefreshedAt    *time.Time `json:"tokenRefreshedAt,omitempty"`
+
+	Requests       int64 `json:"requests" example:"120"`
+	Failures       int64 `json:"failures" example:"2"`
+	Retries        int64 `json:"retries" example:"5"`
+	RateLimited    int64 `json:"rateLimited" example:"1"`
+	TokenRefreshes int64 `json:"tokenRefreshes" example:"3"`
+	// ServedStale counts scans answered from the last good result because ChartInk was unavailable
+	ServedStale   int64 `json:"servedStale" example:"0"`
+	LastLatencyMs int64 `json:"lastLatencyMs" example:"850"`
+}
+
+// MarginScan is a strategy's scan result joined with margin data, highest margin first
+type MarginScan struct {
+	Strategy  string    `json:"strategy" example:"BULLISH OB 1D"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// AgeSeconds is how old the result was when served
+	AgeSeconds int64 `json:"ageSeconds" example:"75"`
+	// Stale is set when the result is past its freshness and a refresh runs in the background, or ChartInk is down
+	Stale bool `json:"stale"`
+	// Total is the number of stocks left after filtering, before paging
+	Total  int              `json:"total" example:"42"`
+	Page   int              `json:"page" example:"1"`
+	Size   int              `json:"size" example:"20"`
+	Stocks []StockMarginDto `json:"stocks"`
+}
+
+// MarginScanQuery sorts, filters and pages a margin scan
+type MarginScanQuery struct {
+	// Sort is margin, close, volume, pChange, name, symbol or any other scan column; default margin
+	Sort      string
+	Ascending bool
+	MinPrice  float64
+	MinVolume float64
+	MinMargin float64
+	// Page counts from 1; a Size of 0 returns every stock
+	Page int
+	Size int
+}
+
+// UnmarshalJSON reads a ChartInk result row, keeping the columns without a field in Columns. ChartInk sends codes and
+// numbers as either strings or numbers depending on the scan, so both are accepted.
+func (d *StockData) UnmarshalJSON(data []byte) error {
+	var row map[string]any
+	if err := json.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	*d = StockData{
+		NSECode: columnString(row, "nsecode"),
+		Name:    columnString(row, "name"),
+		Close:   float32(columnFloat(row, "close")),
+		BSECode: columnString(row, "bsecode"),
+		PChange: columnFloat(row, "per_chg"),
+		Volume:  columnFloat(row, "volume"),
+	}
+	// Rows re-read from JSON we wrote ourselves carry their extra columns nested
+	if nested, ok := row["columns"].(map[string]any); ok {
+		delete(row, "columns")
+		for k, v := range nested {
+			row[k] = v
+		}
+	}
+	if len(row) > 0 {
+		d.Columns = row
+	}
+	return nil
+}
+
+// columnString removes a column from a row and returns it as a string.
+func columnString(row map[string]any, key string) string {
+	v := row[key]
+	delete(row, key)
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// columnFloat removes a column from a row and returns it as a number, 0 if it is not one.
+func columnFloat(row map[string]any, key string) float64 {
+	v := row[key]
+	delete(row, key)
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(t), 64)
+		return f
+	}
+	return 0
+}
@@ -5,15 +5,28 @@ var (
 )
 
 type MongoEnvConfig struct {
-	ID           string   `json:"-" bson:"_id,omitempty"`
-	FrontendUrls []string `json:"frontendUrls" bson:"frontendUrls"`
-	BrevoEmail   string   `json:"brevoEmail" bson:"brevoEmail"`
-	BrevoApiKey  string   `json:"brevoApiKey" bson:"brevoApiKey"`
-	ApiKey       string   `json:"apiKey" bson:"apiKey"`
-	Leverage     float32  `json:"leverage" bson:"leverage"`
-	DebugMode    bool     `json:"debug" bson:"debug"`
-	RateLimiter  bool     `json:"rateLimiter" bson:"rateLimiter"`
-	JwtSecret    string   `json:"jwtSecret" bson:"jwtSecret"`
+	ID           string           `json:"-" bson:"_id,omitempty"`
+	FrontendUrls []string         `json:"frontendUrls" bson:"frontendUrls"`
+	BrevoEmail   string           `json:"brevoEmail" bson:"brevoEmail"`
+	BrevoApiKey  string           `json:"brevoApiKey" bson:"brevoApiKey"`
+	ApiKey       string           `json:"apiKey" bson:"apiKey"`
+	Leverage     float32          `json:"leverage" bson:"leverage"`
+	DebugMode    bool             `json:"debug" bson:"debug"`
+	RateLimiter  bool             `json:"rateLimiter" bson:"rateLimiter"`
+	JwtSecret    string           `json:"jwtSecret" bson:"jwtSecret"`
+	NseHolidays  []string         `json:"nseHolidays" bson:"nseHolidays"`
+	Scheduler    SchedulerConfig  `json:"scheduler" bson:"scheduler"`
+	MarketData   MarketDataConfig `json:"marketData" bson:"marketData"`
+	Detection    DetectionConfig  `json:"detection" bson:"detection"`
+	Telegram     TelegramConfig   `json:"telegram" bson:"telegram"`
+	Mitigation   MitigationConfig `json:"mitigation" bson:"mitigation"`
+}
+
+// SchedulerConfig controls the in-process job scheduler
+type SchedulerConfig struct {
+	Disabled bool `json:"disabled" bson:"disabled"`
+	// Schedules overrides the default cron expression of a job, keyed by job name
+	Schedules map[string]string `json:"schedules" bson:"schedules"`
 }
 
 // --- SYSTEM CONFIG ---
@@ -25,3 +38,47 @@ type EnvConfig struct {
 	MongoPassword string `json:"mongoPassword"`
 	Environment   string `json:"environment"`
 }
+
+// MarketDataConfig orders the market data providers and tunes their fallback behaviour
+type MarketDataConfig struct {
+	// Providers are tried in order; when empty the chain defaults to yahoo then nse
+	Providers []ProviderConfig `json:"providers" bson:"providers"`
+	// FileDir holds <SYMBOL>.csv candle files for the local file provider
+	FileDir string `json:"fileDir" bson:"fileDir"`
+	// BackfillDays is how much history is loaded into the candle store for a new symbol (default 365)
+	BackfillDays int `json:"backfillDays" bson:"backfillDays"`
+}
+
+// ProviderConfig configures a single provider in the chain
+type ProviderConfig struct {
+	Name     string `json:"name" bson:"name" example:"yahoo" enums:"yahoo,nse,file"`
+	Disabled bool   `json:"disabled" bson:"disabled"`
+	// TimeoutSeconds bounds a single call to the provider (default 10)
+	TimeoutSeconds int `json:"timeoutSeconds" bson:"timeoutSeconds"`
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default 5)
+	FailureThreshold int `json:"failureThreshold" bson:"failureThreshold"`
+	// CooldownSeconds is how long an open circuit skips the provider (default 300)
+	CooldownSeconds int `json:"cooldownSeconds" bson:"cooldownSeconds"`
+}
+
+// MitigationConfig names the ChartInk strategies whose stocks the mitigation checks test against stored zones
+type MitigationConfig struct {
+	// BullishStrategy is checked against bullish zones (default BULLISH CLOSE 200)
+	BullishStrategy string `json:"bullishStrategy" bson:"bullishStrategy"`
+	// BearishStrategy is checked against bearish zones (default BEARISH CLOSE 200)
+	BearishStrategy string `json:"bearishStrategy" bson:"bearishStrategy"`
+}
+
+// DetectionConfig tunes the native order block and FVG detection rules
+type DetectionConfig struct {
+	// Timeframes scanned by the scheduled detection job (default 1D)
+	Timeframes []Timeframe `json:"timeframes" bson:"timeframes"`
+	// MinGapPct is the smallest FVG, as a percent of the displacement candle's close (default 0.2)
+	MinGapPct float64 `json:"minGapPct" bson:"minGapPct"`
+	// MinDisplacementPct is the smallest displacement candle body, as a percent of its open (default 1.5)
+	MinDisplacementPct float64 `json:"minDisplacementPct" bson:"minDisplacementPct"`
+	// VolumeMultiplier requires displacement volume of at least this multiple of the average volume; 0 disables the check
+	VolumeMultiplier float64 `json:"volumeMultiplier" bson:"volumeMultiplier"`
+	// VolumeLookback is the number of candles averaged for volume confirmation (default 20)
+	VolumeLookback int `json:"volumeLookback" bson:"volumeLookback"`
+}
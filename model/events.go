@@ -0,0 +1,45 @@
+package model
+
+// The types in this file are the Data of events on the in-process bus (see package events)
+
+// ZoneEvent describes a zone on the event bus
+type ZoneEvent struct {
+	Symbol    string    `json:"symbol" example:"RELIANCE"`
+	Kind      string    `json:"kind" example:"OB"`
+	Direction Direction `json:"direction" example:"BULLISH"`
+	Timeframe Timeframe `json:"timeframe" example:"1D"`
+	Date      string    `json:"date" example:"2025-01-10"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+}
+
+// ScanEvent describes a completed ChartInk scan on the event bus. It carries the strategy's visibility so subscribers
+// only pass it on to users who can see the strategy.
+type ScanEvent struct {
+	Strategy   string             `json:"strategy" example:"BULLISH OB 1D"`
+	Count      int                `json:"count" example:"12"`
+	Symbols    []string           `json:"symbols" example:"RELIANCE,TCS"`
+	Owner      int64              `json:"-"`
+	Visibility StrategyVisibility `json:"-"`
+	SharedWith []int64            `json:"-"`
+}
+
+// VisibleTo reports whether a user can see the scanned strategy; userID 0 only sees public strategies.
+func (e ScanEvent) VisibleTo(userID int64) bool {
+	strategy := StrategyDto{Owner: e.Owner, Visibility: e.Visibility, SharedWith: e.SharedWith}
+	return strategy.VisibleTo(userID)
+}
+
+// AutomationEvent describes a finished OB or FVG automation run on the event bus
+type AutomationEvent struct {
+	Kind     string `json:"kind" example:"OB"`
+	Total    int    `json:"total" example:"12"`
+	Inserted int    `json:"inserted" example:"10"`
+}
+
+// MitigationEvent describes a finished mitigation check on the event bus
+type MitigationEvent struct {
+	Kind      string       `json:"kind" example:"OB"`
+	Direction Direction    `json:"direction" example:"BULLISH"`
+	Results   []ObResponse `json:"results"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Job tracks the progress and outcome of a long-running background task
+type Job struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Type       string             `bson:"type" json:"type" example:"ob-automation"`
+	Trigger    RunTrigger         `bson:"trigger" json:"trigger"`
+	Status     RunStatus          `bson:"status" json:"status"`
+	Total      int                `bson:"total" json:"total"`
+	Processed  int                `bson:"processed" json:"processed"`
+	Inserted   int                `bson:"inserted" json:"inserted"`
+	Skipped    int                `bson:"skipped" json:"skipped"`
+	Failed     int                `bson:"failed" json:"failed"`
+	Errors     []JobError         `bson:"errors" json:"errors"`
+	Message    string             `bson:"message,omitempty" json:"message,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	StartedAt  *time.Time         `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	FinishedAt *time.Time         `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+}
+
+// JobError records why a single symbol could not be processed
+type JobError struct {
+	Symbol string `bson:"symbol" json:"symbol"`
+	Error  string `bson:"error" json:"error"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MarginChangeType is how an import changed a symbol's margin
+type MarginChangeType string
+
+const (
+	MarginAdded   MarginChangeType = "ADDED"
+	MarginRemoved MarginChangeType = "REMOVED"
+	MarginChanged MarginChangeType = "CHANGED"
+)
+
+// MarginBatch is one margin import, either a CSV upload or a rollback to an earlier batch
+type MarginBatch struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	FileName   string             `bson:"fileName,omitempty" json:"fileName,omitempty" example:"margins.csv"`
+	UploadedBy int64              `bson:"uploadedBy" json:"uploadedBy"`
+	ImportedAt time.Time          `bson:"importedAt" json:"importedAt"`
+	// RestoredFrom is the batch whose margins a rollback restored
+	RestoredFrom *primitive.ObjectID `bson:"restoredFrom,omitempty" json:"restoredFrom,omitempty"`
+
+	Total     int `bson:"total" json:"total" example:"1200"`
+	Added     int `bson:"added" json:"added" example:"4"`
+	Removed   int `bson:"removed" json:"removed" example:"2"`
+	Changed   int `bson:"changed" json:"changed" example:"15"`
+	Unchanged int `bson:"unchanged" json:"unchanged" example:"1179"`
+
+	// Margins is the full margin set the batch left behind, kept so it can be rolled back to
+	Margins []Margin `bson:"margins,omitempty" json:"-"`
+}
+
+// MarginChange is one symbol's change in a margin import
+type MarginChange struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	BatchID   primitive.ObjectID `bson:"batchId" json:"batchId"`
+	Symbol    string             `bson:"symbol" json:"symbol" example:"RELIANCE"`
+	Name      string             `bson:"name" json:"name"`
+	Type      MarginChangeType   `bson:"type" json:"type" example:"CHANGED"`
+	OldMargin *float32           `bson:"oldMargin,omitempty" json:"oldMargin,omitempty" example:"5"`
+	NewMargin *float32           `bson:"newMargin,omitempty" json:"newMargin,omitempty" example:"4"`
+	At        time.Time          `bson:"at" json:"at"`
+}
+
+// MarginBatchDiff is a margin import with every symbol it added, removed or re-levered
+type MarginBatchDiff struct {
+	Batch   MarginBatch    `json:"batch"`
+	Changes []MarginChange `json:"changes"`
+}
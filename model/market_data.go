@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// Candle is a provider-independent OHLCV bar
+type Candle struct {
+	Symbol string    `bson:"symbol" json:"symbol"`
+	Time   time.Time `bson:"time" json:"time"`
+	Open   float64   `bson:"open" json:"open"`
+	High   float64   `bson:"high" json:"high"`
+	Low    float64   `bson:"low" json:"low"`
+	Close  float64   `bson:"close" json:"close"`
+	Volume int64     `bson:"volume" json:"volume"`
+}
+
+// StockQuote is the latest traded price of a symbol
+type StockQuote struct {
+	Symbol        string    `json:"symbol"`
+	LastPrice     float64   `json:"lastPrice"`
+	Open          float64   `json:"open"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	PreviousClose float64   `json:"previousClose"`
+	Change        float64   `json:"change"`
+	PChange       float64   `json:"pChange"`
+	Volume        int64     `json:"volume"`
+	Time          time.Time `json:"time"`
+	Source        string    `json:"source"`
+}
+
+// ProviderStatus reports the health of a market data provider in the fallback chain
+type ProviderStatus struct {
+	Name                string     `json:"name"`
+	Enabled             bool       `json:"enabled"`
+	Circuit             string     `json:"circuit" example:"CLOSED"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastFailure         *time.Time `json:"lastFailure,omitempty"`
+	LastSuccess         *time.Time `json:"lastSuccess,omitempty"`
+}
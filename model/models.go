@@ -3,9 +3,12 @@ package model
 import (
 	"fmt"
 	"math/rand/v2"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -25,6 +28,33 @@ const (
 	ThemeDark  UserTheme = "DARK"
 )
 
+// StrategyVisibility controls who can see and run a strategy
+// @Description PRIVATE, SHARED or PUBLIC
+type StrategyVisibility string
+
+const (
+	StrategyPrivate StrategyVisibility = "PRIVATE"
+	StrategyShared  StrategyVisibility = "SHARED"
+	StrategyPublic  StrategyVisibility = "PUBLIC"
+)
+
+// OrDefault returns PUBLIC for an empty visibility; strategies saved before sharing existed were admin-made and public.
+func (v StrategyVisibility) OrDefault() StrategyVisibility {
+	if v == "" {
+		return StrategyPublic
+	}
+	return v
+}
+
+// IsValid reports whether v is PRIVATE, SHARED or PUBLIC.
+func (v StrategyVisibility) IsValid() bool {
+	switch v {
+	case StrategyPrivate, StrategyShared, StrategyPublic:
+		return true
+	}
+	return false
+}
+
 // --- MARGIN ---
 // Margin represents the database entity for stock leverage
 type Margin struct {
@@ -35,33 +65,120 @@ type Margin struct {
 
 // StockMarginDto combines stock price with margin requirements
 type StockMarginDto struct {
-	Name   string  `json:"name"`
-	Symbol string  `json:"symbol"`
-	Margin float32 `json:"margin"`
-	Close  float32 `json:"close"`
+	Name    string         `json:"name"`
+	Symbol  string         `json:"symbol"`
+	Margin  float32        `json:"margin"`
+	Close   float32        `json:"close"`
+	PChange float64        `json:"pChange"`
+	Volume  float64        `json:"volume"`
+	Columns map[string]any `json:"columns,omitempty"`
 }
 
 // --- STRATEGY ---
 // Strategy is the core scanner entity
 type Strategy struct {
-	Name       string `bson:"_id" json:"name"`
-	ScanClause string `bson:"scanClause" json:"scanClause"`
-	Active     bool   `bson:"active" json:"active"`
+	Name        string    `bson:"_id" json:"name"`
+	ScanClause  string    `bson:"scanClause" json:"scanClause"`
+	Active      bool      `bson:"active" json:"active"`
+	Description string    `bson:"description,omitempty" json:"description,omitempty"`
+	Category    string    `bson:"category,omitempty" json:"category,omitempty"`
+	Tags        []string  `bson:"tags,omitempty" json:"tags,omitempty"`
+	Timeframe   Timeframe `bson:"timeframe,omitempty" json:"timeframe,omitempty"`
+	// Owner is the user ID of the user who created the strategy
+	Owner      int64              `bson:"owner,omitempty" json:"owner,omitempty"`
+	Visibility StrategyVisibility `bson:"visibility,omitempty" json:"visibility,omitempty"`
+	// SharedWith are the user IDs a SHARED strategy is visible to besides its owner
+	SharedWith []int64 `bson:"sharedWith,omitempty" json:"sharedWith,omitempty"`
+	// Version is the number of the scan clause's latest StrategyVersion, 0 for strategies saved before versioning
+	Version   int       `bson:"version" json:"version"`
+	CreatedAt time.Time `bson:"createdAt,omitempty" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt,omitempty" json:"updatedAt"`
 }
 
 // StrategyDto is used for creating/updating strategies
 type StrategyDto struct {
-	Name       string `json:"name" validate:"required"`
-	ScanClause string `json:"scanClause" validate:"required"`
-	Active     bool   `json:"active"`
+	Name        string             `json:"name" validate:"required"`
+	ScanClause  string             `json:"scanClause" validate:"required"`
+	Active      bool               `json:"active"`
+	Description string             `json:"description,omitempty" example:"Daily bullish order blocks near a 200 DMA"`
+	Category    string             `json:"category,omitempty" example:"Price Action"`
+	Tags        []string           `json:"tags,omitempty" example:"ob,swing"`
+	Timeframe   Timeframe          `json:"timeframe,omitempty" example:"1D"`
+	Visibility  StrategyVisibility `json:"visibility,omitempty" example:"PUBLIC"`
+	// Owner, SharedWith, Version and the timestamps are set by the server
+	Owner      int64     `json:"owner,omitempty"`
+	SharedWith []int64   `json:"sharedWith,omitempty"`
+	Version    int       `json:"version,omitempty"`
+	CreatedAt  time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt,omitempty"`
+	// Preview is the dry run of the scan clause, only set in save responses that asked for validation
+	Preview *ScanPreview `json:"preview,omitempty"`
 }
 
 func (d *StrategyDto) ToEntity() Strategy {
 	return Strategy{
-		Name:       strings.ToUpper(d.Name),
-		ScanClause: d.ScanClause,
-		Active:     d.Active,
+		Name:        strings.ToUpper(d.Name),
+		ScanClause:  d.ScanClause,
+		Active:      d.Active,
+		Description: d.Description,
+		Category:    d.Category,
+		Tags:        d.Tags,
+		Timeframe:   d.Timeframe,
+		Owner:       d.Owner,
+		Visibility:  d.Visibility,
+		SharedWith:  d.SharedWith,
+		Version:     d.Version,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+func (s *Strategy) ToDto() StrategyDto {
+	return StrategyDto{
+		Name:        s.Name,
+		ScanClause:  s.ScanClause,
+		Active:      s.Active,
+		Description: s.Description,
+		Category:    s.Category,
+		Tags:        s.Tags,
+		Timeframe:   s.Timeframe,
+		Owner:       s.Owner,
+		Visibility:  s.Visibility,
+		SharedWith:  s.SharedWith,
+		Version:     s.Version,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// VisibleTo reports whether a user may see and run the strategy; userID is 0 for anonymous requests.
+func (d *StrategyDto) VisibleTo(userID int64) bool {
+	switch d.Visibility.OrDefault() {
+	case StrategyPublic:
+		return true
+	case StrategyShared:
+		return userID != 0 && (d.Owner == userID || slices.Contains(d.SharedWith, userID))
 	}
+	return userID != 0 && d.Owner == userID
+}
+
+// StrategySharingRequest sets who can see a strategy. SharedWith are user emails, used when Visibility is SHARED.
+type StrategySharingRequest struct {
+	Visibility StrategyVisibility `json:"visibility" binding:"required" example:"SHARED"`
+	SharedWith []string           `json:"sharedWith" example:"friend@example.com"`
+}
+
+// StrategyVersion is a scan clause a strategy had, kept so an edit can be rolled back
+type StrategyVersion struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Strategy   string             `bson:"strategy" json:"strategy" example:"BULLISH OB 1D"`
+	Version    int                `bson:"version" json:"version" example:"2"`
+	ScanClause string             `bson:"scanClause" json:"scanClause"`
+	// EditedBy is the user ID of the admin who saved the clause, 0 for clauses recorded from before versioning
+	EditedBy int64 `bson:"editedBy,omitempty" json:"editedBy,omitempty"`
+	// RestoredFrom is the version this one restored, if it was a rollback
+	RestoredFrom int       `bson:"restoredFrom,omitempty" json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
 }
 
 // --- USER ---
@@ -75,6 +192,12 @@ type User struct {
 	Theme    UserTheme `bson:"theme" json:"theme"`
 	Mobile   int64     `bson:"mobile" json:"mobile"`
 	Name     string    `bson:"name" json:"name"`
+	// DefaultCapital and DefaultRiskPct prefill the position sizing calculator
+	DefaultCapital float64 `bson:"defaultCapital" json:"defaultCapital"`
+	DefaultRiskPct float64 `bson:"defaultRiskPct" json:"defaultRiskPct"`
+	// TelegramChatID is the chat linked for Telegram notifications
+	TelegramChatID string            `bson:"telegramChatId,omitempty" json:"telegramChatId,omitempty"`
+	Notifications  NotificationPrefs `bson:"notifications,omitempty" json:"notifications,omitempty"`
 }
 
 // ToDto maps the Entity to the API Response object
@@ -87,6 +210,9 @@ func (u *User) ToDto() UserDto {
 		Theme:    u.Theme,
 		Mobile:   u.Mobile,
 		Name:     u.Name,
+
+		DefaultCapital: u.DefaultCapital,
+		DefaultRiskPct: u.DefaultRiskPct,
 	}
 }
 
@@ -101,6 +227,8 @@ type UserDto struct {
 	Theme           UserTheme `json:"theme"`
 	Mobile          int64     `json:"mobile"`
 	Name            string    `json:"name"`
+	DefaultCapital  float64   `json:"defaultCapital" example:"500000"`
+	DefaultRiskPct  float64   `json:"defaultRiskPct" example:"1"`
 }
 
 func (d *UserDto) ToEntity() (*User, error) {
@@ -160,6 +288,11 @@ func (r *BrevoEmailRequest) Signup(otp string, validity int) {
 type ChartInkResponseDto struct {
 	// json:"data" tells the parser to map the JSON key "data" to this field
 	Data []StockData `json:"data"`
+	// ScanError is set instead of Data when ChartInk could not run the scan clause
+	ScanError string    `json:"scan_error,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// Stale is set when the result is served past its freshness, e.g. from the last snapshot while ChartInk is down
+	Stale bool `json:"stale"`
 }
 
 // StockData mimics the static inner class
@@ -169,6 +302,11 @@ type StockData struct {
 	NSECode string  `json:"nsecode"`
 	Name    string  `json:"name"`
 	Close   float32 `json:"close"`
+	BSECode string  `json:"bsecode,omitempty"`
+	PChange float64 `json:"per_chg"`
+	Volume  float64 `json:"volume"`
+	// Columns keeps every other column the scan returned, e.g. the row number "sr" or custom scan fields
+	Columns map[string]any `json:"columns,omitempty"`
 }
 
 // MessageResponse represents a standard JSON response for auth operations
@@ -199,9 +337,26 @@ type ObRequest struct {
 	Date   string  `json:"date"`
 	High   float64 `json:"high"`
 	Low    float64 `json:"low"`
+	// Timeframe defaults to 1D. Intraday zones use "YYYY-MM-DD HH:mm" dates.
+	Timeframe Timeframe `json:"timeframe" example:"1D" enums:"15m,1h,1D,1W,1M"`
+	// Direction defaults to BULLISH. With the date and timeframe it identifies the zone to update or delete
+	Direction Direction `json:"direction" example:"BULLISH" enums:"BULLISH,BEARISH"`
+}
+
+// DetectRequest runs the detection engine over one symbol
+type DetectRequest struct {
+	Symbol    string    `json:"symbol" binding:"required" example:"RELIANCE"`
+	Timeframe Timeframe `json:"timeframe" example:"1D" enums:"15m,1h,1D,1W,1M"`
+	// From and To are YYYY-MM-DD; the default range is the last 90 days
+	From string `json:"from" example:"2025-01-01"`
+	To   string `json:"to" example:"2025-03-31"`
+	// Save writes the detected zones; otherwise they are only returned
+	Save bool `json:"save"`
 }
 
 type ObResponse struct {
 	StockMarginDto
-	Date string `json:"date"`
+	Date      string    `json:"date"`
+	Timeframe Timeframe `json:"timeframe"`
+	Direction Direction `json:"direction"`
 }
@@ -0,0 +1,75 @@
+package model
+
+// ChannelType names an outbound notification channel
+type ChannelType string
+
+const (
+	ChannelEmail    ChannelType = "EMAIL"
+	ChannelTelegram ChannelType = "TELEGRAM"
+)
+
+// NotificationEvent is a kind of notification a user can route to channels
+type NotificationEvent string
+
+const (
+	// NotifyAlerts carries the user's own alerts
+	NotifyAlerts NotificationEvent = "ALERTS"
+	// NotifyMitigations summarises the zones mitigated by each mitigation check
+	NotifyMitigations NotificationEvent = "MITIGATIONS"
+	// NotifyAutomation summarises each OB and FVG automation run
+	NotifyAutomation NotificationEvent = "AUTOMATION"
+)
+
+// NotificationEvents lists the events a user can route
+var NotificationEvents = []NotificationEvent{NotifyAlerts, NotifyMitigations, NotifyAutomation}
+
+// NotificationPrefs maps an event to the channels it is sent on. An event that is not set uses its default:
+// alerts go by email, everything else is off.
+type NotificationPrefs map[NotificationEvent][]ChannelType
+
+// Channels returns the channels an event is sent on, applying the defaults.
+func (p NotificationPrefs) Channels(event NotificationEvent) []ChannelType {
+	if channels, ok := p[event]; ok {
+		return channels
+	}
+	if event == NotifyAlerts {
+		return []ChannelType{ChannelEmail}
+	}
+	return nil
+}
+
+// Notification is a short message to a user
+type Notification struct {
+	Event   NotificationEvent
+	Title   string
+	Message string
+}
+
+// TelegramConfig configures the Telegram bot used for notifications
+type TelegramConfig struct {
+	BotToken string `json:"botToken" bson:"botToken"`
+	// BaseURL of the Bot API (default https://api.telegram.org); point it at a stub to test locally
+	BaseURL string `json:"baseUrl" bson:"baseUrl"`
+}
+
+// TelegramLinkRequest links a Telegram chat to the user's account
+type TelegramLinkRequest struct {
+	ChatID string `json:"chatId" binding:"required" example:"123456789"`
+}
+
+// NotificationPrefsRequest replaces the user's routing of events to channels
+type NotificationPrefsRequest struct {
+	Preferences NotificationPrefs `json:"preferences" binding:"required"`
+}
+
+// NotificationTestRequest sends a test message on one channel
+type NotificationTestRequest struct {
+	Channel ChannelType `json:"channel" binding:"required" example:"TELEGRAM" enums:"EMAIL,TELEGRAM"`
+}
+
+// NotificationSettings is the user's notification setup with every event's effective channels
+type NotificationSettings struct {
+	TelegramChatID string            `json:"telegramChatId,omitempty" example:"123456789"`
+	Channels       []ChannelType     `json:"channels" example:"EMAIL,TELEGRAM"`
+	Preferences    NotificationPrefs `json:"preferences"`
+}
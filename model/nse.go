@@ -11,9 +11,31 @@ type NSEHistoricalData struct {
 	High      float64 `json:"chTradeHighPrice"`
 	Low       float64 `json:"chTradeLowPrice"`
 	Close     float64 `json:"chClosingPrice"`
+	Volume    float64 `json:"chTotTradedQty"`
 	Timestamp string  `json:"mtimestamp"`
 }
 
+// NseQuoteResponse is the subset of /api/quote-equity we rely on
+type NseQuoteResponse struct {
+	Info struct {
+		Symbol string `json:"symbol"`
+	} `json:"info"`
+	Metadata struct {
+		LastUpdateTime string `json:"lastUpdateTime"`
+	} `json:"metadata"`
+	PriceInfo struct {
+		LastPrice       float64 `json:"lastPrice"`
+		Change          float64 `json:"change"`
+		PChange         float64 `json:"pChange"`
+		PreviousClose   float64 `json:"previousClose"`
+		Open            float64 `json:"open"`
+		IntraDayHighLow struct {
+			Min float64 `json:"min"`
+			Max float64 `json:"max"`
+		} `json:"intraDayHighLow"`
+	} `json:"priceInfo"`
+}
+
 type SectorData struct {
 	Index         string  `json:"index"`
 	IndexLongName string  `json:"indexLongName"`
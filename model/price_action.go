@@ -1,9 +1,76 @@
 package model
 
+// Direction is the side of a price action zone
+type Direction string
+
+const (
+	DirectionBullish Direction = "BULLISH"
+	DirectionBearish Direction = "BEARISH"
+)
+
+// OrDefault returns BULLISH for an empty direction; zones saved before detection existed are bullish.
+func (d Direction) OrDefault() Direction {
+	if d == "" {
+		return DirectionBullish
+	}
+	return d
+}
+
+// IsValid reports whether d is BULLISH or BEARISH.
+func (d Direction) IsValid() bool {
+	return d == DirectionBullish || d == DirectionBearish
+}
+
+// ZoneStatus is where a zone is in its lifecycle
+type ZoneStatus string
+
+const (
+	// ZoneFresh has not been revisited since it formed
+	ZoneFresh ZoneStatus = "FRESH"
+	// ZoneTested was revisited once and held
+	ZoneTested ZoneStatus = "TESTED"
+	// ZoneMitigated was revisited more than once and is considered used up
+	ZoneMitigated ZoneStatus = "MITIGATED"
+	// ZoneInvalidated was broken through its far side
+	ZoneInvalidated ZoneStatus = "INVALIDATED"
+)
+
+// OrDefault returns FRESH for an empty status.
+func (s ZoneStatus) OrDefault() ZoneStatus {
+	if s == "" {
+		return ZoneFresh
+	}
+	return s
+}
+
+// IsActive reports whether a zone can still be traded (FRESH or TESTED).
+func (s ZoneStatus) IsActive() bool {
+	s = s.OrDefault()
+	return s == ZoneFresh || s == ZoneTested
+}
+
+// IsValid reports whether s is a known status.
+func (s ZoneStatus) IsValid() bool {
+	switch s {
+	case ZoneFresh, ZoneTested, ZoneMitigated, ZoneInvalidated:
+		return true
+	}
+	return false
+}
+
 type Info struct {
-	Date string  `bson:"date" json:"date"`
-	High float64 `bson:"high" json:"high"`
-	Low  float64 `bson:"low" json:"low"`
+	Date      string    `bson:"date" json:"date"`
+	High      float64   `bson:"high" json:"high"`
+	Low       float64   `bson:"low" json:"low"`
+	Timeframe Timeframe `bson:"timeframe" json:"timeframe"`
+	Direction Direction `bson:"direction" json:"direction"`
+
+	Status     ZoneStatus `bson:"status" json:"status"`
+	TouchCount int        `bson:"touchCount" json:"touchCount"`
+	// MitigatedOn is the date price first revisited the zone
+	MitigatedOn string `bson:"mitigatedOn,omitempty" json:"mitigatedOn,omitempty"`
+	// InvalidatedOn is the date price broke through the zone
+	InvalidatedOn string `bson:"invalidatedOn,omitempty" json:"invalidatedOn,omitempty"`
 }
 
 type StockRecord struct {
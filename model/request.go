@@ -4,3 +4,9 @@ package model
 type UpdateThemeRequest struct {
 	Theme UserTheme `json:"theme" example:"DARK" enums:"LIGHT,DARK" binding:"required"`
 }
+
+// UpdateTradingDefaultsRequest sets the capital and risk the sizing calculator starts from
+type UpdateTradingDefaultsRequest struct {
+	DefaultCapital float64 `json:"defaultCapital" example:"500000" binding:"gte=0"`
+	DefaultRiskPct float64 `json:"defaultRiskPct" example:"1" binding:"gte=0,lte=100"`
+}
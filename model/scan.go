@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ScanSnapshot is a distinct result of a strategy's ChartInk scan on a day. Runs that return the same symbols as the
+// latest snapshot of the same day only bump its Runs and LastSeenAt.
+type ScanSnapshot struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Strategy   string             `bson:"strategy" json:"strategy" example:"BULLISH OB 1D"`
+	TakenAt    time.Time          `bson:"takenAt" json:"takenAt"`
+	LastSeenAt time.Time          `bson:"lastSeenAt" json:"lastSeenAt"`
+	Runs       int                `bson:"runs" json:"runs" example:"3"`
+	Count      int                `bson:"count" json:"count" example:"12"`
+	// Symbols are sorted, which makes comparing two snapshots cheap
+	Symbols []string    `bson:"symbols" json:"symbols" example:"RELIANCE,TCS"`
+	Stocks  []StockData `bson:"stocks" json:"stocks,omitempty"`
+}
+
+// ScanDiff lists the stocks that entered and exited a strategy's scan between two snapshots
+type ScanDiff struct {
+	Strategy string       `json:"strategy" example:"BULLISH OB 1D"`
+	From     ScanSnapshot `json:"from"`
+	To       ScanSnapshot `json:"to"`
+	// Entered are in To but not in From, with their data from To
+	Entered []StockData `json:"entered"`
+	// Exited are in From but not in To, with their data from From
+	Exited   []StockData `json:"exited"`
+	Retained []string    `json:"retained" example:"RELIANCE"`
+}
+
+// Confluence ranks the stocks that several strategies returned in the same run
+type Confluence struct {
+	Strategies []string `json:"strategies" example:"BULLISH OB 1D,BULLISH CLOSE 200"`
+	// Failed maps strategies whose scan failed to the error, they count as not matching
+	Failed map[string]string `json:"failed,omitempty"`
+	Stocks []ConfluenceStock `json:"stocks"`
+}
+
+// ConfluenceStock is a margin stock with the strategies that returned it and its untraded zones
+type ConfluenceStock struct {
+	StockMarginDto
+	Matches     int      `json:"matches" example:"2"`
+	Strategies  []string `json:"strategies" example:"BULLISH OB 1D,BULLISH CLOSE 200"`
+	OrderBlocks []Info   `json:"orderBlocks"`
+	Fvg         []Info   `json:"fvg"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RunStatus represents the state of a scheduled run or background job
+// @Description PENDING, RUNNING, SUCCESS or FAILED
+type RunStatus string
+
+// RunTrigger describes what caused a scheduled job to execute
+// @Description SCHEDULED, MANUAL, RETRY, CATCHUP or CHAINED
+type RunTrigger string
+
+const (
+	RunStatusPending RunStatus = "PENDING"
+	RunStatusRunning RunStatus = "RUNNING"
+	RunStatusSuccess RunStatus = "SUCCESS"
+	RunStatusFailed  RunStatus = "FAILED"
+
+	TriggerScheduled RunTrigger = "SCHEDULED"
+	TriggerManual    RunTrigger = "MANUAL"
+	TriggerRetry     RunTrigger = "RETRY"
+	TriggerCatchUp   RunTrigger = "CATCHUP"
+	// TriggerChained runs a job after the job it depends on succeeded
+	TriggerChained RunTrigger = "CHAINED"
+)
+
+// SchedulerRun is a single persisted execution of a scheduled job
+type SchedulerRun struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Job          string             `bson:"job" json:"job"`
+	Trigger      RunTrigger         `bson:"trigger" json:"trigger"`
+	Attempt      int                `bson:"attempt" json:"attempt"`
+	ScheduledFor time.Time          `bson:"scheduledFor" json:"scheduledFor"`
+	StartedAt    time.Time          `bson:"startedAt" json:"startedAt"`
+	FinishedAt   *time.Time         `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	Status       RunStatus          `bson:"status" json:"status"`
+	Error        string             `bson:"error,omitempty" json:"error,omitempty"`
+	JobID        primitive.ObjectID `bson:"jobId,omitempty" json:"jobId"`
+}
+
+// ScheduledJobDto is the admin view of a registered job and its latest run
+type ScheduledJobDto struct {
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Schedule       string        `json:"schedule"`
+	TradingDayOnly bool          `json:"tradingDayOnly"`
+	Running        bool          `json:"running"`
+	NextRun        time.Time     `json:"nextRun"`
+	LastRun        *SchedulerRun `json:"lastRun,omitempty"`
+}
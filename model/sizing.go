@@ -0,0 +1,48 @@
+package model
+
+// Stop loss sources of a sizing result
+const (
+	StopFromRequest = "REQUEST"
+	StopFromZone    = "ZONE"
+)
+
+// SizingRequest sizes a trade so that hitting the stop loses at most RiskPct of Capital
+type SizingRequest struct {
+	Symbol string    `json:"symbol" binding:"required" example:"RELIANCE"`
+	Side   TradeSide `json:"side" example:"LONG" enums:"LONG,SHORT"`
+	// Capital and RiskPct default to the user's profile, then to 100000 and 1%
+	Capital float64 `json:"capital" binding:"gte=0" example:"500000"`
+	RiskPct float64 `json:"riskPct" binding:"gte=0,lte=100" example:"1"`
+	// Entry defaults to the latest close
+	Entry float64 `json:"entry" binding:"gte=0" example:"2910.5"`
+	// StopLoss defaults to the far side of Zone: its low for longs, its high for shorts
+	StopLoss float64 `json:"stopLoss" binding:"gte=0" example:"0"`
+	// Zone picks the zone the stop is taken from. Without a date, the newest active zone of the kind
+	// (default OB) on the side's direction is used.
+	Zone *TradeZone `json:"zone"`
+}
+
+// SizingResult is the quantity a trade can take within the risk budget
+type SizingResult struct {
+	Symbol  string    `json:"symbol" example:"RELIANCE"`
+	Side    TradeSide `json:"side" example:"LONG"`
+	Capital float64   `json:"capital" example:"500000"`
+	RiskPct float64   `json:"riskPct" example:"1"`
+	// RiskAmount is Capital x RiskPct, the most the trade may lose
+	RiskAmount float64 `json:"riskAmount" example:"5000"`
+	Entry      float64 `json:"entry" example:"2910.5"`
+	StopLoss   float64 `json:"stopLoss" example:"2870"`
+	StopSource string  `json:"stopSource" example:"ZONE" enums:"REQUEST,ZONE"`
+	// Zone is the zone the stop came from
+	Zone         *TradeZone `json:"zone,omitempty"`
+	RiskPerShare float64    `json:"riskPerShare" example:"40.5"`
+	Quantity     int        `json:"quantity" example:"123"`
+	// PositionValue is Quantity x Entry; CapitalRequired is that divided by Leverage
+	PositionValue   float64 `json:"positionValue" example:"357991.5"`
+	Leverage        float64 `json:"leverage" example:"5"`
+	CapitalRequired float64 `json:"capitalRequired" example:"71598.3"`
+	// MaxLoss is the loss if the stop is hit
+	MaxLoss float64 `json:"maxLoss" example:"4981.5"`
+	// CappedByCapital is set when the quantity was reduced to what the capital can fund
+	CappedByCapital bool `json:"cappedByCapital" example:"false"`
+}
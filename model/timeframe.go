@@ -0,0 +1,34 @@
+package model
+
+// Timeframe is the candle period a price action zone was formed on
+type Timeframe string
+
+const (
+	Timeframe15m Timeframe = "15m"
+	Timeframe1h  Timeframe = "1h"
+	Timeframe1D  Timeframe = "1D"
+	Timeframe1W  Timeframe = "1W"
+	Timeframe1M  Timeframe = "1M"
+)
+
+// IsValid reports whether t is one of the supported timeframes.
+func (t Timeframe) IsValid() bool {
+	switch t {
+	case Timeframe15m, Timeframe1h, Timeframe1D, Timeframe1W, Timeframe1M:
+		return true
+	}
+	return false
+}
+
+// IsIntraday reports whether candles of t are shorter than a session.
+func (t Timeframe) IsIntraday() bool {
+	return t == Timeframe15m || t == Timeframe1h
+}
+
+// OrDefault returns 1D for an empty timeframe; zones saved before timeframes existed are daily.
+func (t Timeframe) OrDefault() Timeframe {
+	if t == "" {
+		return Timeframe1D
+	}
+	return t
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TradeSide is whether a trade bought or sold first
+type TradeSide string
+
+const (
+	SideLong  TradeSide = "LONG"
+	SideShort TradeSide = "SHORT"
+)
+
+// OrDefault returns LONG for an empty side.
+func (s TradeSide) OrDefault() TradeSide {
+	if s == "" {
+		return SideLong
+	}
+	return s
+}
+
+// IsValid reports whether s is LONG or SHORT.
+func (s TradeSide) IsValid() bool {
+	return s == SideLong || s == SideShort
+}
+
+// Sign is +1 for long and -1 for short trades, turning price moves into profit.
+func (s TradeSide) Sign() float64 {
+	if s.OrDefault() == SideShort {
+		return -1
+	}
+	return 1
+}
+
+// TradeStatus is whether a trade is still open
+type TradeStatus string
+
+const (
+	TradeOpen   TradeStatus = "OPEN"
+	TradeClosed TradeStatus = "CLOSED"
+)
+
+// TradeZone links a trade to the price action zone it was taken from
+type TradeZone struct {
+	Kind      string    `bson:"kind" json:"kind" example:"OB" enums:"OB,FVG"`
+	Date      string    `bson:"date" json:"date" example:"2025-01-10"`
+	Timeframe Timeframe `bson:"timeframe" json:"timeframe" example:"1D"`
+}
+
+// Trade is a journal entry owned by a user
+type Trade struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     int64              `bson:"userId" json:"userId"`
+	Symbol     string             `bson:"symbol" json:"symbol" example:"RELIANCE"`
+	Side       TradeSide          `bson:"side" json:"side" example:"LONG"`
+	Status     TradeStatus        `bson:"status" json:"status" example:"OPEN"`
+	Quantity   int                `bson:"quantity" json:"quantity" example:"10"`
+	EntryPrice float64            `bson:"entryPrice" json:"entryPrice" example:"2910.5"`
+	EntryDate  string             `bson:"entryDate" json:"entryDate" example:"2025-01-14"`
+	ExitPrice  float64            `bson:"exitPrice,omitempty" json:"exitPrice,omitempty" example:"2990"`
+	ExitDate   string             `bson:"exitDate,omitempty" json:"exitDate,omitempty" example:"2025-01-20"`
+	// Leverage defaults to the symbol's margin when the trade is recorded
+	Leverage float64 `bson:"leverage" json:"leverage" example:"5"`
+	// Charges are brokerage, taxes and fees for both legs
+	Charges float64 `bson:"charges" json:"charges" example:"42.6"`
+	// ExitCharges are the part of Charges recorded when the trade was closed
+	ExitCharges float64    `bson:"exitCharges,omitempty" json:"exitCharges,omitempty" example:"21.3"`
+	Strategy    string     `bson:"strategy,omitempty" json:"strategy,omitempty" example:"BULLISH CLOSE 200"`
+	Zone        *TradeZone `bson:"zone,omitempty" json:"zone,omitempty"`
+	Notes       string     `bson:"notes,omitempty" json:"notes,omitempty"`
+	ExitNotes   string     `bson:"exitNotes,omitempty" json:"exitNotes,omitempty" example:"Target hit"`
+	// RealisedPnL is set when the trade is closed, net of charges
+	RealisedPnL float64   `bson:"realisedPnl" json:"realisedPnl" example:"752.4"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// TradeRequest records or edits a trade
+type TradeRequest struct {
+	Symbol     string    `json:"symbol" binding:"required" example:"RELIANCE"`
+	Side       TradeSide `json:"side" example:"LONG" enums:"LONG,SHORT"`
+	Quantity   int       `json:"quantity" binding:"required,gt=0" example:"10"`
+	EntryPrice float64   `json:"entryPrice" binding:"required,gt=0" example:"2910.5"`
+	// EntryDate is YYYY-MM-DD; defaults to today
+	EntryDate string `json:"entryDate" example:"2025-01-14"`
+	// Leverage defaults to the symbol's margin, or 1 without one
+	Leverage float64 `json:"leverage" binding:"gte=0" example:"5"`
+	// Charges of the entry leg; a closed trade keeps its exit charges on top
+	Charges  float64    `json:"charges" binding:"gte=0" example:"21.3"`
+	Strategy string     `json:"strategy" example:"BULLISH CLOSE 200"`
+	Zone     *TradeZone `json:"zone"`
+	Notes    string     `json:"notes" example:"Entered on first touch of the daily OB"`
+}
+
+// CloseTradeRequest records the exit of an open trade
+type CloseTradeRequest struct {
+	ExitPrice float64 `json:"exitPrice" binding:"required,gt=0" example:"2990"`
+	// ExitDate is YYYY-MM-DD; defaults to today
+	ExitDate string `json:"exitDate" example:"2025-01-20"`
+	// Charges of the exit leg, added to the trade's charges
+	Charges float64 `json:"charges" binding:"gte=0" example:"21.3"`
+	Notes   string  `json:"notes" example:"Target hit"`
+}
+
+// Position is an open trade valued at the latest candle
+type Position struct {
+	Trade
+	LastPrice float64 `json:"lastPrice" example:"2951.2"`
+	// PriceDate is the date of the candle LastPrice comes from
+	PriceDate string `json:"priceDate" example:"2025-01-16"`
+	// CapitalUsed is the entry value divided by leverage
+	CapitalUsed float64 `json:"capitalUsed" example:"5821"`
+	// UnrealisedPnL is net of the charges paid so far; UnrealisedPct is its return on CapitalUsed
+	UnrealisedPnL float64 `json:"unrealisedPnl" example:"385.7"`
+	UnrealisedPct float64 `json:"unrealisedPct" example:"6.63"`
+}
+
+// PnLSummary totals a user's realised and unrealised P&L
+type PnLSummary struct {
+	RealisedPnL   float64    `json:"realisedPnl" example:"12450.5"`
+	UnrealisedPnL float64    `json:"unrealisedPnl" example:"385.7"`
+	TotalPnL      float64    `json:"totalPnl" example:"12836.2"`
+	Charges       float64    `json:"charges" example:"1210.4"`
+	ClosedTrades  int        `json:"closedTrades" example:"38"`
+	Positions     []Position `json:"positions"`
+}
+
+// StrategyStats summarises the closed trades of one strategy
+type StrategyStats struct {
+	// Strategy is empty for trades not linked to one
+	Strategy   string `json:"strategy" example:"BULLISH CLOSE 200"`
+	OpenTrades int    `json:"openTrades" example:"2"`
+	// Trades counts the closed trades: the wins, losses and breakevens
+	Trades     int `json:"trades" example:"24"`
+	Wins       int `json:"wins" example:"13"`
+	Losses     int `json:"losses" example:"10"`
+	Breakevens int `json:"breakevens" example:"1"`
+	// WinRate is the percent of closed trades with a positive realised PnL
+	WinRate     float64 `json:"winRate" example:"54.17"`
+	RealisedPnL float64 `json:"realisedPnl" example:"8450.2"`
+	AvgPnL      float64 `json:"avgPnl" example:"352.09"`
+	AvgWin      float64 `json:"avgWin" example:"1120.5"`
+	AvgLoss     float64 `json:"avgLoss" example:"-556.1"`
+}
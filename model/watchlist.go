@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Watchlist is a named, ordered list of symbols owned by a user
+type Watchlist struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID int64              `bson:"userId" json:"userId"`
+	Name   string             `bson:"name" json:"name" example:"Swing"`
+	// Position orders a user's watchlists, lowest first
+	Position int `bson:"position" json:"position" example:"0"`
+	// Items are kept in display order
+	Items     []WatchlistItem `bson:"items" json:"items"`
+	CreatedAt time.Time       `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time       `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WatchlistItem is a symbol on a watchlist
+type WatchlistItem struct {
+	Symbol  string    `bson:"symbol" json:"symbol" example:"RELIANCE"`
+	Note    string    `bson:"note,omitempty" json:"note,omitempty" example:"Waiting for daily OB retest"`
+	AddedAt time.Time `bson:"addedAt" json:"addedAt"`
+}
+
+// WatchlistRequest creates or renames a watchlist
+type WatchlistRequest struct {
+	Name string `json:"name" binding:"required,max=50" example:"Swing"`
+}
+
+// WatchlistItemRequest adds a symbol or edits its note
+type WatchlistItemRequest struct {
+	Symbol string `json:"symbol" example:"RELIANCE"`
+	Note   string `json:"note" binding:"max=500" example:"Waiting for daily OB retest"`
+}
+
+// WatchlistOrderRequest reorders watchlists by ID, or a watchlist's items by symbol
+type WatchlistOrderRequest struct {
+	Order []string `json:"order" binding:"required" example:"RELIANCE,TCS,INFY"`
+}
+
+// WatchlistView is a watchlist with market data for each symbol
+type WatchlistView struct {
+	ID       primitive.ObjectID `json:"id"`
+	Name     string             `json:"name" example:"Swing"`
+	Position int                `json:"position" example:"0"`
+	Items    []WatchlistEntry   `json:"items"`
+}
+
+// WatchlistEntry joins a watchlist symbol with its margin, latest candle and active zones
+type WatchlistEntry struct {
+	WatchlistItem
+	Name   string  `json:"name,omitempty" example:"Reliance Industries Ltd"`
+	Margin float32 `json:"margin,omitempty" example:"5"`
+	// Candle is the latest daily candle, including a session still in progress
+	Candle  *Candle `json:"candle,omitempty"`
+	Change  float64 `json:"change" example:"12.4"`
+	PChange float64 `json:"pChange" example:"0.42"`
+	// OrderBlocks and Fvg are the symbol's FRESH and TESTED zones
+	OrderBlocks []Info `json:"orderBlocks"`
+	Fvg         []Info `json:"fvg"`
+}
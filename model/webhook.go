@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookEvent is an event type a webhook can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookScanCompleted      WebhookEvent = "scan.completed"
+	WebhookOBCreated          WebhookEvent = "ob.created"
+	WebhookOBMitigated        WebhookEvent = "ob.mitigated"
+	WebhookFvgCreated         WebhookEvent = "fvg.created"
+	WebhookFvgMitigated       WebhookEvent = "fvg.mitigated"
+	WebhookAutomationFinished WebhookEvent = "automation.finished"
+	// WebhookTest is only sent on demand to check an endpoint
+	WebhookTest WebhookEvent = "webhook.test"
+)
+
+// WebhookEvents lists the event types that can be subscribed to
+var WebhookEvents = []WebhookEvent{
+	WebhookScanCompleted, WebhookOBCreated, WebhookOBMitigated, WebhookFvgCreated, WebhookFvgMitigated,
+	WebhookAutomationFinished,
+}
+
+// Webhook is an outbound subscription managed by admins
+type Webhook struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	URL         string             `bson:"url" json:"url" example:"https://hooks.example.com/trades"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	// Secret signs every delivery; it is never returned
+	Secret    string         `bson:"secret" json:"-"`
+	Events    []WebhookEvent `bson:"events" json:"events" example:"ob.mitigated"`
+	Active    bool           `bson:"active" json:"active"`
+	CreatedBy int64          `bson:"createdBy" json:"createdBy"`
+	CreatedAt time.Time      `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time      `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookRequest creates or replaces a webhook. On update an empty secret keeps the current one.
+type WebhookRequest struct {
+	URL         string         `json:"url" binding:"required,url" example:"https://hooks.example.com/trades"`
+	Description string         `json:"description" binding:"max=200"`
+	Secret      string         `json:"secret" binding:"omitempty,min=16" example:"change-me-to-a-long-secret"`
+	Events      []WebhookEvent `json:"events" binding:"required,min=1" example:"ob.mitigated,fvg.created"`
+	// Active defaults to true
+	Active *bool `json:"active" example:"true"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook
+type WebhookPayload struct {
+	// ID is the delivery ID, also sent as X-Webhook-Delivery; receivers can use it to drop repeats
+	ID     string       `json:"id"`
+	Event  WebhookEvent `json:"event" example:"ob.mitigated"`
+	Symbol string       `json:"symbol,omitempty" example:"RELIANCE"`
+	Time   time.Time    `json:"time"`
+	Data   any          `json:"data,omitempty"`
+}
+
+// WebhookDelivery logs one event sent to a webhook and every attempt at it
+type WebhookDelivery struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	WebhookID  primitive.ObjectID `bson:"webhookId" json:"webhookId"`
+	Event      WebhookEvent       `bson:"event" json:"event" example:"ob.mitigated"`
+	Payload    string             `bson:"payload" json:"payload"`
+	Attempts   int                `bson:"attempts" json:"attempts"`
+	StatusCode int                `bson:"statusCode,omitempty" json:"statusCode,omitempty" example:"200"`
+	Success    bool               `bson:"success" json:"success"`
+	Error      string             `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	// NextAttemptAt is set while a retry is pending
+	NextAttemptAt *time.Time `bson:"nextAttemptAt,omitempty" json:"nextAttemptAt,omitempty"`
+	DeliveredAt   *time.Time `bson:"deliveredAt,omitempty" json:"deliveredAt,omitempty"`
+}
@@ -17,21 +17,47 @@ const (
 	RangeMax YahooTimeRange = "max"
 )
 
+// YahooInterval is the bar size requested from the chart API
+type YahooInterval string
+
+const (
+	Interval15m YahooInterval = "15m"
+	Interval60m YahooInterval = "60m"
+	Interval1d  YahooInterval = "1d"
+)
+
 // YahooChartResponse is the top-level container
 type YahooChartResponse struct {
 	Chart ChartData `json:"chart"`
 }
 
 type ChartData struct {
-	Result []Result `json:"result"`
-	Error  any      `json:"error"`
+	Result []Result         `json:"result"`
+	Error  *YahooChartError `json:"error"`
+}
+
+// YahooChartError is the error Yahoo reports in place of a chart, e.g. "Not Found" for a delisted symbol
+type YahooChartError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
 }
 
 type Result struct {
+	Meta       ChartMeta  `json:"meta"`
 	Timestamp  []int64    `json:"timestamp"`
 	Indicators Indicators `json:"indicators"`
 }
 
+type ChartMeta struct {
+	Symbol               string  `json:"symbol"`
+	RegularMarketPrice   float64 `json:"regularMarketPrice"`
+	RegularMarketTime    int64   `json:"regularMarketTime"`
+	RegularMarketDayHigh float64 `json:"regularMarketDayHigh"`
+	RegularMarketDayLow  float64 `json:"regularMarketDayLow"`
+	RegularMarketVolume  int64   `json:"regularMarketVolume"`
+	ChartPreviousClose   float64 `json:"chartPreviousClose"`
+}
+
 type Indicators struct {
 	Quote []Quote `json:"quote"`
 }
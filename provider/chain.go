@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/model"
+	"backend/util"
+)
+
+const (
+	defaultTimeoutSeconds   = 10
+	defaultFailureThreshold = 5
+	defaultCooldownSeconds  = 300
+)
+
+var defaultOrder = []model.ProviderConfig{{Name: "yahoo"}, {Name: "nse"}}
+
+// Chain tries the configured providers in order until one returns data.
+// Order, timeouts and breaker thresholds are read from config on every call so they can be
+// changed at runtime; breaker state is kept per provider for the life of the process.
+type Chain struct {
+	providers map[string]MarketDataProvider
+	breakers  map[string]*util.CircuitBreaker
+	cfg       *config.ConfigManager
+}
+
+func NewChain(cfg *config.ConfigManager, providers ...MarketDataProvider) *Chain {
+	c := &Chain{
+		providers: make(map[string]MarketDataProvider),
+		breakers:  make(map[string]*util.CircuitBreaker),
+		cfg:       cfg,
+	}
+	for _, p := range providers {
+		c.providers[p.Name()] = p
+		c.breakers[p.Name()] = &util.CircuitBreaker{}
+	}
+	return c
+}
+
+func (c *Chain) Name() string {
+	return "chain"
+}
+
+func (c *Chain) GetCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error) {
+	return try(c, ctx, fmt.Sprintf("%s candles %s", tf, symbol), func(ctx context.Context, p MarketDataProvider) ([]model.Candle, error) {
+		candles, err := p.GetCandles(ctx, symbol, tf, from, to)
+		if err == nil && len(candles) == 0 {
+			err = fmt.Errorf("%w: no candles for %s", ErrNoData, symbol)
+		}
+		return candles, err
+	})
+}
+
+func (c *Chain) GetQuote(ctx context.Context, symbol string) (*model.StockQuote, error) {
+	return try(c, ctx, "quote "+symbol, func(ctx context.Context, p MarketDataProvider) (*model.StockQuote, error) {
+		return p.GetQuote(ctx, symbol)
+	})
+}
+
+func (c *Chain) GetIndices(ctx context.Context) ([]model.NseIndexData, error) {
+	return try(c, ctx, "indices", func(ctx context.Context, p MarketDataProvider) ([]model.NseIndexData, error) {
+		indices, err := p.GetIndices(ctx)
+		if err == nil && len(indices) == 0 {
+			err = errors.New("no index data")
+		}
+		return indices, err
+	})
+}
+
+// Status reports every configured provider with its breaker state, in chain order.
+func (c *Chain) Status() []model.ProviderStatus {
+	result := make([]model.ProviderStatus, 0)
+	for _, pc := range c.order() {
+		breaker, ok := c.breakers[pc.Name]
+		if !ok {
+			result = append(result, model.ProviderStatus{Name: pc.Name, Enabled: false, Circuit: util.CircuitClosed, LastError: "unknown provider"})
+			continue
+		}
+
+		snap := breaker.Snapshot(threshold(pc), cooldown(pc))
+		status := model.ProviderStatus{
+			Name:                pc.Name,
+			Enabled:             !pc.Disabled,
+			Circuit:             snap.State,
+			ConsecutiveFailures: snap.Failures,
+			LastError:           snap.LastError,
+		}
+		if !snap.LastFailure.IsZero() {
+			status.LastFailure = &snap.LastFailure
+		}
+		if !snap.LastSuccess.IsZero() {
+			status.LastSuccess = &snap.LastSuccess
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+func (c *Chain) order() []model.ProviderConfig {
+	if cfg := c.cfg.GetConfig(); cfg != nil && len(cfg.MarketData.Providers) > 0 {
+		return cfg.MarketData.Providers
+	}
+	return defaultOrder
+}
+
+func try[T any](c *Chain, ctx context.Context, what string, call func(context.Context, MarketDataProvider) (T, error)) (T, error) {
+	var zero T
+	var errs []string
+	noData := false
+
+	for _, pc := range c.order() {
+		p, ok := c.providers[pc.Name]
+		if !ok || pc.Disabled {
+			continue
+		}
+
+		breaker := c.breakers[pc.Name]
+		if !breaker.Allow(threshold(pc), cooldown(pc)) {
+			errs = append(errs, pc.Name+": circuit open")
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, timeout(pc))
+		result, err := call(callCtx, p)
+		cancel()
+
+		if err == nil {
+			breaker.Success()
+			return result, nil
+		}
+		if errors.Is(err, ErrNotSupported) || errors.Is(err, ErrNoData) {
+			// Not a health problem; release a half-open trial without penalising the provider
+			breaker.Release()
+			noData = noData || errors.Is(err, ErrNoData)
+			continue
+		}
+		if ctx.Err() != nil {
+			breaker.Release()
+			return zero, ctx.Err()
+		}
+
+		breaker.Failure(err, threshold(pc))
+		log.Printf("market data: %s failed for %s: %v", pc.Name, what, err)
+		errs = append(errs, fmt.Sprintf("%s: %v", pc.Name, err))
+	}
+
+	if len(errs) == 0 && noData {
+		return zero, fmt.Errorf("%w for %s", ErrNoData, what)
+	}
+	if len(errs) == 0 {
+		return zero, fmt.Errorf("no market data provider available for %s", what)
+	}
+	return zero, fmt.Errorf("all market data providers failed for %s: %s", what, strings.Join(errs, "; "))
+}
+
+func timeout(pc model.ProviderConfig) time.Duration {
+	if pc.TimeoutSeconds <= 0 {
+		return defaultTimeoutSeconds * time.Second
+	}
+	return time.Duration(pc.TimeoutSeconds) * time.Second
+}
+
+func threshold(pc model.ProviderConfig) int {
+	if pc.FailureThreshold <= 0 {
+		return defaultFailureThreshold
+	}
+	return pc.FailureThreshold
+}
+
+func cooldown(pc model.ProviderConfig) time.Duration {
+	if pc.CooldownSeconds <= 0 {
+		return defaultCooldownSeconds * time.Second
+	}
+	return time.Duration(pc.CooldownSeconds) * time.Second
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"backend/model"
+	"backend/util"
+)
+
+// FileProvider serves candles from <dir>/<SYMBOL>.csv files. It is the last resort when
+// both remote sources are blocking us, and is handy for replaying data locally.
+type FileProvider struct {
+	dir func() string
+}
+
+// NewFileProvider takes a getter so the directory follows hot-swapped configuration.
+func NewFileProvider(dir func() string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+func (p *FileProvider) GetCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error) {
+	if tf != model.Timeframe1D {
+		return nil, ErrNotSupported
+	}
+
+	all, err := p.read(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to = util.StartOfDay(from), util.StartOfDay(to)
+	candles := make([]model.Candle, 0, len(all))
+	for _, c := range all {
+		if c.Time.Before(from) || c.Time.After(to) {
+			continue
+		}
+		candles = append(candles, c)
+	}
+	return candles, nil
+}
+
+func (p *FileProvider) GetQuote(ctx context.Context, symbol string) (*model.StockQuote, error) {
+	all, err := p.read(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("%w: no candles on file for %s", ErrNoData, symbol)
+	}
+
+	last := all[len(all)-1]
+	quote := &model.StockQuote{
+		Symbol:    symbol,
+		LastPrice: last.Close,
+		Open:      last.Open,
+		High:      last.High,
+		Low:       last.Low,
+		Volume:    last.Volume,
+		Time:      last.Time,
+		Source:    p.Name(),
+	}
+	if len(all) > 1 {
+		quote.PreviousClose = all[len(all)-2].Close
+		quote.Change = formatToTwo(quote.LastPrice - quote.PreviousClose)
+		quote.PChange = formatToTwo(quote.Change / quote.PreviousClose * 100)
+	}
+	return quote, nil
+}
+
+func (p *FileProvider) GetIndices(ctx context.Context) ([]model.NseIndexData, error) {
+	return nil, ErrNotSupported
+}
+
+func (p *FileProvider) read(symbol string) ([]model.Candle, error) {
+	dir := p.dir()
+	if dir == "" {
+		return nil, ErrNotSupported
+	}
+
+	f, err := os.Open(filepath.Join(dir, strings.ToUpper(filepath.Base(symbol))+".csv"))
+	if errors.Is(err, fs.ErrNotExist) {
+		// A symbol without a file is not a failing provider
+		return nil, fmt.Errorf("%w: no file for %s", ErrNoData, symbol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return util.ReadCandles(f, symbol)
+}
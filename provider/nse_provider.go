@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/client"
+	"backend/model"
+	"backend/util"
+)
+
+const (
+	historicalPath = "/api/NextApi/apiClient/GetQuoteApi"
+	quotePath      = "/api/quote-equity"
+	allIndicesPath = "/api/allindices"
+	nseDateLayout  = "02-Jan-2006"
+)
+
+type NseProvider struct {
+	client *client.NseClient
+}
+
+func NewNseProvider(c *client.NseClient) *NseProvider {
+	return &NseProvider{client: c}
+}
+
+func (p *NseProvider) Name() string {
+	return "nse"
+}
+
+func (p *NseProvider) GetCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error) {
+	if tf != model.Timeframe1D {
+		return nil, ErrNotSupported
+	}
+
+	var data []model.NSEHistoricalData
+	err := p.client.Get(ctx,
+		fmt.Sprintf("%s/get-quote/equity/%s", client.NseUrl, symbol),
+		historicalPath,
+		map[string]string{
+			"functionName": "getHistoricalTradeData",
+			"symbol":       symbol,
+			"series":       "EQ",
+			"fromDate":     from.In(util.MarketLocation()).Format("02-01-2006"),
+			"toDate":       to.In(util.MarketLocation()).Format("02-01-2006"),
+		},
+		&data,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// NSE returns the newest session first
+	candles := make([]model.Candle, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		d := data[i]
+		t, err := time.ParseInLocation(nseDateLayout, strings.TrimSpace(d.Timestamp), util.MarketLocation())
+		if err != nil {
+			continue
+		}
+		candles = append(candles, model.Candle{
+			Symbol: symbol,
+			Time:   t,
+			Open:   d.Open,
+			High:   d.High,
+			Low:    d.Low,
+			Close:  d.Close,
+			Volume: int64(d.Volume),
+		})
+	}
+	return candles, nil
+}
+
+func (p *NseProvider) GetQuote(ctx context.Context, symbol string) (*model.StockQuote, error) {
+	var resp model.NseQuoteResponse
+	err := p.client.Get(ctx,
+		fmt.Sprintf("%s/get-quote/equity/%s", client.NseUrl, symbol),
+		quotePath,
+		map[string]string{"symbol": symbol},
+		&resp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp.PriceInfo.LastPrice == 0 {
+		return nil, fmt.Errorf("nse returned no price for %s", symbol)
+	}
+
+	quote := &model.StockQuote{
+		Symbol:        symbol,
+		LastPrice:     resp.PriceInfo.LastPrice,
+		Open:          resp.PriceInfo.Open,
+		High:          resp.PriceInfo.IntraDayHighLow.Max,
+		Low:           resp.PriceInfo.IntraDayHighLow.Min,
+		PreviousClose: resp.PriceInfo.PreviousClose,
+		Change:        formatToTwo(resp.PriceInfo.Change),
+		PChange:       formatToTwo(resp.PriceInfo.PChange),
+		Time:          time.Now(),
+		Source:        p.Name(),
+	}
+	if t, err := time.ParseInLocation("02-Jan-2006 15:04:05", resp.Metadata.LastUpdateTime, util.MarketLocation()); err == nil {
+		quote.Time = t
+	}
+	return quote, nil
+}
+
+func (p *NseProvider) GetIndices(ctx context.Context) ([]model.NseIndexData, error) {
+	var result model.NseResponseWrapper[model.NseIndexData]
+	err := p.client.Get(ctx,
+		client.NseUrl+"/market-data/live-market-indices",
+		allIndicesPath,
+		nil,
+		&result,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"backend/model"
+)
+
+// ErrNotSupported is returned when a provider cannot serve a kind of data.
+// The chain moves on to the next provider without counting it as a failure.
+var ErrNotSupported = errors.New("operation not supported by provider")
+
+// ErrNoData is returned when a provider answered but has nothing for a symbol, e.g. a delisted one.
+// The chain moves on to the next provider without counting it as a failure.
+var ErrNoData = errors.New("no market data")
+
+// MarketDataProvider is a source of candles, quotes and index data.
+type MarketDataProvider interface {
+	Name() string
+	// GetCandles returns tf candles between from and to (inclusive), oldest first.
+	// Providers serve 1D and, where the source allows it, 15m; other timeframes are resampled by the caller.
+	GetCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error)
+	GetQuote(ctx context.Context, symbol string) (*model.StockQuote, error)
+	GetIndices(ctx context.Context) ([]model.NseIndexData, error)
+}
+
+func formatToTwo(n float64) float64 {
+	val, _ := strconv.ParseFloat(fmt.Sprintf("%.2f", n), 64)
+	return val
+}
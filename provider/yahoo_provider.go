@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"backend/client"
+	"backend/model"
+	"backend/util"
+)
+
+type YahooProvider struct {
+	client *client.YahooClient
+}
+
+func NewYahooProvider(c *client.YahooClient) *YahooProvider {
+	return &YahooProvider{client: c}
+}
+
+func (p *YahooProvider) Name() string {
+	return "yahoo"
+}
+
+func (p *YahooProvider) GetCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error) {
+	var interval model.YahooInterval
+	switch tf {
+	case model.Timeframe1D:
+		interval = model.Interval1d
+	case model.Timeframe15m:
+		interval = model.Interval15m
+	default:
+		return nil, ErrNotSupported
+	}
+
+	result, err := p.client.GetChart(ctx, symbol, map[string]string{
+		"period1":  strconv.FormatInt(util.StartOfDay(from).Unix(), 10),
+		"period2":  strconv.FormatInt(util.StartOfDay(to).AddDate(0, 0, 1).Unix(), 10),
+		"interval": string(interval),
+	})
+	if err != nil {
+		return nil, noChart(err)
+	}
+
+	candles := make([]model.Candle, 0, len(result.Timestamp))
+	if len(result.Indicators.Quote) == 0 {
+		return candles, nil
+	}
+
+	quote := result.Indicators.Quote[0]
+	// Yahoo sometimes returns series shorter than the timestamps
+	rows := min(len(result.Timestamp), len(quote.Open), len(quote.High), len(quote.Low), len(quote.Close),
+		len(quote.Volume))
+	for i, ts := range result.Timestamp[:rows] {
+		// Yahoo pads holidays and the live session with empty rows
+		if quote.Volume[i] <= 0 || quote.Open[i] == 0 {
+			continue
+		}
+		t := time.Unix(ts, 0).In(util.MarketLocation())
+		if tf == model.Timeframe1D {
+			t = util.StartOfDay(t)
+		}
+		candles = append(candles, model.Candle{
+			Symbol: symbol,
+			Time:   t,
+			Open:   formatToTwo(quote.Open[i]),
+			High:   formatToTwo(quote.High[i]),
+			Low:    formatToTwo(quote.Low[i]),
+			Close:  formatToTwo(quote.Close[i]),
+			Volume: quote.Volume[i],
+		})
+	}
+	return candles, nil
+}
+
+func (p *YahooProvider) GetQuote(ctx context.Context, symbol string) (*model.StockQuote, error) {
+	result, err := p.client.GetChart(ctx, symbol, map[string]string{
+		"range":    string(model.Range1d),
+		"interval": string(model.Interval1d),
+	})
+	if err != nil {
+		return nil, noChart(err)
+	}
+
+	meta := result.Meta
+	if meta.RegularMarketPrice == 0 {
+		return nil, fmt.Errorf("yahoo returned no price for %s", symbol)
+	}
+
+	quote := &model.StockQuote{
+		Symbol:        symbol,
+		LastPrice:     meta.RegularMarketPrice,
+		High:          meta.RegularMarketDayHigh,
+		Low:           meta.RegularMarketDayLow,
+		PreviousClose: meta.ChartPreviousClose,
+		Volume:        meta.RegularMarketVolume,
+		Time:          time.Unix(meta.RegularMarketTime, 0),
+		Source:        p.Name(),
+	}
+	if len(result.Indicators.Quote) > 0 && len(result.Indicators.Quote[0].Open) > 0 {
+		quote.Open = formatToTwo(result.Indicators.Quote[0].Open[0])
+	}
+	if quote.PreviousClose != 0 {
+		quote.Change = formatToTwo(quote.LastPrice - quote.PreviousClose)
+		quote.PChange = formatToTwo(quote.Change / quote.PreviousClose * 100)
+	}
+	return quote, nil
+}
+
+func (p *YahooProvider) GetIndices(ctx context.Context) ([]model.NseIndexData, error) {
+	return nil, ErrNotSupported
+}
+
+// noChart reports a symbol Yahoo does not know as ErrNoData, so delisted symbols do not trip its circuit breaker.
+func noChart(err error) error {
+	if errors.Is(err, client.ErrChartNotFound) {
+		return fmt.Errorf("%w: %v", ErrNoData, err)
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrDuplicateAlert is returned when an alert has already fired for a dedup key.
+var ErrDuplicateAlert = errors.New("alert already fired")
+
+type AlertRepository struct {
+	collection *mongo.Collection
+	history    *mongo.Collection
+}
+
+// NewAlertRepository initializes the repository for the alerts and alert_history collections.
+func NewAlertRepository(db *mongo.Database) *AlertRepository {
+	r := &AlertRepository{
+		collection: db.Collection("alerts"),
+		history:    db.Collection("alert_history"),
+	}
+
+	ctx := context.Background()
+	if _, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "type", Value: 1}, {Key: "active", Value: 1}, {Key: "symbol", Value: 1}},
+	}); err != nil {
+		log.Printf("Warning: failed to create alert index: %v", err)
+	}
+	// The unique key is what makes firing idempotent across repeated checks
+	if _, err := r.history.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "alertId", Value: 1}, {Key: "dedupKey", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Printf("Warning: failed to create alert history index: %v", err)
+	}
+	return r
+}
+
+// Insert stores a new alert and sets its generated ID.
+func (r *AlertRepository) Insert(ctx context.Context, alert *model.Alert) error {
+	res, err := r.collection.InsertOne(ctx, alert)
+	if err != nil {
+		return err
+	}
+	alert.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByUser lists a user's alerts, newest first.
+func (r *AlertRepository) FindByUser(ctx context.Context, userID int64) ([]model.Alert, error) {
+	return r.find(ctx, bson.M{"userId": userID})
+}
+
+// FindActive lists the active alerts of a type, optionally only for one symbol.
+func (r *AlertRepository) FindActive(ctx context.Context, alertType model.AlertType, symbol string) ([]model.Alert, error) {
+	filter := bson.M{"type": alertType, "active": true}
+	if symbol != "" {
+		filter["symbol"] = symbol
+	}
+	return r.find(ctx, filter)
+}
+
+// SetActive enables or disables a user's alert, reporting whether it was found.
+func (r *AlertRepository) SetActive(ctx context.Context, userID int64, id primitive.ObjectID, active bool) (bool, error) {
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "userId": userID}, bson.M{"$set": bson.M{"active": active}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// MarkTriggered records that an alert fired.
+func (r *AlertRepository) MarkTriggered(ctx context.Context, alert *model.Alert) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": alert.ID}, bson.M{
+		"$set": bson.M{"lastTriggeredAt": alert.LastTriggeredAt},
+		"$inc": bson.M{"triggerCount": 1},
+	})
+	return err
+}
+
+// Delete removes a user's alert, reporting whether it existed.
+func (r *AlertRepository) Delete(ctx context.Context, userID int64, id primitive.ObjectID) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "userId": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// InsertHistory claims a firing. It returns ErrDuplicateAlert if the alert already fired for the dedup key.
+func (r *AlertRepository) InsertHistory(ctx context.Context, entry *model.AlertHistory) error {
+	res, err := r.history.InsertOne(ctx, entry)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicateAlert
+		}
+		return err
+	}
+	entry.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// ClaimFailedHistory claims the retry of a firing whose delivery failed, clearing its error so that concurrent checks
+// do not claim it too. It returns nil if the firing was delivered, is being delivered or ran out of attempts.
+func (r *AlertRepository) ClaimFailedHistory(ctx context.Context, alertID primitive.ObjectID, dedupKey string,
+	maxAttempts int) (*model.AlertHistory, error) {
+	filter := bson.M{
+		"alertId":   alertID,
+		"dedupKey":  dedupKey,
+		"delivered": false,
+		"error":     bson.M{"$exists": true, "$ne": ""},
+		"attempts":  bson.M{"$lt": maxAttempts},
+	}
+	update := bson.M{"$set": bson.M{"error": ""}, "$inc": bson.M{"attempts": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var entry model.AlertHistory
+	if err := r.history.FindOneAndUpdate(ctx, filter, update, opts).Decode(&entry); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UpdateHistory records the delivery outcome of a firing.
+func (r *AlertRepository) UpdateHistory(ctx context.Context, entry *model.AlertHistory) error {
+	_, err := r.history.UpdateOne(ctx, bson.M{"_id": entry.ID}, bson.M{
+		"$set": bson.M{"delivered": entry.Delivered, "error": entry.Error},
+	})
+	return err
+}
+
+// FindHistory lists a user's latest firings, newest first.
+func (r *AlertRepository) FindHistory(ctx context.Context, userID int64, limit int64) ([]model.AlertHistory, error) {
+	opts := options.Find().SetSort(bson.M{"triggeredAt": -1}).SetLimit(limit)
+	cursor, err := r.history.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []model.AlertHistory
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		return []model.AlertHistory{}, nil
+	}
+	return entries, nil
+}
+
+func (r *AlertRepository) find(ctx context.Context, filter bson.M) ([]model.Alert, error) {
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var alerts []model.Alert
+	if err = cursor.All(ctx, &alerts); err != nil {
+		return nil, err
+	}
+	if alerts == nil {
+		return []model.Alert{}, nil
+	}
+	return alerts, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CandleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCandleRepository initializes the repository for the candles collection.
+// Candles are unique per symbol and session time.
+func NewCandleRepository(db *mongo.Database) *CandleRepository {
+	r := &CandleRepository{
+		collection: db.Collection("candles"),
+	}
+
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "symbol", Value: 1}, {Key: "time", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to create candle index: %v", err)
+	}
+	return r
+}
+
+// UpsertMany stores candles, overwriting any existing bar for the same symbol and time.
+func (r *CandleRepository) UpsertMany(ctx context.Context, candles []model.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(candles))
+	for _, c := range candles {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"symbol": c.Symbol, "time": c.Time}).
+			SetReplacement(c).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// FindRange returns a symbol's candles between from and to (inclusive), oldest first.
+func (r *CandleRepository) FindRange(ctx context.Context, symbol string, from, to time.Time) ([]model.Candle, error) {
+	filter := bson.M{
+		"symbol": symbol,
+		"time":   bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"time": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var candles []model.Candle
+	if err = cursor.All(ctx, &candles); err != nil {
+		return nil, err
+	}
+	if candles == nil {
+		return []model.Candle{}, nil
+	}
+	return candles, nil
+}
+
+// FindLatest returns the newest stored candle of a symbol, or nil if none exist.
+func (r *CandleRepository) FindLatest(ctx context.Context, symbol string) (*model.Candle, error) {
+	return r.findEdge(ctx, symbol, -1)
+}
+
+// FindEarliest returns the oldest stored candle of a symbol, or nil if none exist.
+func (r *CandleRepository) FindEarliest(ctx context.Context, symbol string) (*model.Candle, error) {
+	return r.findEdge(ctx, symbol, 1)
+}
+
+func (r *CandleRepository) findEdge(ctx context.Context, symbol string, order int) (*model.Candle, error) {
+	var candle model.Candle
+	opts := options.FindOne().SetSort(bson.M{"time": order})
+	err := r.collection.FindOne(ctx, bson.M{"symbol": symbol}, opts).Decode(&candle)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &candle, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewJobRepository initializes the repository for the jobs collection.
+func NewJobRepository(db *mongo.Database) *JobRepository {
+	return &JobRepository{
+		collection: db.Collection("jobs"),
+	}
+}
+
+// Insert stores a new job and sets its generated ID.
+func (r *JobRepository) Insert(ctx context.Context, job *model.Job) error {
+	res, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		return err
+	}
+	job.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update replaces the stored job with the given state.
+func (r *JobRepository) Update(ctx context.Context, job *model.Job) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": job.ID}, job)
+	return err
+}
+
+// FindById retrieves a job by its ID, returning nil if it does not exist.
+func (r *JobRepository) FindById(ctx context.Context, id primitive.ObjectID) (*model.Job, error) {
+	var job model.Job
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindRecent lists the latest jobs matching the filter, newest first.
+func (r *JobRepository) FindRecent(ctx context.Context, filter bson.M, limit int64) ([]model.Job, error) {
+	opts := options.Find().
+		SetSort(bson.M{"createdAt": -1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"errors": 0})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []model.Job
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	if jobs == nil {
+		return []model.Job{}, nil
+	}
+	return jobs, nil
+}
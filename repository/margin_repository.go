@@ -5,21 +5,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MarginRepository struct {
 	collection *mongo.Collection
+	batches    *mongo.Collection
+	changes    *mongo.Collection
 }
 
-// NewMarginRepository initializes the repository for the margin collection.
+// NewMarginRepository initializes the repository for the margin collection and its import history in
+// margin_batches and margin_changes.
 func NewMarginRepository(db *mongo.Database) *MarginRepository {
-	return &MarginRepository{
+	r := &MarginRepository{
 		collection: db.Collection("margin"),
+		batches:    db.Collection("margin_batches"),
+		changes:    db.Collection("margin_changes"),
 	}
+
+	if _, err := r.changes.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "batchId", Value: 1}, {Key: "symbol", Value: 1}}},
+		{Keys: bson.D{{Key: "symbol", Value: 1}, {Key: "at", Value: -1}}},
+	}); err != nil {
+		log.Printf("Warning: failed to create margin change indexes: %v", err)
+	}
+	return r
 }
 
 // --- Query Methods ---
@@ -109,3 +124,90 @@ func (r *MarginRepository) DeleteByIdNotIn(ctx context.Context, ids []string) (i
 
 	return result.DeletedCount, nil
 }
+
+// --- Import History ---
+
+// InsertBatch records a margin import and sets its generated ID.
+func (r *MarginRepository) InsertBatch(ctx context.Context, batch *model.MarginBatch) error {
+	res, err := r.batches.InsertOne(ctx, batch)
+	if err != nil {
+		return err
+	}
+	batch.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindBatchById returns a batch with its margin set, or nil if it does not exist.
+func (r *MarginRepository) FindBatchById(ctx context.Context, id primitive.ObjectID) (*model.MarginBatch, error) {
+	var batch model.MarginBatch
+	err := r.batches.FindOne(ctx, bson.M{"_id": id}).Decode(&batch)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// FindBatches lists imports without their margin sets, newest first.
+func (r *MarginRepository) FindBatches(ctx context.Context, limit int64) ([]model.MarginBatch, error) {
+	opts := options.Find().
+		SetSort(bson.M{"importedAt": -1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"margins": 0})
+	cursor, err := r.batches.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var batches []model.MarginBatch
+	if err = cursor.All(ctx, &batches); err != nil {
+		return nil, err
+	}
+	if batches == nil {
+		return []model.MarginBatch{}, nil
+	}
+	return batches, nil
+}
+
+// InsertChanges records the per-symbol changes of a batch.
+func (r *MarginRepository) InsertChanges(ctx context.Context, changes []model.MarginChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	docs := make([]any, len(changes))
+	for i := range changes {
+		docs[i] = changes[i]
+	}
+	_, err := r.changes.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// FindChangesByBatch lists a batch's changes by symbol.
+func (r *MarginRepository) FindChangesByBatch(ctx context.Context, batchID primitive.ObjectID) ([]model.MarginChange, error) {
+	return r.findChanges(ctx, bson.M{"batchId": batchID}, options.Find().SetSort(bson.M{"symbol": 1}))
+}
+
+// FindChangesBySymbol lists a symbol's changes across imports, newest first.
+func (r *MarginRepository) FindChangesBySymbol(ctx context.Context, symbol string, limit int64) ([]model.MarginChange, error) {
+	return r.findChanges(ctx, bson.M{"symbol": symbol}, options.Find().SetSort(bson.M{"at": -1}).SetLimit(limit))
+}
+
+func (r *MarginRepository) findChanges(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]model.MarginChange, error) {
+	cursor, err := r.changes.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []model.MarginChange
+	if err = cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		return []model.MarginChange{}, nil
+	}
+	return changes, nil
+}
@@ -31,6 +31,18 @@ func (r *PriceActionRepo) SaveFvg(ctx context.Context, ob model.ObRequest) error
 	return r.saveNestedInfo(ctx, ob, "fvg")
 }
 
+// HasOrderBlock reports whether a symbol already has an order block on a date, timeframe and direction.
+func (r *PriceActionRepo) HasOrderBlock(ctx context.Context, symbol, date string, tf model.Timeframe,
+	direction model.Direction) (bool, error) {
+	return r.hasNestedInfo(ctx, symbol, zoneKey(date, tf, direction), "order_blocks")
+}
+
+// HasFvg reports whether a symbol already has an FVG on a date, timeframe and direction.
+func (r *PriceActionRepo) HasFvg(ctx context.Context, symbol, date string, tf model.Timeframe,
+	direction model.Direction) (bool, error) {
+	return r.hasNestedInfo(ctx, symbol, zoneKey(date, tf, direction), "fvg")
+}
+
 func (r *PriceActionRepo) UpdateOrderBlock(ctx context.Context, req model.ObRequest) error {
 	return r.updateNestedInfo(ctx, req, "order_blocks")
 }
@@ -39,12 +51,47 @@ func (r *PriceActionRepo) UpdateFvg(ctx context.Context, req model.ObRequest) er
 	return r.updateNestedInfo(ctx, req, "fvg")
 }
 
-func (r *PriceActionRepo) DeleteOrderBlockByDate(ctx context.Context, symbol, date string) error {
-	return r.deleteNestedInfo(ctx, symbol, date, "order_blocks")
+func (r *PriceActionRepo) DeleteOrderBlockByDate(ctx context.Context, symbol, date string, tf model.Timeframe,
+	direction model.Direction) error {
+	return r.deleteNestedInfo(ctx, symbol, zoneKey(date, tf, direction), "order_blocks")
+}
+
+func (r *PriceActionRepo) DeleteFvgByDate(ctx context.Context, symbol, date string, tf model.Timeframe,
+	direction model.Direction) error {
+	return r.deleteNestedInfo(ctx, symbol, zoneKey(date, tf, direction), "fvg")
+}
+
+// UpdateOrderBlockLifecycle stores the lifecycle fields of an order block.
+func (r *PriceActionRepo) UpdateOrderBlockLifecycle(ctx context.Context, symbol string, info model.Info) error {
+	return r.updateLifecycle(ctx, symbol, info, "order_blocks")
+}
+
+// UpdateFvgLifecycle stores the lifecycle fields of an FVG.
+func (r *PriceActionRepo) UpdateFvgLifecycle(ctx context.Context, symbol string, info model.Info) error {
+	return r.updateLifecycle(ctx, symbol, info, "fvg")
 }
 
-func (r *PriceActionRepo) DeleteFvgByDate(ctx context.Context, symbol, date string) error {
-	return r.deleteNestedInfo(ctx, symbol, date, "fvg")
+// BackfillZoneDefaults marks zones saved before timeframes, directions and statuses existed as daily, bullish and fresh.
+func (r *PriceActionRepo) BackfillZoneDefaults(ctx context.Context) error {
+	defaults := map[string]any{
+		"timeframe":  model.Timeframe1D,
+		"direction":  model.DirectionBullish,
+		"status":     model.ZoneFresh,
+		"touchCount": 0,
+	}
+	for _, fieldName := range []string{"order_blocks", "fvg"} {
+		for key, value := range defaults {
+			update := bson.M{"$set": bson.M{fieldName + ".$[elem]." + key: value}}
+			opts := options.Update().SetArrayFilters(options.ArrayFilters{
+				Filters: []any{bson.M{"elem." + key: bson.M{"$exists": false}}},
+			})
+			filter := bson.M{fieldName: bson.M{"$elemMatch": bson.M{key: bson.M{"$exists": false}}}}
+			if _, err := r.collection.UpdateMany(ctx, filter, update, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // --- Standard Query API ---
@@ -71,15 +118,32 @@ func (r *PriceActionRepo) GetPAByID(ctx context.Context, symbol string) (model.S
 
 // --- Generic Helpers (The Refactor Magic) ---
 
+// zoneKey matches the zone of a date, timeframe and direction; a bullish and a bearish zone can share a date.
+func zoneKey(date string, tf model.Timeframe, direction model.Direction) bson.M {
+	return bson.M{"date": date, "timeframe": tf.OrDefault(), "direction": direction.OrDefault()}
+}
+
+// elemFilter prefixes a zone key for use as the $[elem] array filter.
+func elemFilter(key bson.M) bson.M {
+	filter := bson.M{}
+	for field, value := range key {
+		filter["elem."+field] = value
+	}
+	return filter
+}
+
 // saveNestedInfo handles the BulkWrite logic (Pull then Push) to ensure no duplicate dates and sorted results.
 func (r *PriceActionRepo) saveNestedInfo(ctx context.Context, req model.ObRequest, fieldName string) error {
 	var newInfo model.Info
 	copier.Copy(&newInfo, &req)
+	newInfo.Timeframe = req.Timeframe.OrDefault()
+	newInfo.Direction = req.Direction.OrDefault()
+	newInfo.Status = model.ZoneFresh
 
-	// Operation 1: Remove existing record for that date
+	// Operation 1: Remove existing record for that date, timeframe and direction
 	pull := mongo.NewUpdateOneModel().
 		SetFilter(bson.M{"_id": req.Symbol}).
-		SetUpdate(bson.M{"$pull": bson.M{fieldName: bson.M{"date": req.Date}}})
+		SetUpdate(bson.M{"$pull": bson.M{fieldName: zoneKey(req.Date, newInfo.Timeframe, newInfo.Direction)}})
 
 	// Operation 2: Add new record and sort the array by date descending
 	push := mongo.NewUpdateOneModel().
@@ -97,17 +161,23 @@ func (r *PriceActionRepo) saveNestedInfo(ctx context.Context, req model.ObReques
 	return err
 }
 
-// updateNestedInfo uses arrayFilters ($[elem]) to perform surgical updates on a specific date.
+func (r *PriceActionRepo) hasNestedInfo(ctx context.Context, symbol string, key bson.M, fieldName string) (bool, error) {
+	filter := bson.M{"_id": symbol, fieldName: bson.M{"$elemMatch": key}}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// updateNestedInfo uses arrayFilters ($[elem]) to perform surgical updates on a specific date, timeframe and direction.
 func (r *PriceActionRepo) updateNestedInfo(ctx context.Context, req model.ObRequest, fieldName string) error {
-	filter := bson.M{"_id": req.Symbol, fieldName + ".date": req.Date}
-	update := bson.M{
-		"$set": bson.M{
-			fieldName + ".$[elem].high": req.High,
-			fieldName + ".$[elem].low":  req.Low,
-		},
-	}
+	tf := req.Timeframe.OrDefault()
+	key := zoneKey(req.Date, tf, req.Direction)
+	filter := bson.M{"_id": req.Symbol, fieldName: bson.M{"$elemMatch": key}}
+	update := bson.M{"$set": bson.M{
+		fieldName + ".$[elem].high": req.High,
+		fieldName + ".$[elem].low":  req.Low,
+	}}
 	opts := options.Update().SetArrayFilters(options.ArrayFilters{
-		Filters: []any{bson.M{"elem.date": req.Date}},
+		Filters: []any{elemFilter(key)},
 	})
 
 	res, err := r.collection.UpdateOne(ctx, filter, update, opts)
@@ -115,22 +185,41 @@ func (r *PriceActionRepo) updateNestedInfo(ctx context.Context, req model.ObRequ
 		return err
 	}
 	if res.ModifiedCount == 0 {
-		return fmt.Errorf("no %s record found for date %s", fieldName, req.Date)
+		return fmt.Errorf("no %s %s %s record found for date %s", tf, req.Direction.OrDefault(), fieldName, req.Date)
 	}
 	return nil
 }
 
-// deleteNestedInfo removes a specific object from the nested array by date.
-func (r *PriceActionRepo) deleteNestedInfo(ctx context.Context, symbol, date, fieldName string) error {
+// updateLifecycle sets the status fields of the zone matching the info's date, timeframe and direction.
+func (r *PriceActionRepo) updateLifecycle(ctx context.Context, symbol string, info model.Info, fieldName string) error {
+	update := bson.M{
+		"$set": bson.M{
+			fieldName + ".$[elem].status":        info.Status,
+			fieldName + ".$[elem].touchCount":    info.TouchCount,
+			fieldName + ".$[elem].mitigatedOn":   info.MitigatedOn,
+			fieldName + ".$[elem].invalidatedOn": info.InvalidatedOn,
+		},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{elemFilter(zoneKey(info.Date, info.Timeframe, info.Direction))},
+	})
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": symbol}, update, opts)
+	return err
+}
+
+// deleteNestedInfo removes a specific object from the nested array by date, timeframe and direction.
+func (r *PriceActionRepo) deleteNestedInfo(ctx context.Context, symbol string, key bson.M, fieldName string) error {
 	filter := bson.M{"_id": symbol}
-	update := bson.M{"$pull": bson.M{fieldName: bson.M{"date": date}}}
+	update := bson.M{"$pull": bson.M{fieldName: key}}
 
 	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 	if res.ModifiedCount == 0 {
-		return fmt.Errorf("no %s record found to delete for date %s", fieldName, date)
+		return fmt.Errorf("no %s %s %s record found to delete for date %s", key["timeframe"], key["direction"], fieldName,
+			key["date"])
 	}
 	return nil
 }
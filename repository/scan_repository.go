@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScanRepository struct {
+	collection *mongo.Collection
+}
+
+// NewScanRepository initializes the repository for the scan_snapshots collection.
+func NewScanRepository(db *mongo.Database) *ScanRepository {
+	r := &ScanRepository{collection: db.Collection("scan_snapshots")}
+
+	if _, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "strategy", Value: 1}, {Key: "takenAt", Value: -1}},
+	}); err != nil {
+		log.Printf("Warning: failed to create scan snapshot index: %v", err)
+	}
+	return r
+}
+
+// Insert stores a new snapshot and sets its generated ID.
+func (r *ScanRepository) Insert(ctx context.Context, snapshot *model.ScanSnapshot) error {
+	res, err := r.collection.InsertOne(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	snapshot.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Touch records another run that returned the same result as a snapshot.
+func (r *ScanRepository) Touch(ctx context.Context, id primitive.ObjectID, seenAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{"lastSeenAt": seenAt},
+		"$inc": bson.M{"runs": 1},
+	})
+	return err
+}
+
+// FindById returns a snapshot with its stocks, or nil if it does not exist.
+func (r *ScanRepository) FindById(ctx context.Context, id primitive.ObjectID) (*model.ScanSnapshot, error) {
+	return r.findOne(ctx, bson.M{"_id": id}, options.FindOne())
+}
+
+// FindLatest returns a strategy's newest snapshot, taken before a time unless it is zero, or nil if there is none.
+func (r *ScanRepository) FindLatest(ctx context.Context, strategy string, before time.Time) (*model.ScanSnapshot, error) {
+	filter := bson.M{"strategy": strategy}
+	if !before.IsZero() {
+		filter["takenAt"] = bson.M{"$lt": before}
+	}
+	return r.findOne(ctx, filter, options.FindOne().SetSort(bson.M{"takenAt": -1}))
+}
+
+// FindByStrategy lists a strategy's snapshots without their stocks, newest first.
+func (r *ScanRepository) FindByStrategy(ctx context.Context, strategy string, limit int64) ([]model.ScanSnapshot, error) {
+	opts := options.Find().
+		SetSort(bson.M{"takenAt": -1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"stocks": 0})
+	cursor, err := r.collection.Find(ctx, bson.M{"strategy": strategy}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []model.ScanSnapshot
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	if snapshots == nil {
+		return []model.ScanSnapshot{}, nil
+	}
+	return snapshots, nil
+}
+
+func (r *ScanRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*model.ScanSnapshot, error) {
+	var snapshot model.ScanSnapshot
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&snapshot)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SchedulerRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSchedulerRepository initializes the repository for the scheduler_runs collection.
+func NewSchedulerRepository(db *mongo.Database) *SchedulerRepository {
+	return &SchedulerRepository{
+		collection: db.Collection("scheduler_runs"),
+	}
+}
+
+// Insert stores a new run and sets its generated ID.
+func (r *SchedulerRepository) Insert(ctx context.Context, run *model.SchedulerRun) error {
+	res, err := r.collection.InsertOne(ctx, run)
+	if err != nil {
+		return err
+	}
+	run.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update replaces the stored run with the given state.
+func (r *SchedulerRepository) Update(ctx context.Context, run *model.SchedulerRun) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": run.ID}, run)
+	return err
+}
+
+// FindLatest returns the most recent run of a job, or nil if it never ran.
+func (r *SchedulerRepository) FindLatest(ctx context.Context, job string) (*model.SchedulerRun, error) {
+	var run model.SchedulerRun
+	opts := options.FindOne().SetSort(bson.M{"startedAt": -1})
+	err := r.collection.FindOne(ctx, bson.M{"job": job}, opts).Decode(&run)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FindLatestSuccess returns the most recent successful run of a job, or nil if none exists.
+func (r *SchedulerRepository) FindLatestSuccess(ctx context.Context, job string) (*model.SchedulerRun, error) {
+	var run model.SchedulerRun
+	opts := options.FindOne().SetSort(bson.M{"startedAt": -1})
+	filter := bson.M{"job": job, "status": model.RunStatusSuccess}
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&run)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FindRecent lists the latest runs, optionally restricted to a single job.
+func (r *SchedulerRepository) FindRecent(ctx context.Context, job string, limit int64) ([]model.SchedulerRun, error) {
+	filter := bson.M{}
+	if job != "" {
+		filter["job"] = job
+	}
+
+	opts := options.Find().SetSort(bson.M{"startedAt": -1}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []model.SchedulerRun
+	if err = cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+	if runs == nil {
+		return []model.SchedulerRun{}, nil
+	}
+	return runs, nil
+}
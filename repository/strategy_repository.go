@@ -4,21 +4,34 @@ import (
 	"backend/model"
 	"context"
 	"errors"
+	"log"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type StrategyRepository struct {
 	collection *mongo.Collection
+	versions   *mongo.Collection
 }
 
-// NewStrategyRepository initializes the repository for the chartink_strategy collection.
+// NewStrategyRepository initializes the repository for the chartink_strategy and chartink_strategy_versions
+// collections.
 func NewStrategyRepository(db *mongo.Database) *StrategyRepository {
-	return &StrategyRepository{
+	r := &StrategyRepository{
 		collection: db.Collection("chartink_strategy"),
+		versions:   db.Collection("chartink_strategy_versions"),
 	}
+
+	if _, err := r.versions.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "strategy", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Printf("Warning: failed to create strategy version index: %v", err)
+	}
+	return r
 }
 
 // Save handles both Insert and Update using Upsert logic.
@@ -66,8 +79,61 @@ func (r *StrategyRepository) FindAll(ctx context.Context) ([]model.Strategy, err
 	return strategies, nil
 }
 
-// DeleteById removes a strategy from the collection by its name.
+// DeleteById removes a strategy and its version history by its name.
 func (r *StrategyRepository) DeleteById(ctx context.Context, name string) error {
-	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name})
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": name}); err != nil {
+		return err
+	}
+	_, err := r.versions.DeleteMany(ctx, bson.M{"strategy": name})
 	return err
 }
+
+// InsertVersion records a scan clause version and sets its generated ID.
+func (r *StrategyRepository) InsertVersion(ctx context.Context, version *model.StrategyVersion) error {
+	res, err := r.versions.InsertOne(ctx, version)
+	if err != nil {
+		return err
+	}
+	version.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindVersions lists a strategy's scan clause versions, newest first.
+func (r *StrategyRepository) FindVersions(ctx context.Context, name string) ([]model.StrategyVersion, error) {
+	cursor, err := r.versions.Find(ctx, bson.M{"strategy": name}, options.Find().SetSort(bson.M{"version": -1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var versions []model.StrategyVersion
+	if err = cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		return []model.StrategyVersion{}, nil
+	}
+	return versions, nil
+}
+
+// FindVersion returns one version of a strategy's scan clause, or nil if it does not exist.
+func (r *StrategyRepository) FindVersion(ctx context.Context, name string, version int) (*model.StrategyVersion, error) {
+	return r.findVersion(ctx, bson.M{"strategy": name, "version": version}, options.FindOne())
+}
+
+// FindLatestVersion returns a strategy's newest recorded version, or nil if none was recorded yet.
+func (r *StrategyRepository) FindLatestVersion(ctx context.Context, name string) (*model.StrategyVersion, error) {
+	return r.findVersion(ctx, bson.M{"strategy": name}, options.FindOne().SetSort(bson.M{"version": -1}))
+}
+
+func (r *StrategyRepository) findVersion(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*model.StrategyVersion, error) {
+	var v model.StrategyVersion
+	err := r.versions.FindOne(ctx, filter, opts).Decode(&v)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TradeRepository struct {
+	collection *mongo.Collection
+}
+
+// NewTradeRepository initializes the repository for the trades collection.
+func NewTradeRepository(db *mongo.Database) *TradeRepository {
+	r := &TradeRepository{
+		collection: db.Collection("trades"),
+	}
+
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "status", Value: 1}, {Key: "entryDate", Value: -1}},
+	})
+	if err != nil {
+		log.Printf("Warning: failed to create trade index: %v", err)
+	}
+	return r
+}
+
+// Insert stores a new trade and sets its generated ID.
+func (r *TradeRepository) Insert(ctx context.Context, trade *model.Trade) error {
+	res, err := r.collection.InsertOne(ctx, trade)
+	if err != nil {
+		return err
+	}
+	trade.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update replaces a trade owned by the user, reporting whether it was found.
+func (r *TradeRepository) Update(ctx context.Context, trade *model.Trade) (bool, error) {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": trade.ID, "userId": trade.UserID}, trade)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// FindById retrieves a trade owned by the user, returning nil if it does not exist.
+func (r *TradeRepository) FindById(ctx context.Context, userID int64, id primitive.ObjectID) (*model.Trade, error) {
+	var trade model.Trade
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "userId": userID}).Decode(&trade)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &trade, nil
+}
+
+// FindByUser lists a user's trades, newest entry first. An empty status matches all trades.
+func (r *TradeRepository) FindByUser(ctx context.Context, userID int64, status model.TradeStatus) ([]model.Trade, error) {
+	filter := bson.M{"userId": userID}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "entryDate", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var trades []model.Trade
+	if err = cursor.All(ctx, &trades); err != nil {
+		return nil, err
+	}
+	if trades == nil {
+		return []model.Trade{}, nil
+	}
+	return trades, nil
+}
+
+// Delete removes a trade owned by the user, reporting whether it existed.
+func (r *TradeRepository) Delete(ctx context.Context, userID int64, id primitive.ObjectID) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "userId": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
@@ -44,6 +44,23 @@ func (r *UserRepository) FindOne(ctx context.Context, filter bson.M) (*model.Use
 	return &user, nil
 }
 
+func (r *UserRepository) Find(ctx context.Context, filter bson.M) ([]model.User, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []model.User
+	if err = cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	if users == nil {
+		return []model.User{}, nil
+	}
+	return users, nil
+}
+
 func (s *UserRepository) GetNextSequence(ctx context.Context, sequenceName string) (int, error) {
 	filter := bson.M{"_id": sequenceName}
 	update := bson.M{"$inc": bson.M{"seq": 1}}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrDuplicateWatchlist is returned when a user already has a watchlist with the name.
+var ErrDuplicateWatchlist = errors.New("a watchlist with this name already exists")
+
+type WatchlistRepository struct {
+	collection *mongo.Collection
+}
+
+// NewWatchlistRepository initializes the repository for the watchlists collection.
+func NewWatchlistRepository(db *mongo.Database) *WatchlistRepository {
+	r := &WatchlistRepository{
+		collection: db.Collection("watchlists"),
+	}
+
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to create watchlist index: %v", err)
+	}
+	return r
+}
+
+// Insert stores a new watchlist and sets its generated ID.
+func (r *WatchlistRepository) Insert(ctx context.Context, list *model.Watchlist) error {
+	res, err := r.collection.InsertOne(ctx, list)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicateWatchlist
+		}
+		return err
+	}
+	list.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update replaces a watchlist owned by the user, reporting whether it was found.
+func (r *WatchlistRepository) Update(ctx context.Context, list *model.Watchlist) (bool, error) {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": list.ID, "userId": list.UserID}, list)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, ErrDuplicateWatchlist
+		}
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// SetPosition moves a user's watchlist in their ordering.
+func (r *WatchlistRepository) SetPosition(ctx context.Context, userID int64, id primitive.ObjectID, position int) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "userId": userID}, bson.M{"$set": bson.M{"position": position}})
+	return err
+}
+
+// FindById retrieves a watchlist owned by the user, returning nil if it does not exist.
+func (r *WatchlistRepository) FindById(ctx context.Context, userID int64, id primitive.ObjectID) (*model.Watchlist, error) {
+	var list model.Watchlist
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "userId": userID}).Decode(&list)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &list, nil
+}
+
+// FindByUser lists a user's watchlists in their order.
+func (r *WatchlistRepository) FindByUser(ctx context.Context, userID int64) ([]model.Watchlist, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var lists []model.Watchlist
+	if err = cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	if lists == nil {
+		return []model.Watchlist{}, nil
+	}
+	return lists, nil
+}
+
+// Delete removes a user's watchlist, reporting whether it existed.
+func (r *WatchlistRepository) Delete(ctx context.Context, userID int64, id primitive.ObjectID) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "userId": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"backend/model"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookRepository struct {
+	collection *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+// NewWebhookRepository initializes the repository for the webhooks and webhook_deliveries collections.
+func NewWebhookRepository(db *mongo.Database) *WebhookRepository {
+	r := &WebhookRepository{
+		collection: db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+	}
+
+	ctx := context.Background()
+	if _, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "active", Value: 1}, {Key: "events", Value: 1}},
+	}); err != nil {
+		log.Printf("Warning: failed to create webhook index: %v", err)
+	}
+	if _, err := r.deliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "webhookId", Value: 1}, {Key: "createdAt", Value: -1}},
+	}); err != nil {
+		log.Printf("Warning: failed to create webhook delivery index: %v", err)
+	}
+	if _, err := r.deliveries.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "nextAttemptAt", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}); err != nil {
+		log.Printf("Warning: failed to create webhook retry index: %v", err)
+	}
+	return r
+}
+
+// Insert stores a new webhook and sets its generated ID.
+func (r *WebhookRepository) Insert(ctx context.Context, hook *model.Webhook) error {
+	res, err := r.collection.InsertOne(ctx, hook)
+	if err != nil {
+		return err
+	}
+	hook.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update replaces a webhook, reporting whether it was found.
+func (r *WebhookRepository) Update(ctx context.Context, hook *model.Webhook) (bool, error) {
+	res, err := r.collection.ReplaceOne(ctx, bson.M{"_id": hook.ID}, hook)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+// Delete removes a webhook and its delivery log, reporting whether it existed.
+func (r *WebhookRepository) Delete(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.deliveries.DeleteMany(ctx, bson.M{"webhookId": id}); err != nil {
+		log.Printf("Warning: failed to delete deliveries of webhook %s: %v", id.Hex(), err)
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// FindById returns a webhook, or nil if it does not exist.
+func (r *WebhookRepository) FindById(ctx context.Context, id primitive.ObjectID) (*model.Webhook, error) {
+	var hook model.Webhook
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&hook)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// FindAll lists every webhook, newest first.
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]model.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindSubscribed lists the active webhooks subscribed to an event.
+func (r *WebhookRepository) FindSubscribed(ctx context.Context, event model.WebhookEvent) ([]model.Webhook, error) {
+	return r.find(ctx, bson.M{"active": true, "events": event})
+}
+
+// InsertDelivery logs a new delivery and sets its generated ID.
+func (r *WebhookRepository) InsertDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	res, err := r.deliveries.InsertOne(ctx, delivery)
+	if err != nil {
+		return err
+	}
+	delivery.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// UpdateDelivery records the outcome of the latest attempt.
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	_, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": delivery.ID}, bson.M{"$set": bson.M{
+		"attempts":      delivery.Attempts,
+		"statusCode":    delivery.StatusCode,
+		"success":       delivery.Success,
+		"error":         delivery.Error,
+		"nextAttemptAt": delivery.NextAttemptAt,
+		"deliveredAt":   delivery.DeliveredAt,
+	}})
+	return err
+}
+
+// ClaimDueDelivery returns the delivery whose retry is most overdue, or nil if none is due. Its next attempt is
+// pushed to leaseUntil so a retry that dies with the process is picked up again.
+func (r *WebhookRepository) ClaimDueDelivery(ctx context.Context, now, leaseUntil time.Time) (*model.WebhookDelivery, error) {
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"nextAttemptAt": 1}).
+		SetReturnDocument(options.After)
+	var delivery model.WebhookDelivery
+	err := r.deliveries.FindOneAndUpdate(ctx,
+		bson.M{"nextAttemptAt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"nextAttemptAt": leaseUntil}},
+		opts,
+	).Decode(&delivery)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindDeliveries lists a webhook's latest deliveries, newest first.
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID primitive.ObjectID, limit int64) ([]model.WebhookDelivery, error) {
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(limit)
+	cursor, err := r.deliveries.Find(ctx, bson.M{"webhookId": webhookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []model.WebhookDelivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		return []model.WebhookDelivery{}, nil
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) find(ctx context.Context, filter bson.M) ([]model.Webhook, error) {
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var hooks []model.Webhook
+	if err = cursor.All(ctx, &hooks); err != nil {
+		return nil, err
+	}
+	if hooks == nil {
+		return []model.Webhook{}, nil
+	}
+	return hooks, nil
+}
@@ -1,11 +1,15 @@
 package routes
 
 import (
+	"context"
+
 	"backend/auth"
 	"backend/client"
 	"backend/config"
 	"backend/controller"
+	"backend/events"
 	"backend/middleware"
+	"backend/provider"
 	"backend/repository"
 	"backend/service"
 
@@ -36,6 +40,8 @@ func SetupRouter(db *mongo.Database, cfg *config.SystemConfigs) *gin.Engine {
 	// --- 1. Clients ---
 	brevoClient := client.NewBrevoClient()
 	chartInkClient := client.NewChartinkClient()
+	webhookClient := client.NewWebhookClient()
+	telegramClient := client.NewTelegramClient()
 
 	// --- 2. Repositories ---
 	userRepo := repository.NewUserRepository(db)
@@ -43,36 +49,77 @@ func SetupRouter(db *mongo.Database, cfg *config.SystemConfigs) *gin.Engine {
 	strategyRepo := repository.NewStrategyRepository(db)
 
 	// --- 3. Services (Dependency Injection) ---
+	bus := events.NewBus()
 	emailSvc := service.NewEmailService(brevoClient, configmanager)
 	otpSvc := service.NewOtpService(emailSvc, configmanager)
 	userSvc := service.NewUserService(userRepo)
 
 	marginSvc := service.NewMarginService(marginRepo, configmanager)
-	strategySvc := service.NewStrategyService(strategyRepo)
-	chartInkSvc := service.NewChartInkService(chartInkClient, marginSvc)
-	yahooClient := client.NewYahooClient()
-	nseSvc := service.NewNseService(yahooClient)
+	scanRepo := repository.NewScanRepository(db)
+	chartInkSvc := service.NewChartInkService(chartInkClient, marginSvc, bus, scanRepo)
+	strategySvc := service.NewStrategyService(strategyRepo, userSvc, chartInkSvc)
+	nseClient := client.NewNseClient()
+	marketData := provider.NewChain(configmanager,
+		provider.NewYahooProvider(client.NewYahooClient()),
+		provider.NewNseProvider(nseClient),
+		provider.NewFileProvider(func() string { return configmanager.GetConfig().MarketData.FileDir }),
+	)
+	priceActionRepo := repository.NewPriceActionRepo(db)
+	scanSvc := service.NewScanService(scanRepo, chartInkSvc, priceActionRepo)
+	candleRepo := repository.NewCandleRepository(db)
+	candleSvc := service.NewCandleService(candleRepo, marketData, configmanager, priceActionRepo, marginSvc, bus)
+	nseSvc := service.NewNseService(nseClient, marketData, candleSvc)
 	auth.SecretKey = []byte(configmanager.GetConfig().JwtSecret)
 
 	if !isProduction {
 		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
-	priceActionRepo := repository.NewPriceActionRepo(db)
-	priceActionSvc := service.NewPriceActionService(chartInkSvc, nseSvc, priceActionRepo, marginSvc)
+	priceActionSvc := service.NewPriceActionService(chartInkSvc, nseSvc, candleSvc, priceActionRepo, marginSvc, bus,
+		configmanager)
+
+	jobRepo := repository.NewJobRepository(db)
+	jobSvc := service.NewJobService(jobRepo, bus)
+
+	schedulerRepo := repository.NewSchedulerRepository(db)
+	detectionSvc := service.NewDetectionService(candleSvc, priceActionRepo, marginSvc, configmanager, bus)
+	schedulerSvc := service.NewSchedulerService(schedulerRepo, jobSvc, configmanager, priceActionSvc, candleSvc, detectionSvc, scanSvc)
+	schedulerSvc.Start(context.Background())
+	backtestSvc := service.NewBacktestService(candleSvc, priceActionRepo, marginSvc)
+
+	tradeRepo := repository.NewTradeRepository(db)
+	tradeSvc := service.NewTradeService(tradeRepo, candleSvc, marginSvc)
+	sizingSvc := service.NewSizingService(userSvc, priceActionRepo, candleSvc, marginSvc)
+
+	notificationSvc := service.NewNotificationService(userSvc, bus,
+		service.NewEmailChannel(emailSvc, configmanager),
+		service.NewTelegramChannel(telegramClient, configmanager),
+	)
+	notificationSvc.Start()
+
+	alertRepo := repository.NewAlertRepository(db)
+	alertSvc := service.NewAlertService(alertRepo, candleSvc, notificationSvc, bus)
+	alertSvc.Start()
+
+	watchlistRepo := repository.NewWatchlistRepository(db)
+	watchlistSvc := service.NewWatchlistService(watchlistRepo, priceActionRepo, candleSvc, marginSvc)
+
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookSvc := service.NewWebhookService(webhookRepo, webhookClient, bus)
+	webhookSvc.Start()
 
 	// --- 4. Routes & Controllers ---
 	api := r.Group("/api")
 	{
 
 		// Health Check
-		controller.NewHealthController().RegisterRoutes(api)
+		controller.NewHealthController(chartInkSvc).RegisterRoutes(api)
 
 		// Email Endpoints
 		controller.NewEmailController(emailSvc).RegisterRoutes(api)
 
 		// Margin Endpoints
-		controller.NewMarginController(marginSvc).RegisterRoutes(api)
+		controller.NewMarginController(marginSvc, isProduction).RegisterRoutes(api)
 
 		// Strategy Endpoints
 		controller.NewStrategyController(strategySvc, isProduction).RegisterRoutes(api)
@@ -80,6 +127,8 @@ func SetupRouter(db *mongo.Database, cfg *config.SystemConfigs) *gin.Engine {
 		// ChartInk Endpoints
 		controller.NewChartInkController(chartInkSvc, strategySvc).RegisterRoutes(api)
 
+		controller.NewScanController(scanSvc).RegisterRoutes(api)
+
 		//User/Auth Endpoints (Once implemented)
 		controller.NewAuthController(userSvc, configmanager, otpSvc, isProduction).RegisterRoutes(api)
 
@@ -89,7 +138,29 @@ func SetupRouter(db *mongo.Database, cfg *config.SystemConfigs) *gin.Engine {
 
 		controller.NewConfigController(configService, isProduction).RegisterRoutes(api)
 
-		controller.NewPriceActionController(priceActionSvc, isProduction).RegisterRoutes(api)
+		controller.NewPriceActionController(priceActionSvc, schedulerSvc, jobSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewSchedulerController(schedulerSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewJobController(jobSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewDetectionController(detectionSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewBacktestController(backtestSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewTradeController(tradeSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewSizingController(sizingSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewAlertController(alertSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewNotificationController(notificationSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewStreamController(bus, isProduction).RegisterRoutes(api)
+
+		controller.NewWatchlistController(watchlistSvc, isProduction).RegisterRoutes(api)
+
+		controller.NewWebhookController(webhookSvc, isProduction).RegisterRoutes(api)
 	}
 
 	return r
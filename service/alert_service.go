@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"backend/detection"
+	"backend/events"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultAlertCooldown = 60 * time.Minute
+	// maxAlertAttempts bounds the deliveries of one firing, retried each time the alert is checked
+	maxAlertAttempts = 5
+	// alertTimeout bounds the evaluation of one event, including delivery
+	alertTimeout = 2 * time.Minute
+)
+
+var (
+	ErrAlertNotFound = errors.New("alert not found")
+	ErrInvalidAlert  = errors.New("invalid alert")
+)
+
+// AlertService manages user alerts and evaluates them as events arrive on the bus.
+type AlertService interface {
+	CreateAlert(ctx context.Context, userID int64, req model.AlertRequest) (*model.Alert, error)
+	ListAlerts(ctx context.Context, userID int64) ([]model.Alert, error)
+	SetActive(ctx context.Context, userID int64, id string, active bool) error
+	DeleteAlert(ctx context.Context, userID int64, id string) error
+	ListHistory(ctx context.Context, userID int64, limit int64) ([]model.AlertHistory, error)
+	// Start subscribes the alert engine to the bus. Price alerts are evaluated after every candle sync and
+	// mitigation check; zone alerts on every new or mitigated zone.
+	Start()
+}
+
+type AlertServiceImpl struct {
+	repo            *repository.AlertRepository
+	candleSvc       CandleService
+	notificationSvc NotificationService
+	bus             *events.Bus
+}
+
+func NewAlertService(repo *repository.AlertRepository, candleSvc CandleService,
+	notificationSvc NotificationService, bus *events.Bus) AlertService {
+	return &AlertServiceImpl{
+		repo:            repo,
+		candleSvc:       candleSvc,
+		notificationSvc: notificationSvc,
+		bus:             bus,
+	}
+}
+
+func (s *AlertServiceImpl) CreateAlert(ctx context.Context, userID int64, req model.AlertRequest) (*model.Alert, error) {
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidAlert, req.Type)
+	}
+
+	alert := &model.Alert{
+		UserID:          userID,
+		Symbol:          strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		Type:            req.Type,
+		Active:          true,
+		CooldownMinutes: req.CooldownMinutes,
+		CreatedAt:       time.Now(),
+	}
+	if alert.CooldownMinutes == 0 {
+		alert.CooldownMinutes = int(defaultAlertCooldown / time.Minute)
+	}
+
+	if req.Type == model.AlertPrice {
+		if req.Condition != model.CrossAbove && req.Condition != model.CrossBelow {
+			return nil, fmt.Errorf("%w: condition must be ABOVE or BELOW", ErrInvalidAlert)
+		}
+		if req.Level <= 0 {
+			return nil, fmt.Errorf("%w: level is required", ErrInvalidAlert)
+		}
+		alert.Condition = req.Condition
+		alert.Level = req.Level
+	} else {
+		if req.Kind != "" && req.Kind != detection.KindOrderBlock && req.Kind != detection.KindFvg {
+			return nil, fmt.Errorf("%w: kind must be OB or FVG", ErrInvalidAlert)
+		}
+		if req.Direction != "" && !req.Direction.IsValid() {
+			return nil, fmt.Errorf("%w: invalid direction %q", ErrInvalidAlert, req.Direction)
+		}
+		if req.Timeframe != "" && !req.Timeframe.IsValid() {
+			return nil, fmt.Errorf("%w: invalid timeframe %q", ErrInvalidAlert, req.Timeframe)
+		}
+		alert.Kind = req.Kind
+		alert.Direction = req.Direction
+		alert.Timeframe = req.Timeframe
+	}
+
+	if err := s.repo.Insert(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (s *AlertServiceImpl) ListAlerts(ctx context.Context, userID int64) ([]model.Alert, error) {
+	return s.repo.FindByUser(ctx, userID)
+}
+
+func (s *AlertServiceImpl) SetActive(ctx context.Context, userID int64, id string, active bool) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrAlertNotFound
+	}
+
+	found, err := s.repo.SetActive(ctx, userID, objectId, active)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+func (s *AlertServiceImpl) DeleteAlert(ctx context.Context, userID int64, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrAlertNotFound
+	}
+
+	found, err := s.repo.Delete(ctx, userID, objectId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+func (s *AlertServiceImpl) ListHistory(ctx context.Context, userID int64, limit int64) ([]model.AlertHistory, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.FindHistory(ctx, userID, limit)
+}
+
+func (s *AlertServiceImpl) Start() {
+	s.bus.Subscribe("alerts", 0, s.handle)
+}
+
+func (s *AlertServiceImpl) handle(e events.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+
+	switch e.Type {
+	case events.CandlesSynced, events.MitigationChecked:
+		s.evaluatePrices(ctx)
+	case events.ZoneDetected:
+		if zone, ok := e.Data.(model.ZoneEvent); ok {
+			s.evaluateZone(ctx, model.AlertNewZone, zone)
+		}
+	case events.ZoneMitigated:
+		if zone, ok := e.Data.(model.ZoneEvent); ok {
+			s.evaluateZone(ctx, model.AlertZoneMitigated, zone)
+		}
+	}
+}
+
+// evaluatePrices fires price alerts whose level the latest candle crossed: the previous close was on the
+// near side and the latest candle traded through the level.
+func (s *AlertServiceImpl) evaluatePrices(ctx context.Context) {
+	alerts, err := s.repo.FindActive(ctx, model.AlertPrice, "")
+	if err != nil {
+		log.Printf("Alerts: failed to load price alerts: %v", err)
+		return
+	}
+
+	bySymbol := make(map[string][]model.Alert)
+	for _, a := range alerts {
+		bySymbol[a.Symbol] = append(bySymbol[a.Symbol], a)
+	}
+
+	now := time.Now()
+	for symbol, symbolAlerts := range bySymbol {
+		candles, err := s.candleSvc.GetCandles(ctx, symbol, now.Add(-priceLookback), now)
+		if err != nil || len(candles) < 2 {
+			log.Printf("Alerts: not enough candles for %s: %v", symbol, err)
+			continue
+		}
+		prev, last := candles[len(candles)-2], candles[len(candles)-1]
+
+		for i := range symbolAlerts {
+			a := &symbolAlerts[i]
+			var crossed bool
+			var msg string
+			switch a.Condition {
+			case model.CrossAbove:
+				crossed = prev.Close < a.Level && last.High >= a.Level
+				msg = fmt.Sprintf("%s crossed above %.2f (high %.2f, last %.2f)", symbol, a.Level, last.High, last.Close)
+			case model.CrossBelow:
+				crossed = prev.Close > a.Level && last.Low <= a.Level
+				msg = fmt.Sprintf("%s crossed below %.2f (low %.2f, last %.2f)", symbol, a.Level, last.Low, last.Close)
+			}
+			if crossed {
+				s.fire(ctx, a, "price:"+util.DateKey(last.Time), msg)
+			}
+		}
+	}
+}
+
+func (s *AlertServiceImpl) evaluateZone(ctx context.Context, alertType model.AlertType, zone model.ZoneEvent) {
+	alerts, err := s.repo.FindActive(ctx, alertType, zone.Symbol)
+	if err != nil {
+		log.Printf("Alerts: failed to load %s alerts for %s: %v", alertType, zone.Symbol, err)
+		return
+	}
+
+	verb, prefix := "formed", "zone"
+	if alertType == model.AlertZoneMitigated {
+		verb, prefix = "was mitigated", "mitigated"
+	}
+	msg := fmt.Sprintf("%s %s %s %s dated %s (%.2f - %.2f) %s", zone.Symbol, zone.Timeframe, zone.Direction,
+		zone.Kind, zone.Date, zone.Low, zone.High, verb)
+	key := fmt.Sprintf("%s:%s:%s:%s:%s", prefix, zone.Kind, zone.Direction, zone.Timeframe, zone.Date)
+
+	for i := range alerts {
+		a := &alerts[i]
+		if a.Kind != "" && a.Kind != zone.Kind {
+			continue
+		}
+		if a.Direction != "" && a.Direction != zone.Direction {
+			continue
+		}
+		if a.Timeframe != "" && a.Timeframe != zone.Timeframe {
+			continue
+		}
+		s.fire(ctx, a, key, msg)
+	}
+}
+
+// fire delivers an alert unless it is cooling down or has already been delivered for the dedup key.
+func (s *AlertServiceImpl) fire(ctx context.Context, alert *model.Alert, dedupKey, message string) {
+	now := time.Now()
+	cooldown := time.Duration(alert.CooldownMinutes) * time.Minute
+	if alert.LastTriggeredAt != nil && now.Before(alert.LastTriggeredAt.Add(cooldown)) {
+		return
+	}
+
+	entry, err := s.claim(ctx, alert, dedupKey, message, now)
+	if err != nil {
+		log.Printf("Alerts: failed to record alert %s: %v", alert.ID.Hex(), err)
+		return
+	}
+	if entry == nil {
+		return
+	}
+
+	err = s.notificationSvc.Notify(ctx, alert.UserID, model.Notification{
+		Event:   model.NotifyAlerts,
+		Title:   fmt.Sprintf("Alert: %s", alert.Symbol),
+		Message: entry.Message,
+	})
+	if err != nil {
+		entry.Error = err.Error()
+		log.Printf("Alerts: delivery %d/%d of %s to user %d failed: %v",
+			entry.Attempts, maxAlertAttempts, alert.ID.Hex(), alert.UserID, err)
+	} else {
+		entry.Delivered = true
+		// Only a delivered firing starts the cooldown, otherwise it would hold back the retry
+		alert.LastTriggeredAt = &now
+		if err := s.repo.MarkTriggered(ctx, alert); err != nil {
+			log.Printf("Alerts: failed to mark alert %s: %v", alert.ID.Hex(), err)
+		}
+	}
+	if err := s.repo.UpdateHistory(ctx, entry); err != nil {
+		log.Printf("Alerts: failed to update history of %s: %v", alert.ID.Hex(), err)
+	}
+
+	// Retries are not announced again
+	if entry.Attempts == 1 {
+		s.bus.Publish(events.Event{Type: events.AlertTriggered, Symbol: alert.Symbol, Data: *entry})
+	}
+}
+
+// claim records the firing for the dedup key, or claims its retry if an earlier delivery failed. It returns nil if
+// the firing was already delivered or is being delivered.
+func (s *AlertServiceImpl) claim(ctx context.Context, alert *model.Alert, dedupKey, message string,
+	now time.Time) (*model.AlertHistory, error) {
+	entry := &model.AlertHistory{
+		AlertID:     alert.ID,
+		UserID:      alert.UserID,
+		Symbol:      alert.Symbol,
+		Type:        alert.Type,
+		Message:     message,
+		DedupKey:    dedupKey,
+		Attempts:    1,
+		TriggeredAt: now,
+	}
+	err := s.repo.InsertHistory(ctx, entry)
+	if errors.Is(err, repository.ErrDuplicateAlert) {
+		return s.repo.ClaimFailedHistory(ctx, alert.ID, dedupKey, maxAlertAttempts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"backend/detection"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+)
+
+const (
+	defaultBacktestCapital     = 100000
+	defaultBacktestPositionPct = 10
+	defaultRewardRisk          = 2
+	defaultMaxHoldCandles      = 20
+)
+
+// ErrIntradayBacktest is returned for 15m and 1h runs; intraday candles are not stored.
+var ErrIntradayBacktest = errors.New("intraday timeframes cannot be backtested")
+
+// BacktestService measures how trading stored order blocks and FVGs would have performed.
+type BacktestService interface {
+	// Run replays every matching zone dated between from and to against the candles that followed it.
+	Run(ctx context.Context, req model.BacktestRequest, from, to time.Time) (*model.BacktestResult, error)
+}
+
+type BacktestServiceImpl struct {
+	candleSvc       CandleService
+	priceActionRepo *repository.PriceActionRepo
+	marginSvc       MarginService
+}
+
+func NewBacktestService(candleSvc CandleService, repo *repository.PriceActionRepo, marginSvc MarginService) BacktestService {
+	return &BacktestServiceImpl{
+		candleSvc:       candleSvc,
+		priceActionRepo: repo,
+		marginSvc:       marginSvc,
+	}
+}
+
+// backtestZone is a stored zone together with its kind
+type backtestZone struct {
+	info model.Info
+	kind string
+}
+
+func (s *BacktestServiceImpl) Run(ctx context.Context, req model.BacktestRequest, from, to time.Time) (*model.BacktestResult, error) {
+	tf := req.Timeframe.OrDefault()
+	if tf.IsIntraday() {
+		return nil, ErrIntradayBacktest
+	}
+	req = backtestDefaults(req)
+
+	var records []model.StockRecord
+	var err error
+	if len(req.Symbols) > 0 {
+		records, err = s.priceActionRepo.GetAllPAIn(ctx, req.Symbols)
+	} else {
+		records, err = s.priceActionRepo.GetAllPriceAction(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Symbol < records[j].Symbol })
+
+	fromKey, toKey := util.CandleKey(from, tf), util.CandleKey(to, tf)
+	trades := make([]model.BacktestTrade, 0)
+	var skipped []string
+	for _, record := range records {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		zones := make([]backtestZone, 0)
+		if req.Kind == "" || req.Kind == detection.KindOrderBlock {
+			zones = appendZones(zones, record.OrderBlocks, detection.KindOrderBlock, req, tf, fromKey, toKey)
+		}
+		if req.Kind == "" || req.Kind == detection.KindFvg {
+			zones = appendZones(zones, record.Fvg, detection.KindFvg, req, tf, fromKey, toKey)
+		}
+		if len(zones) == 0 {
+			continue
+		}
+
+		// Exits may fall after the range, so candles run up to today
+		candles, err := s.candleSvc.GetTimeframeCandles(ctx, record.Symbol, tf, from, time.Now())
+		if err != nil {
+			log.Printf("Backtest: no %s candles for %s: %v", tf, record.Symbol, err)
+			skipped = append(skipped, record.Symbol)
+			continue
+		}
+
+		leverage := 1.0
+		if req.UseMargin {
+			if m, found := s.marginSvc.GetMargin(record.Symbol); found && m.Margin > 0 {
+				leverage = float64(m.Margin)
+			}
+		}
+
+		for _, zone := range zones {
+			if trade, ok := simulateTrade(record.Symbol, zone, candles, tf, req, leverage); ok {
+				trades = append(trades, trade)
+			}
+		}
+	}
+
+	result := summarise(trades, req.Capital)
+	result.Skipped = skipped
+	return result, nil
+}
+
+func backtestDefaults(req model.BacktestRequest) model.BacktestRequest {
+	if req.Capital <= 0 {
+		req.Capital = defaultBacktestCapital
+	}
+	if req.PositionPct <= 0 {
+		req.PositionPct = defaultBacktestPositionPct
+	}
+	if req.RewardRisk <= 0 {
+		req.RewardRisk = defaultRewardRisk
+	}
+	if req.MaxHoldCandles <= 0 {
+		req.MaxHoldCandles = defaultMaxHoldCandles
+	}
+	return req
+}
+
+func appendZones(zones []backtestZone, infos []model.Info, kind string, req model.BacktestRequest, tf model.Timeframe,
+	fromKey, toKey string) []backtestZone {
+	for _, info := range infos {
+		if info.Timeframe.OrDefault() != tf || info.Date < fromKey || info.Date > toKey {
+			continue
+		}
+		if req.Direction != "" && info.Direction.OrDefault() != req.Direction {
+			continue
+		}
+		zones = append(zones, backtestZone{info: info, kind: kind})
+	}
+	return zones
+}
+
+// simulateTrade walks the ascending candles after a zone. Like the live mitigation check, the candle that confirmed
+// the zone is ignored and the zone is dropped once broken. The first valid mitigation enters at its close; the
+// position then exits on the stop, the target or after MaxHoldCandles, checking the stop first when a candle
+// reaches both.
+func simulateTrade(symbol string, zone backtestZone, candles []model.Candle, tf model.Timeframe,
+	req model.BacktestRequest, leverage float64) (model.BacktestTrade, bool) {
+	info := zone.info
+	bearish := info.Direction.OrDefault() == model.DirectionBearish
+
+	entry := -1
+	skipConfirmation := true
+	for i, candle := range candles {
+		if util.CandleKey(candle.Time, tf) <= info.Date {
+			continue
+		}
+		if skipConfirmation {
+			skipConfirmation = false
+			continue
+		}
+		if isZoneBroken(candle, info) {
+			return model.BacktestTrade{}, false
+		}
+		if checkValidMitigation(candle, info) {
+			entry = i
+			break
+		}
+	}
+	if entry < 0 {
+		return model.BacktestTrade{}, false
+	}
+
+	trade := model.BacktestTrade{
+		Symbol:     symbol,
+		Kind:       zone.kind,
+		Direction:  info.Direction.OrDefault(),
+		ZoneDate:   info.Date,
+		EntryDate:  util.CandleKey(candles[entry].Time, tf),
+		EntryPrice: candles[entry].Close,
+		Leverage:   leverage,
+	}
+
+	// sign turns price moves into profit: +1 long from bullish zones, -1 short from bearish ones
+	sign := 1.0
+	trade.StopLoss = info.Low
+	if bearish {
+		sign = -1
+		trade.StopLoss = info.High
+	}
+	if req.StopLossPct > 0 {
+		trade.StopLoss = trade.EntryPrice * (1 - sign*req.StopLossPct/100)
+	}
+	risk := sign * (trade.EntryPrice - trade.StopLoss)
+	if risk <= 0 || trade.EntryPrice <= 0 {
+		return model.BacktestTrade{}, false
+	}
+	trade.Target = trade.EntryPrice + sign*req.RewardRisk*risk
+	if req.TargetPct > 0 {
+		trade.Target = trade.EntryPrice * (1 + sign*req.TargetPct/100)
+	}
+
+	trade.Quantity = int(req.Capital * req.PositionPct / 100 * leverage / trade.EntryPrice)
+	if trade.Quantity == 0 {
+		return model.BacktestTrade{}, false
+	}
+
+	exit := len(candles) - 1
+	trade.ExitReason = model.ExitOpen
+	trade.ExitPrice = candles[exit].Close
+	for i := entry + 1; i < len(candles); i++ {
+		c := candles[i]
+		if price, hit := stopHit(c, trade.StopLoss, bearish); hit {
+			exit, trade.ExitPrice, trade.ExitReason = i, price, model.ExitStopLoss
+			break
+		}
+		if price, hit := targetHit(c, trade.Target, bearish); hit {
+			exit, trade.ExitPrice, trade.ExitReason = i, price, model.ExitTarget
+			break
+		}
+		if i-entry >= req.MaxHoldCandles {
+			exit, trade.ExitPrice, trade.ExitReason = i, c.Close, model.ExitTime
+			break
+		}
+	}
+
+	trade.ExitDate = util.CandleKey(candles[exit].Time, tf)
+	move := sign * (trade.ExitPrice - trade.EntryPrice)
+	trade.PnL = round2(move * float64(trade.Quantity))
+	trade.RMultiple = round2(move / risk)
+	trade.StopLoss, trade.Target, trade.ExitPrice = round2(trade.StopLoss), round2(trade.Target), round2(trade.ExitPrice)
+	return trade, true
+}
+
+// stopHit returns the fill of a stop reached by the candle; a gap through the stop fills at the open.
+func stopHit(c model.Candle, stop float64, bearish bool) (float64, bool) {
+	if bearish {
+		if c.High < stop {
+			return 0, false
+		}
+		return math.Max(stop, c.Open), true
+	}
+	if c.Low > stop {
+		return 0, false
+	}
+	return math.Min(stop, c.Open), true
+}
+
+// targetHit returns the fill of a target reached by the candle; a gap through the target fills at the open.
+func targetHit(c model.Candle, target float64, bearish bool) (float64, bool) {
+	if bearish {
+		if c.Low > target {
+			return 0, false
+		}
+		return math.Min(target, c.Open), true
+	}
+	if c.High < target {
+		return 0, false
+	}
+	return math.Max(target, c.Open), true
+}
+
+// summarise computes the statistics and an equity curve of realised PnL by exit date. Trades still open at the end
+// of the range are listed but left out of the closed-trade statistics.
+func summarise(trades []model.BacktestTrade, capital float64) *model.BacktestResult {
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].ExitDate != trades[j].ExitDate {
+			return trades[i].ExitDate < trades[j].ExitDate
+		}
+		return trades[i].Symbol < trades[j].Symbol
+	})
+
+	result := &model.BacktestResult{
+		TotalTrades: len(trades),
+		EquityCurve: make([]model.EquityPoint, 0),
+		Trades:      trades,
+	}
+
+	equity, peak := capital, capital
+	var totalR float64
+	for _, t := range trades {
+		if t.ExitReason == model.ExitOpen {
+			result.OpenTrades++
+			result.OpenPnL += t.PnL
+			continue
+		}
+		switch {
+		case t.PnL > 0:
+			result.Wins++
+		case t.PnL < 0:
+			result.Losses++
+		default:
+			result.Breakevens++
+		}
+		result.TotalPnL += t.PnL
+		totalR += t.RMultiple
+
+		equity += t.PnL
+		if n := len(result.EquityCurve); n > 0 && result.EquityCurve[n-1].Date == t.ExitDate {
+			result.EquityCurve[n-1].Equity = round2(equity)
+		} else {
+			result.EquityCurve = append(result.EquityCurve, model.EquityPoint{Date: t.ExitDate, Equity: round2(equity)})
+		}
+
+		peak = math.Max(peak, equity)
+		if dd := peak - equity; dd > result.MaxDrawdown {
+			result.MaxDrawdown = dd
+			result.MaxDrawdownPct = dd / peak * 100
+		}
+	}
+
+	if closed := len(trades) - result.OpenTrades; closed > 0 {
+		n := float64(closed)
+		result.WinRate = round2(float64(result.Wins) / n * 100)
+		result.Expectancy = round2(result.TotalPnL / n)
+		result.AvgRMultiple = round2(totalR / n)
+	}
+	result.TotalPnL = round2(result.TotalPnL)
+	result.OpenPnL = round2(result.OpenPnL)
+	result.FinalEquity = round2(equity)
+	result.MaxDrawdown = round2(result.MaxDrawdown)
+	result.MaxDrawdownPct = round2(result.MaxDrawdownPct)
+	return result
+}
+
+func round2(n float64) float64 {
+	return math.Round(n*100) / 100
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"backend/cache"
+	"backend/config"
+	"backend/events"
+	"backend/model"
+	"backend/provider"
+	"backend/repository"
+	"backend/util"
+)
+
+const (
+	// defaultBackfillDays is how far back a symbol is loaded the first time it is synced
+	defaultBackfillDays = 365
+	// sessionOpen and sessionFinal are offsets from midnight IST. A daily candle is only persisted
+	// once the session is final; until then it is served live and refetched.
+	sessionOpen  = 9*time.Hour + 15*time.Minute
+	sessionFinal = 15*time.Hour + 45*time.Minute
+)
+
+// CandleService keeps a local store of daily candles in sync with the market data providers.
+type CandleService interface {
+	// GetCandles returns daily candles between from and to (inclusive), oldest first. Missing sessions are
+	// fetched from the providers first; if they are down, whatever is stored is returned.
+	GetCandles(ctx context.Context, symbol string, from, to time.Time) ([]model.Candle, error)
+	// GetTimeframeCandles returns tf candles between from and to, oldest first. Weekly and monthly candles are
+	// resampled from the store; intraday ones are fetched live at 15m and briefly cached.
+	GetTimeframeCandles(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time) ([]model.Candle, error)
+	// Sync brings every tracked symbol up to date.
+	Sync(ctx context.Context, tracker *JobTracker) error
+}
+
+type CandleServiceImpl struct {
+	repo            *repository.CandleRepository
+	chain           provider.MarketDataProvider
+	cfg             *config.ConfigManager
+	priceActionRepo *repository.PriceActionRepo
+	marginSvc       MarginService
+	bus             *events.Bus
+}
+
+func NewCandleService(repo *repository.CandleRepository, chain provider.MarketDataProvider, cfg *config.ConfigManager,
+	priceActionRepo *repository.PriceActionRepo, marginSvc MarginService, bus *events.Bus) CandleService {
+	return &CandleServiceImpl{
+		repo:            repo,
+		chain:           chain,
+		cfg:             cfg,
+		priceActionRepo: priceActionRepo,
+		marginSvc:       marginSvc,
+		bus:             bus,
+	}
+}
+
+func (s *CandleServiceImpl) GetCandles(ctx context.Context, symbol string, from, to time.Time) ([]model.Candle, error) {
+	from, to = util.StartOfDay(from), util.StartOfDay(to)
+
+	_, live, syncErr := s.sync(ctx, symbol, from)
+	if syncErr != nil {
+		log.Printf("Candle sync failed for %s, serving stored data: %v", symbol, syncErr)
+	}
+
+	candles, err := s.repo.FindRange(ctx, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Append the in-progress session, which is never persisted
+	for _, c := range live {
+		if c.Time.Before(from) || c.Time.After(to) {
+			continue
+		}
+		if len(candles) == 0 || c.Time.After(candles[len(candles)-1].Time) {
+			candles = append(candles, c)
+		}
+	}
+
+	if len(candles) == 0 && syncErr != nil {
+		return nil, syncErr
+	}
+	return candles, nil
+}
+
+func (s *CandleServiceImpl) GetTimeframeCandles(ctx context.Context, symbol string, tf model.Timeframe,
+	from, to time.Time) ([]model.Candle, error) {
+	switch tf {
+	case model.Timeframe1D:
+		return s.GetCandles(ctx, symbol, from, to)
+	case model.Timeframe1W, model.Timeframe1M:
+		daily, err := s.GetCandles(ctx, symbol, util.PeriodStart(from, tf), to)
+		if err != nil {
+			return nil, err
+		}
+		return util.Resample(daily, tf), nil
+	case model.Timeframe15m, model.Timeframe1h:
+		bars, err := s.intraday(ctx, symbol, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if tf == model.Timeframe1h {
+			bars = util.Resample(bars, tf)
+		}
+		return bars, nil
+	}
+	return nil, fmt.Errorf("unsupported timeframe %q", tf)
+}
+
+func (s *CandleServiceImpl) Sync(ctx context.Context, tracker *JobTracker) error {
+	symbols, err := s.trackedSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	tracker.SetTotal(len(symbols))
+
+	total := 0
+	for _, symbol := range symbols {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		stored, _, err := s.sync(ctx, symbol, time.Time{})
+		if err != nil {
+			tracker.Fail(symbol, err)
+			continue
+		}
+		if stored == 0 {
+			tracker.Skipped()
+			continue
+		}
+		tracker.Inserted()
+		total += stored
+	}
+
+	s.bus.Publish(events.Event{Type: events.CandlesSynced})
+	tracker.SetMessage(fmt.Sprintf("%d candles stored", total))
+	log.Printf("Candle sync stored %d candles for %d symbols", total, len(symbols))
+	return nil
+}
+
+// sync fetches the sessions missing from the store for a symbol: everything after the latest stored candle,
+// and anything requested before the earliest one. It returns the number of candles persisted and any live
+// (not yet final) candles.
+func (s *CandleServiceImpl) sync(ctx context.Context, symbol string, from time.Time) (int, []model.Candle, error) {
+	now := time.Now().In(util.MarketLocation())
+
+	latest, err := s.repo.FindLatest(ctx, symbol)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if latest == nil {
+		start := util.StartOfDay(now).AddDate(0, 0, -s.backfillDays())
+		if !from.IsZero() && from.Before(start) {
+			start = from
+		}
+		cache.CandleBackfillCache.Set(symbol, start, 0)
+		return s.fetchAndStore(ctx, symbol, start, now, now)
+	}
+
+	stored := 0
+	if !from.IsZero() {
+		n, err := s.backfill(ctx, symbol, from, now)
+		if err != nil {
+			return 0, nil, err
+		}
+		stored += n
+	}
+
+	if !latest.Time.Before(s.expectedSession(now)) {
+		return stored, nil, nil
+	}
+
+	n, live, err := s.fetchAndStore(ctx, symbol, latest.Time.AddDate(0, 0, 1), now, now)
+	return stored + n, live, err
+}
+
+// backfill loads history older than the earliest stored candle. Attempts are remembered so ranges before
+// a symbol's listing are not refetched on every call.
+func (s *CandleServiceImpl) backfill(ctx context.Context, symbol string, from, now time.Time) (int, error) {
+	if val, found := cache.CandleBackfillCache.Get(symbol); found && !from.Before(val.(time.Time)) {
+		return 0, nil
+	}
+
+	earliest, err := s.repo.FindEarliest(ctx, symbol)
+	if err != nil || earliest == nil {
+		return 0, err
+	}
+	if !from.Before(earliest.Time) {
+		return 0, nil
+	}
+
+	n, _, err := s.fetchAndStore(ctx, symbol, from, earliest.Time.AddDate(0, 0, -1), now)
+	if err == nil {
+		cache.CandleBackfillCache.Set(symbol, from, 0)
+	}
+	return n, err
+}
+
+func (s *CandleServiceImpl) fetchAndStore(ctx context.Context, symbol string, from, to, now time.Time) (int, []model.Candle, error) {
+	if from.After(to) {
+		return 0, nil, nil
+	}
+
+	candles, err := s.chain.GetCandles(ctx, symbol, model.Timeframe1D, from, to)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	final := make([]model.Candle, 0, len(candles))
+	var live []model.Candle
+	for _, c := range candles {
+		if now.Before(c.Time.Add(sessionFinal)) {
+			live = append(live, c)
+			continue
+		}
+		final = append(final, c)
+	}
+
+	if err := s.repo.UpsertMany(ctx, final); err != nil {
+		return 0, nil, err
+	}
+	return len(final), live, nil
+}
+
+// expectedSession returns the start of the most recent session that should have a candle by now.
+func (s *CandleServiceImpl) expectedSession(now time.Time) time.Time {
+	holidays := s.cfg.GetConfig().NseHolidays
+	day := util.StartOfDay(now)
+	if now.Before(day.Add(sessionOpen)) {
+		day = day.AddDate(0, 0, -1)
+	}
+	for i := 0; i < 10 && !util.IsTradingDay(day, holidays); i++ {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+func (s *CandleServiceImpl) backfillDays() int {
+	if days := s.cfg.GetConfig().MarketData.BackfillDays; days > 0 {
+		return days
+	}
+	return defaultBackfillDays
+}
+
+// trackedSymbols is every symbol we hold price action for plus the margin universe.
+func (s *CandleServiceImpl) trackedSymbols(ctx context.Context) ([]string, error) {
+	records, err := s.priceActionRepo.GetAllPriceAction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range records {
+		seen[r.Symbol] = true
+	}
+	for _, m := range s.marginSvc.GetAllMargins() {
+		seen[m.Symbol] = true
+	}
+
+	symbols := make([]string, 0, len(seen))
+	for symbol := range seen {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
+
+// intraday fetches 15m candles. They are not stored; a short cache absorbs repeated mitigation checks.
+func (s *CandleServiceImpl) intraday(ctx context.Context, symbol string, from, to time.Time) ([]model.Candle, error) {
+	cacheKey := fmt.Sprintf("%s_%s_%s", symbol, util.DateKey(from), util.DateKey(to))
+	if val, found := cache.IntradayCache.Get(cacheKey); found {
+		return val.([]model.Candle), nil
+	}
+
+	bars, err := s.chain.GetCandles(ctx, symbol, model.Timeframe15m, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.IntradayCache.Set(cacheKey, bars, 0)
+	return bars, nil
+}
+
+// newestFirst returns a reversed copy of ascending candles.
+func newestFirst(candles []model.Candle) []model.Candle {
+	out := make([]model.Candle, 0, len(candles))
+	for i := len(candles) - 1; i >= 0; i-- {
+		out = append(out, candles[i])
+	}
+	return out
+}
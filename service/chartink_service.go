@@ -3,84 +3,200 @@ package service
 import (
 	localCache "backend/cache"
 	"backend/client"
+	"backend/events"
 	"backend/model"
+	"backend/repository"
+	"backend/util"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/url"
+	"log"
+	"net/http"
+	"slices"
 	"sort"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/patrickmn/go-cache"
+	"golang.org/x/sync/singleflight"
 )
 
+const (
+	// previewSample is the number of matches a dry run returns
+	previewSample = 10
+	// scanFreshFor is how long a scan result is served without refreshing it
+	scanFreshFor = time.Minute
+	// scanStaleFor is how long a result may be served while a background refresh runs; older ones are refetched
+	scanStaleFor = 30 * time.Minute
+	// maxScanPageSize bounds a page of a margin scan
+	maxScanPageSize = 500
+)
+
+var ErrInvalidScanQuery = errors.New("invalid scan query")
+
 type ChartInkService interface {
 	FetchData(strategy model.StrategyDto) (*model.ChartInkResponseDto, error)
+	// FetchWithMargin returns a scan result under a minute old, fetching it if there is none.
 	FetchWithMargin(strategy model.StrategyDto) ([]model.StockMarginDto, error)
+	// FetchMarginScan is FetchWithMargin with stale-while-revalidate: an older result is served right away, marked
+	// stale, while it is refreshed in the background. The stocks are filtered, sorted and paged by query.
+	FetchMarginScan(strategy model.StrategyDto, query model.MarginScanQuery) (*model.MarginScan, error)
+	// Health reports the ChartInk session and the upstream's circuit breaker.
+	Health() model.ChartInkHealth
+	// Preview dry runs a scan clause. A clause ChartInk rejects gives an invalid preview; an error means the
+	// clause could not be checked.
+	Preview(ctx context.Context, scanClause string) (*model.ScanPreview, error)
 }
 
 type ChartInkServiceImpl struct {
-	client        *client.ChartinkClient
+	session       *chartInkSession
 	marginService MarginService
-	xsrfToken     string
-	userAgent     string
-	mu            sync.RWMutex
+	bus           *events.Bus
+	scanRepo      *repository.ScanRepository
+	// refreshes collapses concurrent fetches of the same strategy
+	refreshes singleflight.Group
 }
 
-func NewChartInkService(c *client.ChartinkClient, ms MarginService) ChartInkService {
+func NewChartInkService(c *client.ChartinkClient, ms MarginService, bus *events.Bus,
+	scanRepo *repository.ScanRepository) ChartInkService {
 	return &ChartInkServiceImpl{
-		client:        c,
+		session:       newChartInkSession(c),
 		marginService: ms,
-		userAgent:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		bus:           bus,
+		scanRepo:      scanRepo,
 	}
 }
 
-// FetchData handles CSRF token management and scanner data retrieval.
+// FetchData runs a strategy's scan through the ChartInk session. Every result is kept as a scan snapshot, and while
+// ChartInk is unavailable the latest snapshot is served instead.
 func (s *ChartInkServiceImpl) FetchData(strategy model.StrategyDto) (*model.ChartInkResponseDto, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
 
-	// 1. Execute request with current token
-	resp, err := s.executeWithRetry(ctx, strategy.ScanClause)
+	// 1. Execute request through the session
+	resp, err := s.session.Scan(ctx, strategy.ScanClause)
 	if err != nil {
+		if stale := s.lastGood(strategy.Name, err); stale != nil {
+			return stale, nil
+		}
 		return nil, err
 	}
 
 	// 2. Unmarshal and Cache
-	var dto model.ChartInkResponseDto
-	if err := json.Unmarshal(resp.Body(), &dto); err != nil {
-		return nil, fmt.Errorf("failed to parse chartink json: %w", err)
+	dto, err := parseScan(resp)
+	if err != nil {
+		return nil, err
 	}
 
-	localCache.ChartInkResponseCache.Set(strategy.Name, &dto, cache.DefaultExpiration)
-	return &dto, nil
+	dto.FetchedAt = time.Now()
+	localCache.ChartInkResponseCache.Set(strategy.Name, dto, scanStaleFor)
+	s.saveSnapshot(ctx, strategy.Name, dto)
+	s.publishScan(strategy, dto)
+	return dto, nil
 }
 
 // FetchWithMargin merges scanner results with local stock margin data.
 func (s *ChartInkServiceImpl) FetchWithMargin(strategy model.StrategyDto) ([]model.StockMarginDto, error) {
 	// 1. Cache-first strategy for scanner response
-	var response *model.ChartInkResponseDto
+	response, err := s.cachedScan(strategy, false)
+	if err != nil {
+		return nil, err
+	}
+	return s.withMargin(response), nil
+}
+
+func (s *ChartInkServiceImpl) FetchMarginScan(strategy model.StrategyDto, query model.MarginScanQuery) (*model.MarginScan, error) {
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Size < 0 || query.Size > maxScanPageSize {
+		return nil, fmt.Errorf("%w: size must be between 0 and %d", ErrInvalidScanQuery, maxScanPageSize)
+	}
+	if query.Sort == "" {
+		query.Sort = "margin"
+	}
+
+	response, err := s.cachedScan(strategy, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stocks := slices.DeleteFunc(s.withMargin(response), func(stock model.StockMarginDto) bool {
+		return float64(stock.Close) < query.MinPrice || stock.Volume < query.MinVolume ||
+			float64(stock.Margin) < query.MinMargin
+	})
+	if err := sortMarginStocks(stocks, query.Sort, query.Ascending); err != nil {
+		return nil, err
+	}
+
+	scan := &model.MarginScan{
+		Strategy:   strategy.Name,
+		FetchedAt:  response.FetchedAt,
+		AgeSeconds: int64(time.Since(response.FetchedAt).Seconds()),
+		Stale:      response.Stale,
+		Total:      len(stocks),
+		Page:       query.Page,
+		Size:       query.Size,
+		Stocks:     stocks,
+	}
+	if query.Size > 0 {
+		start := min((query.Page-1)*query.Size, len(stocks))
+		scan.Stocks = stocks[start:min(start+query.Size, len(stocks))]
+	}
+	return scan, nil
+}
+
+// cachedScan returns a strategy's cached result while it is fresh. Past that, with allowStale a result younger than
+// scanStaleFor is served marked stale and refreshed in the background; otherwise it is fetched now.
+func (s *ChartInkServiceImpl) cachedScan(strategy model.StrategyDto, allowStale bool) (*model.ChartInkResponseDto, error) {
 	if val, ok := localCache.ChartInkResponseCache.Get(strategy.Name); ok {
-		response = val.(*model.ChartInkResponseDto)
-	} else {
-		var err error
-		response, err = s.FetchData(strategy)
-		if err != nil {
-			return nil, err
+		cached := val.(*model.ChartInkResponseDto)
+		if time.Since(cached.FetchedAt) < scanFreshFor {
+			return cached, nil
+		}
+		if allowStale {
+			go s.revalidate(strategy)
+			stale := *cached
+			stale.Stale = true
+			return &stale, nil
 		}
 	}
+	return s.fetchShared(strategy)
+}
 
+// fetchShared runs FetchData, sharing one ChartInk call between concurrent callers for the same strategy.
+func (s *ChartInkServiceImpl) fetchShared(strategy model.StrategyDto) (*model.ChartInkResponseDto, error) {
+	v, err, _ := s.refreshes.Do(strategy.Name, func() (any, error) {
+		return s.FetchData(strategy)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*model.ChartInkResponseDto), nil
+}
+
+func (s *ChartInkServiceImpl) revalidate(strategy model.StrategyDto) {
+	if _, err := s.fetchShared(strategy); err != nil {
+		log.Printf("ChartInk: background refresh of %s failed: %v", strategy.Name, err)
+	}
+}
+
+// withMargin joins a scan result with the margin cache, highest margin first.
+func (s *ChartInkServiceImpl) withMargin(response *model.ChartInkResponseDto) []model.StockMarginDto {
 	// 2. Join with Margin Cache
 	result := make([]model.StockMarginDto, 0)
 	for _, stock := range response.Data {
 		if m, exists := s.marginService.GetMargin(stock.NSECode); exists {
 			result = append(result, model.StockMarginDto{
-				Name:   stock.Name,
-				Symbol: stock.NSECode,
-				Margin: m.Margin,
-				Close:  stock.Close,
+				Name:    stock.Name,
+				Symbol:  stock.NSECode,
+				Margin:  m.Margin,
+				Close:   stock.Close,
+				PChange: stock.PChange,
+				Volume:  stock.Volume,
+				Columns: stock.Columns,
 			})
 		}
 	}
@@ -90,64 +206,205 @@ func (s *ChartInkServiceImpl) FetchWithMargin(strategy model.StrategyDto) ([]mod
 		return result[i].Margin > result[j].Margin
 	})
 
-	return result, nil
+	return result
 }
 
-// --- Internal Helpers ---
-
-func (s *ChartInkServiceImpl) executeWithRetry(ctx context.Context, scanClause string) (*resty.Response, error) {
-	payload := map[string]string{"scan_clause": scanClause}
+func (s *ChartInkServiceImpl) Preview(ctx context.Context, scanClause string) (*model.ScanPreview, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
 
-	// Try first with existing token
-	token := s.getStoredToken()
-	if token == "" {
-		if err := s.refreshTokens(ctx); err != nil {
-			return nil, err
+	resp, err := s.session.Scan(ctx, scanClause)
+	if err == nil {
+		var dto *model.ChartInkResponseDto
+		if dto, err = parseScan(resp); err == nil {
+			return &model.ScanPreview{
+				Valid:  true,
+				Count:  len(dto.Data),
+				Sample: dto.Data[:min(len(dto.Data), previewSample)],
+			}, nil
 		}
-		token = s.getStoredToken()
 	}
 
-	resp, err := s.client.FetchData(ctx, token, s.userAgent, payload)
+	var ciErr *model.ChartInkError
+	if errors.As(err, &ciErr) && ciErr.Rejected() {
+		return &model.ScanPreview{Valid: false, Sample: []model.StockData{}, Error: ciErr}, nil
+	}
+	return nil, err
+}
+
+func (s *ChartInkServiceImpl) Health() model.ChartInkHealth {
+	return s.session.Health()
+}
+
+// --- Internal Helpers ---
+
+// sortMarginStocks sorts by a field or scan column, ties broken by margin. Stocks without the column go last.
+func sortMarginStocks(stocks []model.StockMarginDto, key string, ascending bool) error {
+	known := slices.Contains([]string{"margin", "close", "volume", "pChange", "name", "symbol"}, key)
+	hasColumn := func(stock model.StockMarginDto) bool {
+		_, ok := stock.Columns[key]
+		return ok
+	}
+	if !known && len(stocks) > 0 && !slices.ContainsFunc(stocks, hasColumn) {
+		return fmt.Errorf("%w: unknown sort column %q", ErrInvalidScanQuery, key)
+	}
 
-	// Retry once on 419 (CSRF Mismatch) or error
-	if err != nil || (resp != nil && resp.StatusCode() == 419) {
-		if err := s.refreshTokens(ctx); err != nil {
-			return nil, err
+	slices.SortStableFunc(stocks, func(a, b model.StockMarginDto) int {
+		av, aok := marginStockValue(a, key)
+		bv, bok := marginStockValue(b, key)
+		if aok != bok {
+			if aok {
+				return -1
+			}
+			return 1
 		}
-		resp, err = s.client.FetchData(ctx, s.getStoredToken(), s.userAgent, payload)
+		c := compareColumns(av, bv)
+		if !ascending {
+			c = -c
+		}
+		if c == 0 {
+			c = cmp.Compare(b.Margin, a.Margin)
+		}
+		return c
+	})
+	return nil
+}
+
+func marginStockValue(stock model.StockMarginDto, key string) (any, bool) {
+	switch key {
+	case "margin":
+		return float64(stock.Margin), true
+	case "close":
+		return float64(stock.Close), true
+	case "volume":
+		return stock.Volume, true
+	case "pChange":
+		return stock.PChange, true
+	case "name":
+		return stock.Name, true
+	case "symbol":
+		return stock.Symbol, true
 	}
+	v, ok := stock.Columns[key]
+	return v, ok && v != nil
+}
 
-	if err != nil {
-		return nil, err
+// compareColumns orders numbers before text, numerically or lexically within each.
+func compareColumns(a, b any) int {
+	af, aNum := a.(float64)
+	bf, bNum := b.(float64)
+	switch {
+	case aNum && bNum:
+		return cmp.Compare(af, bf)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// lastGood returns a strategy's latest snapshot as a scan result when err means ChartInk is unavailable, rather
+// than that it rejected the clause, or nil if there is nothing to serve.
+func (s *ChartInkServiceImpl) lastGood(strategyName string, err error) *model.ChartInkResponseDto {
+	var ciErr *model.ChartInkError
+	if (errors.As(err, &ciErr) && ciErr.Rejected()) || errors.Is(err, context.Canceled) {
+		return nil
 	}
-	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("chartink api error: %d", resp.StatusCode())
+
+	// The scan's own context may be what ran out
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	snapshot, findErr := s.scanRepo.FindLatest(ctx, strategyName, time.Time{})
+	if findErr != nil || snapshot == nil {
+		return nil
 	}
 
-	return resp, nil
+	s.session.ServedStale()
+	log.Printf("ChartInk: serving %s from its %s snapshot: %v", strategyName,
+		snapshot.LastSeenAt.Format(time.RFC3339), err)
+	return &model.ChartInkResponseDto{Data: snapshot.Stocks, FetchedAt: snapshot.LastSeenAt, Stale: true}
 }
 
-func (s *ChartInkServiceImpl) getStoredToken() string {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.xsrfToken
+// parseScan decodes a scan response, turning a scan_error into a *model.ChartInkError.
+func parseScan(resp *resty.Response) (*model.ChartInkResponseDto, error) {
+	var dto model.ChartInkResponseDto
+	if err := json.Unmarshal(resp.Body(), &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse chartink json: %w", err)
+	}
+	if dto.ScanError != "" {
+		return nil, &model.ChartInkError{StatusCode: resp.StatusCode(), Message: dto.ScanError}
+	}
+	if dto.Data == nil {
+		dto.Data = []model.StockData{}
+	}
+	return &dto, nil
 }
 
-func (s *ChartInkServiceImpl) refreshTokens(ctx context.Context) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// chartInkError describes a failed response, with ChartInk's own message when the body carries one.
+func chartInkError(resp *resty.Response) *model.ChartInkError {
+	var body struct {
+		ScanError string `json:"scan_error"`
+		Message   string `json:"message"`
+	}
+	_ = json.Unmarshal(resp.Body(), &body)
 
-	resp, err := s.client.GetHomepage(ctx)
+	message := strings.TrimSpace(body.ScanError)
+	if message == "" {
+		message = strings.TrimSpace(body.Message)
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode())
+	}
+	return &model.ChartInkError{StatusCode: resp.StatusCode(), Message: message}
+}
+
+// saveSnapshot stores a scan result, or only bumps the latest snapshot when the symbols have not changed since it
+// was taken earlier the same day. Every trading day a strategy is scanned thus has its own snapshot, which the
+// default diff compares. A failure is logged; the scan result is still served.
+func (s *ChartInkServiceImpl) saveSnapshot(ctx context.Context, strategyName string, dto *model.ChartInkResponseDto) {
+	now := time.Now()
+	symbols := make([]string, 0, len(dto.Data))
+	for _, stock := range dto.Data {
+		symbols = append(symbols, stock.NSECode)
+	}
+	slices.Sort(symbols)
+	symbols = slices.Compact(symbols)
+
+	latest, err := s.scanRepo.FindLatest(ctx, strategyName, time.Time{})
+	if err == nil && latest != nil && slices.Equal(latest.Symbols, symbols) &&
+		util.DateKey(latest.TakenAt) == util.DateKey(now) {
+		err = s.scanRepo.Touch(ctx, latest.ID, now)
+	} else if err == nil {
+		err = s.scanRepo.Insert(ctx, &model.ScanSnapshot{
+			Strategy:   strategyName,
+			TakenAt:    now,
+			LastSeenAt: now,
+			Runs:       1,
+			Count:      len(symbols),
+			Symbols:    symbols,
+			Stocks:     dto.Data,
+		})
+	}
 	if err != nil {
-		return err
+		log.Printf("ChartInk: failed to save snapshot of %s: %v", strategyName, err)
 	}
+}
 
-	for _, c := range resp.Cookies() {
-		if c.Name == "XSRF-TOKEN" {
-			decoded, _ := url.QueryUnescape(c.Value)
-			s.xsrfToken = decoded
-			return nil
-		}
+func (s *ChartInkServiceImpl) publishScan(strategy model.StrategyDto, dto *model.ChartInkResponseDto) {
+	symbols := make([]string, 0, len(dto.Data))
+	for _, stock := range dto.Data {
+		symbols = append(symbols, stock.NSECode)
 	}
-	return fmt.Errorf("XSRF-TOKEN not found in cookies")
+	s.bus.Publish(events.Event{
+		Type: events.ScanCompleted,
+		Data: model.ScanEvent{
+			Strategy:   strategy.Name,
+			Count:      len(symbols),
+			Symbols:    symbols,
+			Owner:      strategy.Owner,
+			Visibility: strategy.Visibility,
+			SharedWith: strategy.SharedWith,
+		},
+	})
 }
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"backend/client"
+	"backend/model"
+	"backend/util"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	chartInkUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	// chartInkAttempts bounds the tries of one scan, counting retries after 419, 429 and 5xx
+	chartInkAttempts         = 4
+	chartInkBackoff          = 500 * time.Millisecond
+	chartInkMaxBackoff       = 8 * time.Second
+	chartInkFailureThreshold = 5
+	chartInkCooldown         = 2 * time.Minute
+)
+
+// ErrChartInkUnavailable is returned without calling ChartInk while its circuit is open.
+var ErrChartInkUnavailable = errors.New("chartink unavailable: circuit open")
+
+// chartInkSession owns the XSRF token ChartInk's screener requires and guards the upstream: concurrent token
+// refreshes share one homepage request, rate limits and server errors are retried with exponential backoff, and
+// repeated failures open a circuit breaker that fails fast until a cooldown passes.
+type chartInkSession struct {
+	client    *client.ChartinkClient
+	userAgent string
+
+	mu               sync.RWMutex
+	xsrfToken        string
+	tokenRefreshedAt time.Time
+	refreshes        singleflight.Group
+
+	breaker util.CircuitBreaker
+
+	requests       atomic.Int64
+	failures       atomic.Int64
+	retries        atomic.Int64
+	rateLimited    atomic.Int64
+	tokenRefreshes atomic.Int64
+	servedStale    atomic.Int64
+	lastLatency    atomic.Int64
+}
+
+func newChartInkSession(c *client.ChartinkClient) *chartInkSession {
+	return &chartInkSession{client: c, userAgent: chartInkUserAgent}
+}
+
+// Scan runs a scan clause on ChartInk. A clause ChartInk rejects is returned as a *model.ChartInkError and does not
+// count against the circuit.
+func (s *chartInkSession) Scan(ctx context.Context, scanClause string) (*resty.Response, error) {
+	if !s.breaker.Allow(chartInkFailureThreshold, chartInkCooldown) {
+		return nil, ErrChartInkUnavailable
+	}
+	s.requests.Add(1)
+	start := time.Now()
+	defer func() { s.lastLatency.Store(time.Since(start).Milliseconds()) }()
+
+	payload := map[string]string{"scan_clause": scanClause}
+	var lastErr error
+	for attempt := 0; attempt < chartInkAttempts; attempt++ {
+		if attempt > 0 {
+			s.retries.Add(1)
+		}
+
+		token, err := s.token(ctx)
+		if err != nil {
+			lastErr = err
+			if !s.wait(ctx, attempt, nil) {
+				break
+			}
+			continue
+		}
+
+		resp, err := s.client.FetchData(ctx, token, s.userAgent, payload)
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode() == 419:
+			// CSRF mismatch: the session expired, retry straight away with a fresh token
+			lastErr = chartInkError(resp)
+			if err := s.refresh(ctx, token); err != nil {
+				lastErr = err
+			}
+			continue
+		case resp.StatusCode() == http.StatusTooManyRequests:
+			s.rateLimited.Add(1)
+			lastErr = chartInkError(resp)
+		case resp.StatusCode() >= http.StatusInternalServerError:
+			lastErr = chartInkError(resp)
+		case !resp.IsSuccess():
+			// The upstream answered; the request itself was refused
+			s.breaker.Success()
+			return nil, chartInkError(resp)
+		default:
+			s.breaker.Success()
+			return resp, nil
+		}
+
+		if !s.wait(ctx, attempt, resp) {
+			break
+		}
+	}
+
+	// The caller went away; a deadline though means ChartInk was too slow
+	if errors.Is(ctx.Err(), context.Canceled) {
+		s.breaker.Release()
+		return nil, ctx.Err()
+	}
+	if ctx.Err() != nil {
+		lastErr = fmt.Errorf("%w: %v", ctx.Err(), lastErr)
+	}
+	s.failures.Add(1)
+	s.breaker.Failure(lastErr, chartInkFailureThreshold)
+	log.Printf("ChartInk: scan failed after retries: %v", lastErr)
+	return nil, lastErr
+}
+
+// ServedStale records a scan answered from its last good result.
+func (s *chartInkSession) ServedStale() {
+	s.servedStale.Add(1)
+}
+
+func (s *chartInkSession) Health() model.ChartInkHealth {
+	snap := s.breaker.Snapshot(chartInkFailureThreshold, chartInkCooldown)
+	health := model.ChartInkHealth{
+		Circuit:             snap.State,
+		ConsecutiveFailures: snap.Failures,
+		LastError:           snap.LastError,
+		Requests:            s.requests.Load(),
+		Failures:            s.failures.Load(),
+		Retries:             s.retries.Load(),
+		RateLimited:         s.rateLimited.Load(),
+		TokenRefreshes:      s.tokenRefreshes.Load(),
+		ServedStale:         s.servedStale.Load(),
+		LastLatencyMs:       s.lastLatency.Load(),
+	}
+	if !snap.LastFailure.IsZero() {
+		health.LastFailure = &snap.LastFailure
+	}
+	if !snap.LastSuccess.IsZero() {
+		health.LastSuccess = &snap.LastSuccess
+	}
+	if snap.State != util.CircuitClosed {
+		health.OpenedAt = &snap.OpenedAt
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	health.HasToken = s.xsrfToken != ""
+	if !s.tokenRefreshedAt.IsZero() {
+		refreshedAt := s.tokenRefreshedAt
+		health.TokenRefreshedAt = &refreshedAt
+	}
+	return health
+}
+
+// token returns the current XSRF token, fetching one first if the session has none.
+func (s *chartInkSession) token(ctx context.Context) (string, error) {
+	s.mu.RLock()
+	token := s.xsrfToken
+	s.mu.RUnlock()
+	if token != "" {
+		return token, nil
+	}
+
+	if err := s.refresh(ctx, ""); err != nil {
+		return "", err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.xsrfToken, nil
+}
+
+// refresh replaces the token a request was rejected with. Concurrent callers share one homepage request, and a
+// caller whose stale token was already replaced does not refresh again.
+func (s *chartInkSession) refresh(ctx context.Context, stale string) error {
+	_, err, _ := s.refreshes.Do("token", func() (any, error) {
+		s.mu.RLock()
+		current := s.xsrfToken
+		s.mu.RUnlock()
+		if current != "" && current != stale {
+			return nil, nil
+		}
+
+		token, err := s.fetchToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.tokenRefreshes.Add(1)
+		s.mu.Lock()
+		s.xsrfToken = token
+		s.tokenRefreshedAt = time.Now()
+		s.mu.Unlock()
+		return nil, nil
+	})
+	return err
+}
+
+// fetchToken reads a fresh XSRF token from the homepage cookies.
+func (s *chartInkSession) fetchToken(ctx context.Context) (string, error) {
+	resp, err := s.client.GetHomepage(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range resp.Cookies() {
+		if c.Name == "XSRF-TOKEN" {
+			decoded, _ := url.QueryUnescape(c.Value)
+			return decoded, nil
+		}
+	}
+	return "", fmt.Errorf("XSRF-TOKEN not found in cookies")
+}
+
+// wait sleeps before the next attempt, doubling the backoff each time and honouring a Retry-After header. It
+// reports false when there is no attempt left or the context ended.
+func (s *chartInkSession) wait(ctx context.Context, attempt int, resp *resty.Response) bool {
+	if attempt+1 >= chartInkAttempts {
+		return false
+	}
+
+	delay := min(chartInkBackoff<<attempt, chartInkMaxBackoff)
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header().Get("Retry-After")); err == nil && seconds > 0 {
+			delay = min(time.Duration(seconds)*time.Second, chartInkMaxBackoff)
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
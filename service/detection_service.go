@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"backend/config"
+	"backend/detection"
+	"backend/events"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+)
+
+// detectionWindow is how much history the scheduled scan loads per timeframe. It must cover the
+// volume lookback plus the three candles of a pattern.
+var detectionWindow = map[model.Timeframe]time.Duration{
+	model.Timeframe15m: 7 * 24 * time.Hour,
+	model.Timeframe1h:  20 * 24 * time.Hour,
+	model.Timeframe1D:  60 * 24 * time.Hour,
+	model.Timeframe1W:  200 * 24 * time.Hour,
+	model.Timeframe1M:  800 * 24 * time.Hour,
+}
+
+// DetectionService finds order blocks and FVGs in stored candles and saves them as price action zones.
+type DetectionService interface {
+	// Detect runs the engine over one symbol and range, saving the zones when save is set.
+	Detect(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time, save bool) ([]detection.Zone, error)
+	// Scan saves the zones confirmed by the latest completed candle for every symbol with margin.
+	Scan(ctx context.Context, tracker *JobTracker) error
+}
+
+type DetectionServiceImpl struct {
+	candleSvc       CandleService
+	priceActionRepo *repository.PriceActionRepo
+	marginSvc       MarginService
+	cfg             *config.ConfigManager
+	bus             *events.Bus
+}
+
+func NewDetectionService(candleSvc CandleService, repo *repository.PriceActionRepo, marginSvc MarginService,
+	cfg *config.ConfigManager, bus *events.Bus) DetectionService {
+	return &DetectionServiceImpl{
+		candleSvc:       candleSvc,
+		priceActionRepo: repo,
+		marginSvc:       marginSvc,
+		cfg:             cfg,
+		bus:             bus,
+	}
+}
+
+func (s *DetectionServiceImpl) Detect(ctx context.Context, symbol string, tf model.Timeframe, from, to time.Time,
+	save bool) ([]detection.Zone, error) {
+	candles, err := s.candleSvc.GetTimeframeCandles(ctx, symbol, tf, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := s.detect(completed(candles, tf, time.Now()))
+	if save {
+		for _, zone := range zones {
+			if _, err := s.save(ctx, symbol, tf, zone); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return zones, nil
+}
+
+func (s *DetectionServiceImpl) Scan(ctx context.Context, tracker *JobTracker) error {
+	timeframes := s.cfg.GetConfig().Detection.Timeframes
+	if len(timeframes) == 0 {
+		timeframes = []model.Timeframe{model.Timeframe1D}
+	}
+
+	margins := s.marginSvc.GetAllMargins()
+	sort.Slice(margins, func(i, j int) bool { return margins[i].Symbol < margins[j].Symbol })
+	tracker.SetTotal(len(margins))
+
+	now := time.Now()
+	holidays := s.cfg.GetConfig().NseHolidays
+	count, stale, fresh := 0, 0, 0
+	for _, m := range margins {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		found := 0
+		var symbolErr error
+		for _, tf := range timeframes {
+			candles, err := s.candleSvc.GetTimeframeCandles(ctx, m.Symbol, tf, now.Add(-detectionWindow[tf]), now)
+			if err != nil {
+				symbolErr = err
+				continue
+			}
+			candles = completed(candles, tf, now)
+			if len(candles) < 3 {
+				continue
+			}
+			// A suspended or illiquid symbol has no candle today; skip its daily zones rather than the whole scan
+			if tf == model.Timeframe1D {
+				if isStaleSession(candles[len(candles)-1], now, holidays) {
+					stale++
+					symbolErr = ErrCandleNotUpdated
+					continue
+				}
+				fresh++
+			}
+
+			// Only zones completed by the newest candle are new; older ones were saved by earlier scans
+			latest := candles[len(candles)-1].Time
+			for _, zone := range s.detect(candles) {
+				if !zone.ConfirmedAt.Equal(latest) {
+					continue
+				}
+				saved, err := s.save(ctx, m.Symbol, tf, zone)
+				if err != nil {
+					symbolErr = err
+					continue
+				}
+				if saved {
+					found++
+				}
+			}
+		}
+
+		switch {
+		case symbolErr != nil:
+			tracker.Fail(m.Symbol, symbolErr)
+		case found > 0:
+			tracker.Inserted()
+			count += found
+		default:
+			tracker.Processed()
+		}
+	}
+
+	tracker.SetMessage(fmt.Sprintf("%d zones detected", count))
+	log.Printf("Detection saved %d zones", count)
+	// No symbol had today's candle: the sync has not run yet, so let the scheduler retry
+	if stale > 0 && fresh == 0 {
+		return ErrCandleNotUpdated
+	}
+	return nil
+}
+
+func (s *DetectionServiceImpl) detect(candles []model.Candle) []detection.Zone {
+	rules := detection.RulesFromConfig(s.cfg.GetConfig().Detection)
+	zones := detection.DetectOrderBlocks(candles, rules)
+	return append(zones, detection.DetectFvgs(candles, rules)...)
+}
+
+// save stores and announces a detected zone, reporting false for one already stored. Weekly and monthly zones are
+// detected again by every scan until their next candle completes; re-saving them would reset their lifecycle.
+func (s *DetectionServiceImpl) save(ctx context.Context, symbol string, tf model.Timeframe, zone detection.Zone) (bool, error) {
+	req := model.ObRequest{
+		Symbol:    symbol,
+		Date:      util.CandleKey(zone.Time, tf),
+		High:      zone.High,
+		Low:       zone.Low,
+		Timeframe: tf,
+		Direction: zone.Direction,
+	}
+	has, save := s.priceActionRepo.HasFvg, s.priceActionRepo.SaveFvg
+	if zone.Kind == detection.KindOrderBlock {
+		has, save = s.priceActionRepo.HasOrderBlock, s.priceActionRepo.SaveOrderBlock
+	}
+	if exists, err := has(ctx, symbol, req.Date, tf, zone.Direction); err != nil || exists {
+		return false, err
+	}
+	if err := save(ctx, req); err != nil {
+		return false, err
+	}
+
+	s.bus.Publish(events.Event{Type: events.ZoneDetected, Symbol: symbol, Data: model.ZoneEvent{
+		Symbol: symbol, Kind: zone.Kind, Direction: zone.Direction, Timeframe: tf,
+		Date: req.Date, High: zone.High, Low: zone.Low,
+	}})
+	return true, nil
+}
+
+// completed drops a trailing candle whose period has not finished yet, so zones are never
+// confirmed by a candle that can still change.
+func completed(candles []model.Candle, tf model.Timeframe, now time.Time) []model.Candle {
+	if len(candles) == 0 {
+		return candles
+	}
+
+	last := candles[len(candles)-1].Time
+	var end time.Time
+	switch tf {
+	case model.Timeframe15m:
+		end = last.Add(15 * time.Minute)
+	case model.Timeframe1h:
+		end = last.Add(time.Hour)
+	case model.Timeframe1D:
+		end = last.Add(sessionFinal)
+	case model.Timeframe1W:
+		end = last.AddDate(0, 0, 7)
+	case model.Timeframe1M:
+		end = last.AddDate(0, 1, 0)
+	}
+
+	if now.Before(end) {
+		return candles[:len(candles)-1]
+	}
+	return candles
+}
+
+// isStaleSession reports whether the newest daily candle predates today's session on a trading day.
+func isStaleSession(candle model.Candle, now time.Time, holidays []string) bool {
+	now = now.In(util.MarketLocation())
+	if !util.IsTradingDay(now, holidays) {
+		return false
+	}
+	return util.DateKey(candle.Time) < util.DateKey(now)
+}
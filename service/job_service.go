@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"backend/events"
+	"backend/model"
+	"backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxJobErrors caps the per-symbol errors stored on a job document
+	maxJobErrors = 500
+	// jobFlushInterval throttles progress writes to Mongo while a job is running
+	jobFlushInterval = 2 * time.Second
+)
+
+var ErrBackgroundJobNotFound = errors.New("job not found")
+
+// JobFunc is the body of a background task. It reports progress through the tracker.
+type JobFunc func(ctx context.Context, tracker *JobTracker) error
+
+// JobService gives background tasks an ID and persists their progress and outcome.
+type JobService interface {
+	Create(ctx context.Context, jobType string, trigger model.RunTrigger) (*model.Job, error)
+	Execute(ctx context.Context, job *model.Job, fn JobFunc) error
+	Submit(jobType string, trigger model.RunTrigger, fn JobFunc) (*model.Job, error)
+	GetJob(ctx context.Context, id string) (*model.Job, error)
+	ListJobs(ctx context.Context, jobType string, status model.RunStatus, limit int64) ([]model.Job, error)
+}
+
+type JobServiceImpl struct {
+	repo *repository.JobRepository
+	bus  *events.Bus
+}
+
+func NewJobService(repo *repository.JobRepository, bus *events.Bus) JobService {
+	return &JobServiceImpl{repo: repo, bus: bus}
+}
+
+// Create persists a PENDING job so callers can hand out its ID before work starts.
+func (s *JobServiceImpl) Create(ctx context.Context, jobType string, trigger model.RunTrigger) (*model.Job, error) {
+	job := &model.Job{
+		Type:      jobType,
+		Trigger:   trigger,
+		Status:    model.RunStatusPending,
+		Errors:    []model.JobError{},
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Insert(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, nil
+}
+
+// Execute runs fn synchronously, tracking progress on the given job and recording the final status.
+func (s *JobServiceImpl) Execute(ctx context.Context, job *model.Job, fn JobFunc) (err error) {
+	started := time.Now()
+	job.Status = model.RunStatusRunning
+	job.StartedAt = &started
+
+	tracker := &JobTracker{job: job, repo: s.repo, bus: s.bus}
+	tracker.flush(ctx)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+		tracker.finish(err)
+	}()
+
+	return fn(ctx, tracker)
+}
+
+// Submit creates a job and runs it in the background, returning immediately.
+func (s *JobServiceImpl) Submit(jobType string, trigger model.RunTrigger, fn JobFunc) (*model.Job, error) {
+	job, err := s.Create(context.Background(), jobType, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *job
+	go func() {
+		if err := s.Execute(context.Background(), job, fn); err != nil {
+			log.Printf("Job %s (%s) failed: %v", job.ID.Hex(), jobType, err)
+		}
+	}()
+	return &snapshot, nil
+}
+
+// GetJob returns a single job including its per-symbol errors.
+func (s *JobServiceImpl) GetJob(ctx context.Context, id string) (*model.Job, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrBackgroundJobNotFound
+	}
+
+	job, err := s.repo.FindById(ctx, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrBackgroundJobNotFound
+	}
+	return job, nil
+}
+
+// ListJobs returns recent jobs without their error details.
+func (s *JobServiceImpl) ListJobs(ctx context.Context, jobType string, status model.RunStatus, limit int64) ([]model.Job, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	filter := bson.M{}
+	if jobType != "" {
+		filter["type"] = jobType
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	return s.repo.FindRecent(ctx, filter, limit)
+}
+
+// --- Tracker ---
+
+// JobTracker collects progress for a running job. All methods are safe on a nil tracker,
+// so service methods can be called outside of a job.
+type JobTracker struct {
+	mu        sync.Mutex
+	job       *model.Job
+	repo      *repository.JobRepository
+	bus       *events.Bus
+	lastFlush time.Time
+}
+
+// ID returns the tracked job's ID, or an empty string for a nil tracker.
+func (t *JobTracker) ID() string {
+	if t == nil {
+		return ""
+	}
+	return t.job.ID.Hex()
+}
+
+// SetTotal records how many items the job expects to process.
+func (t *JobTracker) SetTotal(total int) {
+	t.update(func(job *model.Job) { job.Total = total })
+}
+
+// Inserted counts an item that was processed and produced a new record.
+func (t *JobTracker) Inserted() {
+	t.update(func(job *model.Job) {
+		job.Processed++
+		job.Inserted++
+	})
+}
+
+// Processed counts an item that was handled without inserting anything.
+func (t *JobTracker) Processed() {
+	t.update(func(job *model.Job) { job.Processed++ })
+}
+
+// Skipped counts an item that was intentionally not processed.
+func (t *JobTracker) Skipped() {
+	t.update(func(job *model.Job) {
+		job.Processed++
+		job.Skipped++
+	})
+}
+
+// Fail counts an item that errored and records the reason against its symbol.
+func (t *JobTracker) Fail(symbol string, err error) {
+	t.update(func(job *model.Job) {
+		job.Processed++
+		job.Failed++
+		if len(job.Errors) < maxJobErrors {
+			job.Errors = append(job.Errors, model.JobError{Symbol: symbol, Error: err.Error()})
+		}
+	})
+}
+
+// SetMessage stores a human readable summary on the job.
+func (t *JobTracker) SetMessage(msg string) {
+	t.update(func(job *model.Job) { job.Message = msg })
+}
+
+func (t *JobTracker) update(fn func(job *model.Job)) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	fn(t.job)
+	shouldFlush := time.Since(t.lastFlush) >= jobFlushInterval
+	t.mu.Unlock()
+
+	if shouldFlush {
+		t.flush(context.Background())
+	}
+}
+
+func (t *JobTracker) flush(ctx context.Context) {
+	t.mu.Lock()
+	snapshot := *t.job
+	snapshot.Errors = make([]model.JobError, len(t.job.Errors))
+	copy(snapshot.Errors, t.job.Errors)
+	t.lastFlush = time.Now()
+	t.mu.Unlock()
+
+	if err := t.repo.Update(ctx, &snapshot); err != nil {
+		log.Printf("Job %s: failed to persist progress: %v", snapshot.ID.Hex(), err)
+	}
+
+	snapshot.Errors = nil
+	t.bus.Publish(events.Event{Type: events.JobProgress, Data: snapshot})
+}
+
+func (t *JobTracker) finish(err error) {
+	t.mu.Lock()
+	finished := time.Now()
+	t.job.FinishedAt = &finished
+	t.job.Status = model.RunStatusSuccess
+	if err != nil {
+		t.job.Status = model.RunStatusFailed
+		t.job.Message = err.Error()
+	}
+	t.mu.Unlock()
+
+	t.flush(context.Background())
+}
@@ -2,16 +2,26 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"backend/cache"
 	"backend/config"
 	"backend/model"
 	"backend/repository"
 	"backend/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidMarginImport = errors.New("invalid margin import")
+	ErrMarginBatchNotFound = errors.New("margin batch not found")
 )
 
 // MarginService defines the contract for managing stock margins and CSV uploads.
@@ -19,12 +29,24 @@ type MarginService interface {
 	GetAllMargins() []model.Margin
 	GetMargin(symbol string) (*model.Margin, bool)
 	ReloadAllMargins(ctx context.Context) error
-	LoadFromCsv(ctx context.Context, fileName string, file io.Reader) error
+	// LoadFromCsv replaces the margins with a CSV's, recording the upload as a batch with a per-symbol change log.
+	LoadFromCsv(ctx context.Context, fileName string, file io.Reader, userID int64) (*model.MarginBatch, error)
+
+	// ListBatches returns the margin imports, newest first.
+	ListBatches(ctx context.Context, limit int64) ([]model.MarginBatch, error)
+	// GetBatchDiff returns an import with the symbols it added, removed or re-levered.
+	GetBatchDiff(ctx context.Context, id string) (*model.MarginBatchDiff, error)
+	// RollbackToBatch restores the margins an earlier import left behind, recorded as a new batch.
+	RollbackToBatch(ctx context.Context, id string, userID int64) (*model.MarginBatch, error)
+	// GetMarginHistory returns a symbol's changes across imports, newest first.
+	GetMarginHistory(ctx context.Context, symbol string, limit int64) ([]model.MarginChange, error)
 }
 
 type MarginServiceImpl struct {
 	repo *repository.MarginRepository
 	cfg  *config.ConfigManager
+	// importMu serializes imports so each batch diffs against the margins the previous one left
+	importMu sync.Mutex
 }
 
 // NewMarginService initializes the service and performs an initial cache load.
@@ -78,44 +100,173 @@ func (s *MarginServiceImpl) ReloadAllMargins(ctx context.Context) error {
 }
 
 // LoadFromCsv parses a CSV, updates the DB, removes stale records, and refreshes the cache.
-func (s *MarginServiceImpl) LoadFromCsv(ctx context.Context, fileName string, file io.Reader) error {
+func (s *MarginServiceImpl) LoadFromCsv(ctx context.Context, fileName string, file io.Reader, userID int64) (*model.MarginBatch, error) {
 	if file == nil {
-		return fmt.Errorf("file is empty")
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidMarginImport)
 	}
 	if filepath.Ext(fileName) != ".csv" {
-		return fmt.Errorf("invalid file type: must be .csv")
+		return nil, fmt.Errorf("%w: invalid file type: must be .csv", ErrInvalidMarginImport)
 	}
 
 	// 1. Parse CSV using utility
 	margins, err := util.Read(file, s.cfg.GetConfig().Leverage)
 	if err != nil {
-		return fmt.Errorf("csv parsing failed: %w", err)
+		return nil, fmt.Errorf("%w: csv parsing failed: %v", ErrInvalidMarginImport, err)
+	}
+
+	return s.apply(ctx, margins, &model.MarginBatch{FileName: fileName, UploadedBy: userID})
+}
+
+func (s *MarginServiceImpl) ListBatches(ctx context.Context, limit int64) ([]model.MarginBatch, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.FindBatches(ctx, limit)
+}
+
+func (s *MarginServiceImpl) GetBatchDiff(ctx context.Context, id string) (*model.MarginBatchDiff, error) {
+	batch, err := s.findBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.FindChangesByBatch(ctx, batch.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Persist to DB
+	batch.Margins = nil
+	return &model.MarginBatchDiff{Batch: *batch, Changes: changes}, nil
+}
+
+func (s *MarginServiceImpl) RollbackToBatch(ctx context.Context, id string, userID int64) (*model.MarginBatch, error) {
+	target, err := s.findBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.apply(ctx, target.Margins, &model.MarginBatch{
+		FileName:     target.FileName,
+		UploadedBy:   userID,
+		RestoredFrom: &target.ID,
+	})
+}
+
+func (s *MarginServiceImpl) GetMarginHistory(ctx context.Context, symbol string, limit int64) ([]model.MarginChange, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.FindChangesBySymbol(ctx, symbol, limit)
+}
+
+// --- Internal Helpers ---
+
+// apply replaces the stored margins with a new set, then records the batch and what it changed.
+func (s *MarginServiceImpl) apply(ctx context.Context, margins []model.Margin, batch *model.MarginBatch) (*model.MarginBatch, error) {
+	// An empty set would delete every margin
+	if len(margins) == 0 {
+		return nil, fmt.Errorf("%w: no margins to import", ErrInvalidMarginImport)
+	}
+
+	s.importMu.Lock()
+	defer s.importMu.Unlock()
+
+	current, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch.ImportedAt = time.Now()
+	changes := diffMargins(current, margins, batch)
+
+	// 1. Persist to DB
 	if err := s.repo.SaveAll(ctx, margins); err != nil {
-		return fmt.Errorf("failed to save margins: %w", err)
+		return nil, fmt.Errorf("failed to save margins: %w", err)
 	}
 
-	// 3. Clean up stale records (Delete symbols not present in the new CSV)
+	// 2. Clean up stale records (Delete symbols not present in the new set)
 	ids := make([]string, len(margins))
 	for i, m := range margins {
 		ids[i] = m.Symbol
 	}
-
 	deletedCount, err := s.repo.DeleteByIdNotIn(ctx, ids)
 	if err != nil {
 		log.Printf("Error deleting old margins: %v", err)
 	}
 
-	// 4. Synchronize Cache
+	// 3. Synchronize Cache
 	s.updateLocalCache(margins)
 
-	log.Printf("CSV Loaded. Cache updated. Symbols synced: %d. Deleted stale: %d", len(margins), deletedCount)
-	return nil
+	// 4. Record the batch and its change log
+	batch.Margins = margins
+	if err := s.repo.InsertBatch(ctx, batch); err != nil {
+		return nil, fmt.Errorf("margins imported but the batch was not recorded: %w", err)
+	}
+	for i := range changes {
+		changes[i].BatchID = batch.ID
+	}
+	if err := s.repo.InsertChanges(ctx, changes); err != nil {
+		return nil, fmt.Errorf("margins imported but their changes were not recorded: %w", err)
+	}
+
+	log.Printf("Margins imported (batch %s). Symbols synced: %d. Added: %d. Changed: %d. Deleted stale: %d",
+		batch.ID.Hex(), len(margins), batch.Added, batch.Changed, deletedCount)
+	batch.Margins = nil
+	return batch, nil
 }
 
-// --- Internal Helpers ---
+func (s *MarginServiceImpl) findBatch(ctx context.Context, id string) (*model.MarginBatch, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrMarginBatchNotFound
+	}
+	batch, err := s.repo.FindBatchById(ctx, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, ErrMarginBatchNotFound
+	}
+	return batch, nil
+}
+
+// diffMargins lists the symbols added, removed or re-levered going from one margin set to the next, and fills in
+// the batch's counts.
+func diffMargins(current, next []model.Margin, batch *model.MarginBatch) []model.MarginChange {
+	previous := make(map[string]model.Margin, len(current))
+	for _, m := range current {
+		previous[m.Symbol] = m
+	}
+
+	var changes []model.MarginChange
+	seen := make(map[string]bool, len(next))
+	for _, m := range next {
+		seen[m.Symbol] = true
+		newMargin := m.Margin
+		old, existed := previous[m.Symbol]
+		switch {
+		case !existed:
+			batch.Added++
+			changes = append(changes, model.MarginChange{Symbol: m.Symbol, Name: m.Name, Type: model.MarginAdded,
+				NewMargin: &newMargin, At: batch.ImportedAt})
+		case old.Margin != m.Margin:
+			batch.Changed++
+			oldMargin := old.Margin
+			changes = append(changes, model.MarginChange{Symbol: m.Symbol, Name: m.Name, Type: model.MarginChanged,
+				OldMargin: &oldMargin, NewMargin: &newMargin, At: batch.ImportedAt})
+		default:
+			batch.Unchanged++
+		}
+	}
+	for _, m := range current {
+		if !seen[m.Symbol] {
+			batch.Removed++
+			oldMargin := m.Margin
+			changes = append(changes, model.MarginChange{Symbol: m.Symbol, Name: m.Name, Type: model.MarginRemoved,
+				OldMargin: &oldMargin, At: batch.ImportedAt})
+		}
+	}
+	batch.Total = len(next)
+	return changes
+}
 
 // updateLocalCache provides a single point of truth for refreshing the MarginCache.
 func (s *MarginServiceImpl) updateLocalCache(margins []model.Margin) {
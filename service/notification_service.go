@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/client"
+	"backend/config"
+	"backend/events"
+	"backend/model"
+)
+
+const (
+	defaultTelegramBaseURL = "https://api.telegram.org"
+	notificationTimeout    = time.Minute
+	// maxSummaryLines caps the zones listed in a mitigation summary
+	maxSummaryLines = 20
+)
+
+var (
+	ErrInvalidNotification  = errors.New("invalid notification settings")
+	ErrNoChannel            = errors.New("no notification channel selected")
+	ErrChannelNotLinked     = errors.New("channel is not linked to the account")
+	ErrChannelNotConfigured = errors.New("channel is not configured")
+)
+
+const notificationTemplate = `
+<table style="max-width:480px;margin:auto;padding:20px;border:1px solid #ddd;border-radius:8px;">
+  <tr><td style="font-family:Arial, sans-serif;">
+    <h3 style="color:#1a73e8;">%s</h3>
+    <p>%s</p>
+  </td></tr>
+</table>`
+
+// NotificationChannel delivers notifications to a user over one medium.
+type NotificationChannel interface {
+	Type() model.ChannelType
+	Send(ctx context.Context, user *model.User, n model.Notification) error
+}
+
+type emailChannel struct {
+	emailSvc EmailService
+	cfg      *config.ConfigManager
+}
+
+// NewEmailChannel sends notifications as Brevo emails.
+func NewEmailChannel(emailSvc EmailService, cfg *config.ConfigManager) NotificationChannel {
+	return &emailChannel{emailSvc: emailSvc, cfg: cfg}
+}
+
+func (c *emailChannel) Type() model.ChannelType {
+	return model.ChannelEmail
+}
+
+func (c *emailChannel) Send(ctx context.Context, user *model.User, n model.Notification) error {
+	if user.Email == "" {
+		return fmt.Errorf("%w: user has no email", ErrChannelNotLinked)
+	}
+
+	body := strings.ReplaceAll(html.EscapeString(n.Message), "\n", "<br>")
+	return c.emailSvc.SendEmail(ctx, model.BrevoEmailRequest{
+		Sender:      model.Recipient{Email: c.cfg.GetConfig().BrevoEmail, Name: "Shahbaz Trades"},
+		To:          []model.Recipient{{Email: user.Email, Name: user.Name}},
+		Subject:     n.Title,
+		HTMLContent: fmt.Sprintf(notificationTemplate, html.EscapeString(n.Title), body),
+	})
+}
+
+type telegramChannel struct {
+	client *client.TelegramClient
+	cfg    *config.ConfigManager
+}
+
+// NewTelegramChannel sends notifications through the Telegram Bot API to the user's linked chat.
+func NewTelegramChannel(c *client.TelegramClient, cfg *config.ConfigManager) NotificationChannel {
+	return &telegramChannel{client: c, cfg: cfg}
+}
+
+func (c *telegramChannel) Type() model.ChannelType {
+	return model.ChannelTelegram
+}
+
+func (c *telegramChannel) Send(ctx context.Context, user *model.User, n model.Notification) error {
+	if user.TelegramChatID == "" {
+		return fmt.Errorf("%w: no telegram chat", ErrChannelNotLinked)
+	}
+	return c.sendTo(ctx, user.TelegramChatID, n)
+}
+
+func (c *telegramChannel) sendTo(ctx context.Context, chatID string, n model.Notification) error {
+	tg := c.cfg.GetConfig().Telegram
+	if tg.BotToken == "" {
+		return fmt.Errorf("%w: telegram bot token is not set", ErrChannelNotConfigured)
+	}
+	baseURL := tg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultTelegramBaseURL
+	}
+
+	text := "<b>" + html.EscapeString(n.Title) + "</b>\n" + html.EscapeString(n.Message)
+	return c.client.SendMessage(ctx, baseURL, tg.BotToken, chatID, text)
+}
+
+// NotificationService routes notifications to the channels each user picked, and turns mitigation and automation
+// events on the bus into notifications for the users who opted in.
+type NotificationService interface {
+	// Notify sends n to a user on every channel they route its event to. It fails only if no channel delivered it.
+	Notify(ctx context.Context, userID int64, n model.Notification) error
+	GetSettings(ctx context.Context, userID int64) (*model.NotificationSettings, error)
+	// LinkTelegram messages the chat and links it to the account once the message got through.
+	LinkTelegram(ctx context.Context, userID int64, chatID string) (*model.NotificationSettings, error)
+	UnlinkTelegram(ctx context.Context, userID int64) (*model.NotificationSettings, error)
+	UpdatePreferences(ctx context.Context, userID int64, prefs model.NotificationPrefs) (*model.NotificationSettings, error)
+	SendTest(ctx context.Context, userID int64, channel model.ChannelType) error
+	Start()
+}
+
+type NotificationServiceImpl struct {
+	userSvc  UserService
+	bus      *events.Bus
+	channels map[model.ChannelType]NotificationChannel
+	// mitigated collects the zones of the running mitigation check; only the bus handler touches it
+	mitigated []model.ZoneEvent
+}
+
+func NewNotificationService(userSvc UserService, bus *events.Bus, channels ...NotificationChannel) NotificationService {
+	s := &NotificationServiceImpl{
+		userSvc:  userSvc,
+		bus:      bus,
+		channels: make(map[model.ChannelType]NotificationChannel, len(channels)),
+	}
+	for _, c := range channels {
+		s.channels[c.Type()] = c
+	}
+	return s
+}
+
+func (s *NotificationServiceImpl) Notify(ctx context.Context, userID int64, n model.Notification) error {
+	user, err := s.userSvc.FindUser(ctx, 0, "", userID)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, user, n)
+}
+
+func (s *NotificationServiceImpl) send(ctx context.Context, user *model.User, n model.Notification) error {
+	types := user.Notifications.Channels(n.Event)
+	if len(types) == 0 {
+		return ErrNoChannel
+	}
+
+	var errs []error
+	for _, t := range types {
+		channel, ok := s.channels[t]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrChannelNotConfigured, t))
+			continue
+		}
+		if err := channel.Send(ctx, user, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", t, err))
+		}
+	}
+
+	if len(errs) == len(types) {
+		return errors.Join(errs...)
+	}
+	for _, err := range errs {
+		log.Printf("Notifications: %s to user %d partly failed: %v", n.Event, user.UserID, err)
+	}
+	return nil
+}
+
+func (s *NotificationServiceImpl) GetSettings(ctx context.Context, userID int64) (*model.NotificationSettings, error) {
+	user, err := s.userSvc.FindUser(ctx, 0, "", userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.settings(user), nil
+}
+
+func (s *NotificationServiceImpl) LinkTelegram(ctx context.Context, userID int64, chatID string) (*model.NotificationSettings, error) {
+	chatID = strings.TrimSpace(chatID)
+	if chatID == "" {
+		return nil, fmt.Errorf("%w: chat ID is required", ErrInvalidNotification)
+	}
+	channel, ok := s.channels[model.ChannelTelegram].(*telegramChannel)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrChannelNotConfigured, model.ChannelTelegram)
+	}
+
+	// A chat the bot cannot reach would silently swallow every notification
+	err := channel.sendTo(ctx, chatID, model.Notification{
+		Title:   "Shahbaz Trades",
+		Message: "This chat is now linked to your account.",
+	})
+	if err != nil {
+		if errors.Is(err, ErrChannelNotConfigured) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: could not message chat %s: %v", ErrInvalidNotification, chatID, err)
+	}
+
+	user, err := s.userSvc.UpdateTelegramChatID(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	return s.settings(user), nil
+}
+
+func (s *NotificationServiceImpl) UnlinkTelegram(ctx context.Context, userID int64) (*model.NotificationSettings, error) {
+	user, err := s.userSvc.UpdateTelegramChatID(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.settings(user), nil
+}
+
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, userID int64,
+	prefs model.NotificationPrefs) (*model.NotificationSettings, error) {
+	cleaned := make(model.NotificationPrefs, len(prefs))
+	for event, types := range prefs {
+		if !slices.Contains(model.NotificationEvents, event) {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidNotification, event)
+		}
+		channels := make([]model.ChannelType, 0, len(types))
+		for _, t := range types {
+			if _, ok := s.channels[t]; !ok {
+				return nil, fmt.Errorf("%w: unknown channel %q", ErrInvalidNotification, t)
+			}
+			if !slices.Contains(channels, t) {
+				channels = append(channels, t)
+			}
+		}
+		cleaned[event] = channels
+	}
+
+	user, err := s.userSvc.UpdateNotificationPrefs(ctx, userID, cleaned)
+	if err != nil {
+		return nil, err
+	}
+	return s.settings(user), nil
+}
+
+func (s *NotificationServiceImpl) SendTest(ctx context.Context, userID int64, channelType model.ChannelType) error {
+	channel, ok := s.channels[channelType]
+	if !ok {
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidNotification, channelType)
+	}
+	user, err := s.userSvc.FindUser(ctx, 0, "", userID)
+	if err != nil {
+		return err
+	}
+	return channel.Send(ctx, user, model.Notification{
+		Title:   "Test notification",
+		Message: "Notifications on this channel are working.",
+	})
+}
+
+func (s *NotificationServiceImpl) settings(user *model.User) *model.NotificationSettings {
+	settings := &model.NotificationSettings{
+		TelegramChatID: user.TelegramChatID,
+		Channels:       make([]model.ChannelType, 0, len(s.channels)),
+		Preferences:    make(model.NotificationPrefs, len(model.NotificationEvents)),
+	}
+	for t := range s.channels {
+		settings.Channels = append(settings.Channels, t)
+	}
+	slices.Sort(settings.Channels)
+	for _, event := range model.NotificationEvents {
+		settings.Preferences[event] = user.Notifications.Channels(event)
+		if settings.Preferences[event] == nil {
+			settings.Preferences[event] = []model.ChannelType{}
+		}
+	}
+	return settings
+}
+
+func (s *NotificationServiceImpl) Start() {
+	s.bus.Subscribe("notifications", 0, s.handle)
+}
+
+func (s *NotificationServiceImpl) handle(e events.Event) {
+	switch e.Type {
+	case events.ZoneMitigated:
+		if zone, ok := e.Data.(model.ZoneEvent); ok {
+			s.mitigated = append(s.mitigated, zone)
+		}
+	case events.MitigationChecked:
+		if len(s.mitigated) == 0 {
+			return
+		}
+		zones := s.mitigated
+		s.mitigated = nil
+		s.broadcast(mitigationSummary(zones))
+	case events.AutomationFinished:
+		if run, ok := e.Data.(model.AutomationEvent); ok {
+			s.broadcast(model.Notification{
+				Event:   model.NotifyAutomation,
+				Title:   fmt.Sprintf("%s automation finished", run.Kind),
+				Message: fmt.Sprintf("%d of %d scanned symbols saved as new %s zones.", run.Inserted, run.Total, run.Kind),
+			})
+		}
+	}
+}
+
+// broadcast sends n to every user who routes its event to a channel.
+func (s *NotificationServiceImpl) broadcast(n model.Notification) {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+
+	users, err := s.userSvc.FindNotificationSubscribers(ctx, n.Event)
+	if err != nil {
+		log.Printf("Notifications: failed to load subscribers of %s: %v", n.Event, err)
+		return
+	}
+	for i := range users {
+		if err := s.send(ctx, &users[i], n); err != nil {
+			log.Printf("Notifications: %s to user %d failed: %v", n.Event, users[i].UserID, err)
+		}
+	}
+}
+
+func mitigationSummary(zones []model.ZoneEvent) model.Notification {
+	lines := make([]string, 0, min(len(zones), maxSummaryLines)+1)
+	for i, z := range zones {
+		if i == maxSummaryLines {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(zones)-maxSummaryLines))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %s (%.2f - %.2f)", z.Symbol, z.Timeframe, z.Direction, z.Kind,
+			z.Date, z.Low, z.High))
+	}
+	return model.Notification{
+		Event:   model.NotifyMitigations,
+		Title:   fmt.Sprintf("%d zones mitigated", len(zones)),
+		Message: strings.Join(lines, "\n"),
+	}
+}
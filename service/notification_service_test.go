@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/client"
+	"backend/config"
+	"backend/model"
+)
+
+const testBotToken = "123456:test-token"
+
+// telegramStub stands in for the Bot API and records the messages sent to it.
+type telegramStub struct {
+	*httptest.Server
+	paths  []string
+	bodies []map[string]any
+}
+
+func newTelegramStub(t *testing.T, status int, reply string) *telegramStub {
+	stub := &telegramStub{}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		stub.paths = append(stub.paths, r.URL.Path)
+		stub.bodies = append(stub.bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, reply)
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func newTestTelegramChannel(baseURL, token string) NotificationChannel {
+	cfg := config.NewConfigManager(&model.MongoEnvConfig{
+		Telegram: model.TelegramConfig{BotToken: token, BaseURL: baseURL},
+	})
+	return NewTelegramChannel(client.NewTelegramClient(), cfg)
+}
+
+func TestTelegramChannelSend(t *testing.T) {
+	stub := newTelegramStub(t, http.StatusOK, `{"ok":true}`)
+	channel := newTestTelegramChannel(stub.URL+"/", testBotToken)
+
+	err := channel.Send(context.Background(), &model.User{TelegramChatID: "42"}, model.Notification{
+		Title:   "OB & FVG mitigated",
+		Message: "RELIANCE 1D <BULLISH> OB",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if len(stub.paths) != 1 {
+		t.Fatalf("got %d requests, want 1", len(stub.paths))
+	}
+	if want := "/bot" + testBotToken + "/sendMessage"; stub.paths[0] != want {
+		t.Errorf("path = %q, want %q", stub.paths[0], want)
+	}
+	body := stub.bodies[0]
+	if body["chat_id"] != "42" {
+		t.Errorf("chat_id = %v, want 42", body["chat_id"])
+	}
+	if body["parse_mode"] != "HTML" {
+		t.Errorf("parse_mode = %v, want HTML", body["parse_mode"])
+	}
+	if want := "<b>OB &amp; FVG mitigated</b>\nRELIANCE 1D &lt;BULLISH&gt; OB"; body["text"] != want {
+		t.Errorf("text = %q, want %q", body["text"], want)
+	}
+}
+
+func TestTelegramChannelSendErrors(t *testing.T) {
+	stub := newTelegramStub(t, http.StatusBadRequest, `{"ok":false,"description":"Bad Request: chat not found"}`)
+	n := model.Notification{Title: "Test", Message: "Hello"}
+
+	tests := []struct {
+		name    string
+		baseURL string
+		token   string
+		chatID  string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "api error", baseURL: stub.URL, token: testBotToken, chatID: "42", wantMsg: "chat not found"},
+		{name: "chat not linked", baseURL: stub.URL, token: testBotToken, wantErr: ErrChannelNotLinked},
+		{name: "bot token not set", baseURL: stub.URL, chatID: "42", wantErr: ErrChannelNotConfigured},
+		{name: "unreachable", baseURL: "http://127.0.0.1:1", token: testBotToken, chatID: "42", wantMsg: "<token>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := newTestTelegramChannel(tt.baseURL, tt.token)
+			err := channel.Send(context.Background(), &model.User{TelegramChatID: tt.chatID}, n)
+			if err == nil {
+				t.Fatal("Send succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+			if tt.token != "" && strings.Contains(err.Error(), tt.token) {
+				t.Errorf("error %q leaks the bot token", err)
+			}
+		})
+	}
+
+	if len(stub.paths) != 1 {
+		t.Errorf("stub got %d requests, want only the api error case to reach it", len(stub.paths))
+	}
+}
+
+func TestMitigationSummary(t *testing.T) {
+	zones := make([]model.ZoneEvent, maxSummaryLines+2)
+	for i := range zones {
+		zones[i] = model.ZoneEvent{
+			Symbol: fmt.Sprintf("SYM%d", i), Kind: "OB", Direction: model.DirectionBullish,
+			Timeframe: model.Timeframe1D, Date: "2025-01-10", High: 105, Low: 100.5,
+		}
+	}
+
+	n := mitigationSummary(zones)
+	if n.Event != model.NotifyMitigations {
+		t.Errorf("event = %q, want %q", n.Event, model.NotifyMitigations)
+	}
+	if want := fmt.Sprintf("%d zones mitigated", len(zones)); n.Title != want {
+		t.Errorf("title = %q, want %q", n.Title, want)
+	}
+
+	lines := strings.Split(n.Message, "\n")
+	if len(lines) != maxSummaryLines+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), maxSummaryLines+1)
+	}
+	if want := "SYM0 1D BULLISH OB 2025-01-10 (100.50 - 105.00)"; lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+	if want := "... and 2 more"; lines[maxSummaryLines] != want {
+		t.Errorf("last line = %q, want %q", lines[maxSummaryLines], want)
+	}
+}
@@ -1,14 +1,21 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"backend/cache"
+	"backend/config"
+	"backend/detection"
+	"backend/events"
 	"backend/model"
 	"backend/repository"
 	"backend/util"
@@ -17,47 +24,93 @@ import (
 )
 
 type PriceActionService interface {
-	GetPABySymbol(ctx context.Context, symbol string) (model.StockRecord, error)
+	// GetPABySymbol returns a symbol's zones, optionally only those in the given statuses.
+	GetPABySymbol(ctx context.Context, symbol string, statuses []model.ZoneStatus) (model.StockRecord, error)
+	// SaveOrderBlock and SaveFvg store a new zone, returning ErrZoneExists if it is already stored so that its
+	// lifecycle is not reset; UpdateOrderBlock and UpdateFvg change a stored one.
 	SaveOrderBlock(ctx context.Context, req model.ObRequest) error
 	UpdateOrderBlock(ctx context.Context, req model.ObRequest) error
-	DeleteOrderBlock(ctx context.Context, symbol string, date string) error
-	CheckOBMitigation(ctx context.Context) ([]model.ObResponse, error)
-	AutomateOrderBlock(ctx context.Context, attempt int) error
+	DeleteOrderBlock(ctx context.Context, symbol string, date string, tf model.Timeframe, direction model.Direction) error
+	// CheckOBMitigation finds stocks trading into an active order block. With record set, which only the scheduled
+	// checks do, zones are marked tested or invalidated and their mitigation is announced on the bus.
+	CheckOBMitigation(ctx context.Context, record bool) ([]model.ObResponse, error)
+	CheckBearishOBMitigation(ctx context.Context, record bool) ([]model.ObResponse, error)
+	AutomateOrderBlock(ctx context.Context, tracker *JobTracker) error
+	OrderBlockCleanUp(ctx context.Context, tracker *JobTracker) error
 
 	SaveFvg(ctx context.Context, req model.ObRequest) error
 	UpdateFvg(ctx context.Context, req model.ObRequest) error
-	DeleteFvg(ctx context.Context, symbol string, date string) error
-	CheckFvgMitigation(ctx context.Context) ([]model.ObResponse, error)
-	AutomateFvg(ctx context.Context, attempt int) error
-	FvgCleanUp(ctx context.Context) error
+	DeleteFvg(ctx context.Context, symbol string, date string, tf model.Timeframe, direction model.Direction) error
+	CheckFvgMitigation(ctx context.Context, record bool) ([]model.ObResponse, error)
+	CheckBearishFvgMitigation(ctx context.Context, record bool) ([]model.ObResponse, error)
+	AutomateFvg(ctx context.Context, tracker *JobTracker) error
+	FvgCleanUp(ctx context.Context, tracker *JobTracker) error
+
+	AddOlderOb(ctx context.Context, fileName string, file io.Reader, stopDate string, tracker *JobTracker) error
+	AddOlderFvg(ctx context.Context, fileName string, file io.Reader, stopDate string, tracker *JobTracker) error
+}
+
+// ErrCandleNotUpdated signals that the data providers have not published today's candle yet,
+// so the caller should retry the automation later.
+var ErrCandleNotUpdated = errors.New("today's candle is not available yet")
+
+// ErrZoneExists is returned when saving a zone that is already stored.
+var ErrZoneExists = errors.New("zone already exists")
 
-	AddOlderOb(ctx context.Context, fileName string, file io.Reader, stopDate string)
-	AddOlderFvg(ctx context.Context, fileName string, file io.Reader, stopDate string)
+// Strategies the mitigation checks scan unless config names others
+const (
+	defaultBullishMitigationStrategy = "BULLISH CLOSE 200"
+	defaultBearishMitigationStrategy = "BEARISH CLOSE 200"
+)
+
+// mitigationLookback is how much history is loaded to get the current and previous candle of a timeframe.
+var mitigationLookback = map[model.Timeframe]time.Duration{
+	model.Timeframe15m: 5 * 24 * time.Hour,
+	model.Timeframe1h:  10 * 24 * time.Hour,
+	model.Timeframe1W:  30 * 24 * time.Hour,
+	model.Timeframe1M:  70 * 24 * time.Hour,
 }
 
 type PriceActionServiceImpl struct {
 	chartInkService ChartInkService
 	nseService      NseService
+	candleSvc       CandleService
 	priceActionRepo *repository.PriceActionRepo
 	marginSvc       MarginService
+	bus             *events.Bus
+	cfg             *config.ConfigManager
 }
 
-func NewPriceActionService(c ChartInkService, n NseService,
-	repo *repository.PriceActionRepo, marginSvc MarginService) PriceActionService {
-	return &PriceActionServiceImpl{
+func NewPriceActionService(c ChartInkService, n NseService, candleSvc CandleService,
+	repo *repository.PriceActionRepo, marginSvc MarginService, bus *events.Bus,
+	cfg *config.ConfigManager) PriceActionService {
+	s := &PriceActionServiceImpl{
 		chartInkService: c,
 		nseService:      n,
+		candleSvc:       candleSvc,
 		priceActionRepo: repo,
 		marginSvc:       marginSvc,
+		bus:             bus,
+		cfg:             cfg,
+	}
+
+	// Zones saved before timeframes and directions existed are daily and bullish
+	if err := repo.BackfillZoneDefaults(context.Background()); err != nil {
+		log.Printf("Warning: Failed to backfill zone defaults: %v", err)
 	}
+
+	return s
 }
 
 // --- Internal Engine ---
 
-func (s *PriceActionServiceImpl) processMitigation(ctx context.Context, strategyName string, cacheKey string, isOB bool) ([]model.ObResponse, error) {
+func (s *PriceActionServiceImpl) processMitigation(ctx context.Context, cacheKey string, isOB bool,
+	direction model.Direction, record bool) ([]model.ObResponse, error) {
+	strategyName := s.mitigationStrategy(direction)
 	rawStrategy, found := cache.StrategyCache.Get(strategyName)
 	if !found {
-		return nil, errors.New("strategy not found in cache: " + strategyName)
+		return nil, fmt.Errorf("strategy not found in cache: %s (create it or set mitigation.%sStrategy in config)",
+			strategyName, strings.ToLower(string(direction)))
 	}
 	strategy := rawStrategy.(model.StrategyDto)
 
@@ -80,26 +133,44 @@ func (s *PriceActionServiceImpl) processMitigation(ctx context.Context, strategy
 
 	var response []model.ObResponse
 	for _, pa := range pas {
-		history, err := s.nseService.FetchStockData(ctx, pa.Symbol)
-		if err != nil || len(history) == 0 {
-			continue
-		}
-		today := history[0]
-
 		blocks := pa.OrderBlocks
 		if !isOB {
 			blocks = pa.Fvg
 		}
 
+		// Each zone is checked against the current candle of its own timeframe
+		histories := make(map[model.Timeframe][]model.Candle)
 		for _, block := range blocks {
-			checkDate, _ := util.ParseNseDate(history[1].Timestamp)
-			if !isOB && block.Date == checkDate {
+			if block.Direction.OrDefault() != direction || !block.Status.IsActive() {
+				continue
+			}
+			tf := block.Timeframe.OrDefault()
+			history, loaded := histories[tf]
+			if !loaded {
+				history, err = s.timeframeHistory(ctx, pa.Symbol, tf)
+				if err != nil {
+					log.Printf("Mitigation: no %s candles for %s: %v", tf, pa.Symbol, err)
+				}
+				histories[tf] = history
+			}
+
+			broken, touched := checkZone(block, history)
+			if broken {
+				if record {
+					s.recordLifecycle(ctx, pa.Symbol, isOB, block, history[0], true)
+				}
 				continue
 			}
-			if s.checkValidMitigation(today, block) {
+			if touched {
+				if record {
+					s.recordLifecycle(ctx, pa.Symbol, isOB, block, history[0], false)
+					s.bus.Publish(events.Event{Type: events.ZoneMitigated, Symbol: pa.Symbol, Data: zoneEvent(pa.Symbol, isOB, block)})
+				}
 				var obResp model.ObResponse
 				copier.Copy(&obResp, idMap[pa.Symbol])
 				obResp.Date = block.Date
+				obResp.Timeframe = tf
+				obResp.Direction = direction
 				response = append(response, obResp)
 				break
 			}
@@ -112,255 +183,572 @@ func (s *PriceActionServiceImpl) processMitigation(ctx context.Context, strategy
 		})
 		cache.PriceActionCache.Set(cacheKey, response, -1)
 	}
+
+	// Only the scheduled checks are announced; on-demand ones would let any caller trigger alert sweeps
+	if record {
+		kind := detection.KindFvg
+		if isOB {
+			kind = detection.KindOrderBlock
+		}
+		s.bus.Publish(events.Event{
+			Type: events.MitigationChecked,
+			Data: model.MitigationEvent{Kind: kind, Direction: direction, Results: response},
+		})
+	}
 	return response, nil
 }
 
-// --- Interface Methods ---
+// mitigationStrategy is the ChartInk strategy whose stocks are checked against zones of a direction.
+func (s *PriceActionServiceImpl) mitigationStrategy(direction model.Direction) string {
+	cfg := s.cfg.GetConfig().Mitigation
+	if direction == model.DirectionBearish {
+		return cmp.Or(strings.ToUpper(strings.TrimSpace(cfg.BearishStrategy)), defaultBearishMitigationStrategy)
+	}
+	return cmp.Or(strings.ToUpper(strings.TrimSpace(cfg.BullishStrategy)), defaultBullishMitigationStrategy)
+}
+
+// recordLifecycle marks a zone invalidated, or first-mitigated, by the current candle. Touch counts are left to
+// the cleanup jobs, which replay the full history, so repeated checks within a session stay idempotent.
+func (s *PriceActionServiceImpl) recordLifecycle(ctx context.Context, symbol string, isOB bool, info model.Info,
+	candle model.Candle, broken bool) {
+	next, changed := markLifecycle(info, candle, broken)
+	if !changed {
+		return
+	}
+
+	update := s.priceActionRepo.UpdateFvgLifecycle
+	if isOB {
+		update = s.priceActionRepo.UpdateOrderBlockLifecycle
+	}
+	if err := update(ctx, symbol, next); err != nil {
+		log.Printf("Failed to update lifecycle of %s %s: %v", symbol, info.Date, err)
+	}
+}
+
+// markLifecycle returns the zone invalidated, or first-mitigated, by the candle, and whether that changed it.
+func markLifecycle(info model.Info, candle model.Candle, broken bool) (model.Info, bool) {
+	date := util.CandleKey(candle.Time, info.Timeframe.OrDefault())
+	next := info
+	switch {
+	case broken:
+		next.Status = model.ZoneInvalidated
+		next.InvalidatedOn = date
+	case info.MitigatedOn == "":
+		next.Status = model.ZoneTested
+		next.TouchCount = max(info.TouchCount, 1)
+		next.MitigatedOn = date
+	default:
+		return info, false
+	}
+	return next, true
+}
 
-func (s *PriceActionServiceImpl) AutomateOrderBlock(ctx context.Context, attempt int) error {
-	if attempt >= 3 {
-		return nil
+// checkZone reports whether the latest candle of a zone's timeframe history (newest first) broke or touched the
+// zone. The candle right after the zone's date confirmed it and is ignored, as in lifecycle.
+func checkZone(info model.Info, history []model.Candle) (broken, touched bool) {
+	if len(history) < 2 || util.CandleKey(history[1].Time, info.Timeframe.OrDefault()) == info.Date {
+		return false, false
+	}
+	if isZoneBroken(history[0], info) {
+		return true, false
 	}
+	return false, checkValidMitigation(history[0], info)
+}
+
+// timeframeHistory returns recent tf candles for a symbol, newest first.
+func (s *PriceActionServiceImpl) timeframeHistory(ctx context.Context, symbol string, tf model.Timeframe) ([]model.Candle, error) {
+	if tf == model.Timeframe1D {
+		return s.nseService.FetchStockData(ctx, symbol)
+	}
+
+	now := time.Now()
+	candles, err := s.candleSvc.GetTimeframeCandles(ctx, symbol, tf, now.Add(-mitigationLookback[tf]), now)
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(candles), nil
+}
+
+// --- Interface Methods ---
+
+func (s *PriceActionServiceImpl) AutomateOrderBlock(ctx context.Context, tracker *JobTracker) error {
 	raw, _ := cache.StrategyCache.Get("BULLISH OB 1D")
 	strategy, ok := raw.(model.StrategyDto)
 	if !ok {
 		return errors.New("OB strategy not in cache")
 	}
 
-	data, _ := s.chartInkService.FetchWithMargin(strategy)
+	data, err := s.chartInkService.FetchWithMargin(strategy)
+	if err != nil {
+		return err
+	}
+	tracker.SetTotal(len(data))
+
 	count := 0
 	for _, dto := range data {
 		s.nseService.ClearStockDataCache(dto.Symbol)
-		if history, err := s.nseService.FetchStockData(ctx, dto.Symbol); err == nil && len(history) >= 3 {
-			if s.automationReschedule(history[0]) {
-				log.Printf("Rescheduling Ob automation for %d time", attempt+1)
-				time.AfterFunc(25*time.Minute, func() {
-					s.AutomateOrderBlock(context.Background(), attempt+1)
-				})
-				return nil
-			}
-			candle := history[2]
-			if date, err := util.ParseNseDate(candle.Timestamp); err == nil {
-				_ = s.priceActionRepo.SaveOrderBlock(ctx, model.ObRequest{
-					Symbol: dto.Symbol, Date: date, High: candle.High, Low: candle.Low,
-				})
-				count++
-			}
+		history, err := s.nseService.FetchStockData(ctx, dto.Symbol)
+		if err != nil {
+			tracker.Fail(dto.Symbol, err)
+			continue
+		}
+		if len(history) < 3 {
+			tracker.Skipped()
+			continue
+		}
+		if s.isCandleStale(history[0]) {
+			return ErrCandleNotUpdated
 		}
+
+		candle := history[2]
+		err = s.SaveOrderBlock(ctx, model.ObRequest{
+			Symbol: dto.Symbol, Date: util.DateKey(candle.Time), High: candle.High, Low: candle.Low, Timeframe: model.Timeframe1D,
+		})
+		if errors.Is(err, ErrZoneExists) {
+			tracker.Skipped()
+			continue
+		}
+		if err != nil {
+			tracker.Fail(dto.Symbol, err)
+			continue
+		}
+		tracker.Inserted()
+		count++
 	}
 	log.Printf("%d Order block's inserted", count)
+	s.bus.Publish(events.Event{
+		Type: events.AutomationFinished,
+		Data: model.AutomationEvent{Kind: detection.KindOrderBlock, Total: len(data), Inserted: count},
+	})
 	return nil
 }
 
-func (s *PriceActionServiceImpl) AutomateFvg(ctx context.Context, attempt int) error {
-	if attempt >= 3 {
-		return nil
-	}
+func (s *PriceActionServiceImpl) AutomateFvg(ctx context.Context, tracker *JobTracker) error {
 	raw, _ := cache.StrategyCache.Get("FAIR VALUE GAP")
 	strategy, ok := raw.(model.StrategyDto)
 	if !ok {
 		return errors.New("FVG strategy not in cache")
 	}
 
-	data, _ := s.chartInkService.FetchWithMargin(strategy)
+	data, err := s.chartInkService.FetchWithMargin(strategy)
+	if err != nil {
+		return err
+	}
+	tracker.SetTotal(len(data))
+
 	count := 0
 	for _, dto := range data {
 		s.nseService.ClearStockDataCache(dto.Symbol)
-		if history, err := s.nseService.FetchStockData(ctx, dto.Symbol); err == nil && len(history) >= 3 {
-			if s.automationReschedule(history[0]) {
-				log.Printf("Rescheduling Fvg automation for %d time", attempt+1)
-				time.AfterFunc(30*time.Minute, func() {
-					s.AutomateFvg(context.Background(), attempt+1)
-				})
-				return nil
-			}
-			if date, err := util.ParseNseDate(history[1].Timestamp); err == nil {
-				_ = s.priceActionRepo.SaveFvg(ctx, model.ObRequest{
-					Symbol: dto.Symbol, Date: date, High: history[0].Low, Low: history[2].High,
-				})
-				count++
-			}
+		history, err := s.nseService.FetchStockData(ctx, dto.Symbol)
+		if err != nil {
+			tracker.Fail(dto.Symbol, err)
+			continue
+		}
+		if len(history) < 3 {
+			tracker.Skipped()
+			continue
+		}
+		if s.isCandleStale(history[0]) {
+			return ErrCandleNotUpdated
+		}
+
+		err = s.SaveFvg(ctx, model.ObRequest{
+			Symbol: dto.Symbol, Date: util.DateKey(history[1].Time), High: history[0].Low, Low: history[2].High,
+			Timeframe: model.Timeframe1D,
+		})
+		if errors.Is(err, ErrZoneExists) {
+			tracker.Skipped()
+			continue
 		}
+		if err != nil {
+			tracker.Fail(dto.Symbol, err)
+			continue
+		}
+		tracker.Inserted()
+		count++
 	}
 	log.Printf("%d Fvg's inserted", count)
+	s.bus.Publish(events.Event{
+		Type: events.AutomationFinished,
+		Data: model.AutomationEvent{Kind: detection.KindFvg, Total: len(data), Inserted: count},
+	})
 	return nil
 }
 
-func (s *PriceActionServiceImpl) GetPABySymbol(ctx context.Context, symbol string) (model.StockRecord, error) {
-	return s.priceActionRepo.GetPAByID(ctx, symbol)
+func (s *PriceActionServiceImpl) GetPABySymbol(ctx context.Context, symbol string, statuses []model.ZoneStatus) (model.StockRecord, error) {
+	record, err := s.priceActionRepo.GetPAByID(ctx, symbol)
+	if err != nil || len(statuses) == 0 {
+		return record, err
+	}
+
+	record.OrderBlocks = filterByStatus(record.OrderBlocks, statuses)
+	record.Fvg = filterByStatus(record.Fvg, statuses)
+	return record, nil
+}
+
+func filterByStatus(zones []model.Info, statuses []model.ZoneStatus) []model.Info {
+	out := make([]model.Info, 0, len(zones))
+	for _, z := range zones {
+		if slices.Contains(statuses, z.Status.OrDefault()) {
+			out = append(out, z)
+		}
+	}
+	return out
+}
+
+func (s *PriceActionServiceImpl) CheckOBMitigation(ctx context.Context, record bool) ([]model.ObResponse, error) {
+	return s.processMitigation(ctx, "ObCache", true, model.DirectionBullish, record)
 }
 
-func (s *PriceActionServiceImpl) CheckOBMitigation(ctx context.Context) ([]model.ObResponse, error) {
-	return s.processMitigation(ctx, "BULLISH CLOSE 200", "ObCache", true)
+func (s *PriceActionServiceImpl) CheckBearishOBMitigation(ctx context.Context, record bool) ([]model.ObResponse, error) {
+	return s.processMitigation(ctx, "BearishObCache", true, model.DirectionBearish, record)
 }
 
-func (s *PriceActionServiceImpl) CheckFvgMitigation(ctx context.Context) ([]model.ObResponse, error) {
-	return s.processMitigation(ctx, "BULLISH CLOSE 200", "FvgCache", false)
+func (s *PriceActionServiceImpl) CheckFvgMitigation(ctx context.Context, record bool) ([]model.ObResponse, error) {
+	return s.processMitigation(ctx, "FvgCache", false, model.DirectionBullish, record)
 }
 
-// Pass-through CRUD methods
+func (s *PriceActionServiceImpl) CheckBearishFvgMitigation(ctx context.Context, record bool) ([]model.ObResponse, error) {
+	return s.processMitigation(ctx, "BearishFvgCache", false, model.DirectionBearish, record)
+}
+
+// Pass-through CRUD methods; saved zones are announced on the bus
 func (s *PriceActionServiceImpl) SaveOrderBlock(ctx context.Context, req model.ObRequest) error {
-	return s.priceActionRepo.SaveOrderBlock(ctx, req)
+	if err := s.insertZone(ctx, req, true); err != nil {
+		return err
+	}
+	s.publishNewZone(req, true)
+	return nil
 }
 
 func (s *PriceActionServiceImpl) UpdateOrderBlock(ctx context.Context, req model.ObRequest) error {
 	return s.priceActionRepo.UpdateOrderBlock(ctx, req)
 }
 
-func (s *PriceActionServiceImpl) DeleteOrderBlock(ctx context.Context, sym string, d string) error {
-	return s.priceActionRepo.DeleteOrderBlockByDate(ctx, sym, d)
+func (s *PriceActionServiceImpl) DeleteOrderBlock(ctx context.Context, sym string, d string, tf model.Timeframe,
+	direction model.Direction) error {
+	return s.priceActionRepo.DeleteOrderBlockByDate(ctx, sym, d, tf, direction)
 }
 
 func (s *PriceActionServiceImpl) SaveFvg(ctx context.Context, req model.ObRequest) error {
-	return s.priceActionRepo.SaveFvg(ctx, req)
+	if err := s.insertZone(ctx, req, false); err != nil {
+		return err
+	}
+	s.publishNewZone(req, false)
+	return nil
+}
+
+// insertZone stores a zone unless it is already stored, since saving over it would reset its lifecycle.
+func (s *PriceActionServiceImpl) insertZone(ctx context.Context, req model.ObRequest, isOB bool) error {
+	has, save, kind := s.priceActionRepo.HasFvg, s.priceActionRepo.SaveFvg, detection.KindFvg
+	if isOB {
+		has, save, kind = s.priceActionRepo.HasOrderBlock, s.priceActionRepo.SaveOrderBlock, detection.KindOrderBlock
+	}
+	exists, err := has(ctx, req.Symbol, req.Date, req.Timeframe, req.Direction)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s %s %s %s on %s", ErrZoneExists, req.Symbol, req.Timeframe.OrDefault(),
+			req.Direction.OrDefault(), kind, req.Date)
+	}
+	return save(ctx, req)
+}
+
+func (s *PriceActionServiceImpl) publishNewZone(req model.ObRequest, isOB bool) {
+	info := model.Info{Date: req.Date, High: req.High, Low: req.Low, Timeframe: req.Timeframe, Direction: req.Direction}
+	s.bus.Publish(events.Event{Type: events.ZoneDetected, Symbol: req.Symbol, Data: zoneEvent(req.Symbol, isOB, info)})
+}
+
+func zoneEvent(symbol string, isOB bool, info model.Info) model.ZoneEvent {
+	kind := detection.KindFvg
+	if isOB {
+		kind = detection.KindOrderBlock
+	}
+	return model.ZoneEvent{
+		Symbol: symbol, Kind: kind, Direction: info.Direction.OrDefault(), Timeframe: info.Timeframe.OrDefault(),
+		Date: info.Date, High: info.High, Low: info.Low,
+	}
 }
 
 func (s *PriceActionServiceImpl) UpdateFvg(ctx context.Context, req model.ObRequest) error {
 	return s.priceActionRepo.UpdateFvg(ctx, req)
 }
 
-func (s *PriceActionServiceImpl) DeleteFvg(ctx context.Context, sym string, d string) error {
-	return s.priceActionRepo.DeleteFvgByDate(ctx, sym, d)
+func (s *PriceActionServiceImpl) DeleteFvg(ctx context.Context, sym string, d string, tf model.Timeframe,
+	direction model.Direction) error {
+	return s.priceActionRepo.DeleteFvgByDate(ctx, sym, d, tf, direction)
 }
 
 // Shared logic to find the index and data for a specific date
-func (s *PriceActionServiceImpl) processHistory(ctx context.Context, stock string, date string) (string, []model.NSEHistoricalData, int, bool) {
+func (s *PriceActionServiceImpl) processHistory(ctx context.Context, stock string, date string) (string, []model.Candle, int, bool) {
 	m, exists := s.marginSvc.GetMargin(stock)
 	if !exists {
 		return "", nil, 0, false
 	}
 
-	// Uses your time cache strategy internally
-	history, err := s.nseService.FetchStockData(ctx, m.Symbol)
-	if err != nil || len(history) < 3 {
+	day, err := util.ParseDateKey(date)
+	if err != nil {
 		return "", nil, 0, false
 	}
 
+	// Reads from the candle store, so dates older than the in-memory month still resolve
+	candles, err := s.candleSvc.GetCandles(ctx, m.Symbol, day.AddDate(0, 0, -15), day)
+	if err != nil || len(candles) < 3 {
+		return "", nil, 0, false
+	}
+	history := newestFirst(candles)
+
 	for i := 0; i <= len(history)-3; i++ {
-		candleDate, err := util.ParseNseDate(history[i].Timestamp)
-		if err == nil && candleDate == date {
+		if util.DateKey(history[i].Time) == date {
 			return m.Symbol, history, i, true
 		}
 	}
 	return "", nil, 0, false
 }
 
-func (s *PriceActionServiceImpl) AddOlderOb(ctx context.Context, fileName string, file io.Reader, stopDate string) {
+func (s *PriceActionServiceImpl) AddOlderOb(ctx context.Context, fileName string, file io.Reader, stopDate string, tracker *JobTracker) error {
 	req, err := util.ReadCSVReversed(file, stopDate)
 	if err != nil {
-		return
+		return err
 	}
+	tracker.SetTotal(len(req))
 
 	count := 0
 	for _, stock := range req {
-		if symbol, history, i, found := s.processHistory(ctx, stock.Symbol, stock.Date); found {
-			target := history[i+2]
-			if actualDate, err := util.ParseNseDate(target.Timestamp); err == nil {
-				_ = s.priceActionRepo.SaveOrderBlock(ctx, model.ObRequest{
-					Symbol: symbol,
-					Date:   actualDate,
-					High:   target.High,
-					Low:    target.Low,
-				})
-				count++
-			}
+		symbol, history, i, found := s.processHistory(ctx, stock.Symbol, stock.Date)
+		if !found {
+			tracker.Skipped()
+			continue
+		}
+
+		target := history[i+2]
+		err := s.insertZone(ctx, model.ObRequest{
+			Symbol:    symbol,
+			Date:      util.DateKey(target.Time),
+			High:      target.High,
+			Low:       target.Low,
+			Timeframe: model.Timeframe1D,
+		}, true)
+		if errors.Is(err, ErrZoneExists) {
+			tracker.Skipped()
+			continue
+		}
+		if err != nil {
+			tracker.Fail(symbol, err)
+			continue
 		}
+		tracker.Inserted()
+		count++
 	}
 	log.Printf("%d Order block's inserted", count)
+	return nil
 }
 
-func (s *PriceActionServiceImpl) AddOlderFvg(ctx context.Context, fileName string, file io.Reader, stopDate string) {
+func (s *PriceActionServiceImpl) AddOlderFvg(ctx context.Context, fileName string, file io.Reader, stopDate string, tracker *JobTracker) error {
 	req, err := util.ReadCSVReversed(file, stopDate)
 	if err != nil {
-		return
+		return err
 	}
+	tracker.SetTotal(len(req))
 
 	count := 0
 	for _, stock := range req {
-		if symbol, history, i, found := s.processHistory(ctx, stock.Symbol, stock.Date); found {
-			if actualDate, err := util.ParseNseDate(history[i+1].Timestamp); err == nil {
-				_ = s.priceActionRepo.SaveFvg(ctx, model.ObRequest{
-					Symbol: symbol,
-					Date:   actualDate,
-					High:   history[i].Low,
-					Low:    history[i+2].High,
-				})
-				count++
-			}
+		symbol, history, i, found := s.processHistory(ctx, stock.Symbol, stock.Date)
+		if !found {
+			tracker.Skipped()
+			continue
 		}
+
+		err := s.insertZone(ctx, model.ObRequest{
+			Symbol:    symbol,
+			Date:      util.DateKey(history[i+1].Time),
+			High:      history[i].Low,
+			Low:       history[i+2].High,
+			Timeframe: model.Timeframe1D,
+		}, false)
+		if errors.Is(err, ErrZoneExists) {
+			tracker.Skipped()
+			continue
+		}
+		if err != nil {
+			tracker.Fail(symbol, err)
+			continue
+		}
+		tracker.Inserted()
+		count++
 	}
 	log.Printf("%d Fvg's inserted", count)
+	return nil
 }
 
-func (s *PriceActionServiceImpl) FvgCleanUp(ctx context.Context) error {
-	cleanCount := 0
+func (s *PriceActionServiceImpl) FvgCleanUp(ctx context.Context, tracker *JobTracker) error {
+	return s.refreshLifecycles(ctx, tracker, false)
+}
+
+func (s *PriceActionServiceImpl) OrderBlockCleanUp(ctx context.Context, tracker *JobTracker) error {
+	return s.refreshLifecycles(ctx, tracker, true)
+}
+
+// refreshLifecycles replays the candles after each active zone and stores its status, touch count and dates.
+// Zones are never deleted, so their history stays available for analysis.
+func (s *PriceActionServiceImpl) refreshLifecycles(ctx context.Context, tracker *JobTracker, isOB bool) error {
+	kind := "fvg"
+	update := s.priceActionRepo.UpdateFvgLifecycle
+	if isOB {
+		kind = "order block"
+		update = s.priceActionRepo.UpdateOrderBlockLifecycle
+	}
+
 	data, err := s.priceActionRepo.GetAllPriceAction(ctx)
 	if err != nil {
 		return err
 	}
+	tracker.SetTotal(len(data))
 
+	changed := make(map[model.ZoneStatus]int)
 	for _, record := range data {
-		if len(record.Fvg) == 0 {
+		zones := record.Fvg
+		if isOB {
+			zones = record.OrderBlocks
+		}
+
+		// Load candles once per timeframe, far enough back to cover the oldest active zone
+		oldest := make(map[model.Timeframe]string)
+		for _, info := range zones {
+			if !info.Status.IsActive() {
+				continue
+			}
+			tf := info.Timeframe.OrDefault()
+			if d, ok := oldest[tf]; !ok || info.Date < d {
+				oldest[tf] = info.Date
+			}
+		}
+		if len(oldest) == 0 {
+			tracker.Skipped()
 			continue
 		}
 
-		history, err := s.nseService.FetchStockData(ctx, record.Symbol)
-		if err != nil {
+		histories := make(map[model.Timeframe][]model.Candle)
+		failed := false
+		for tf, date := range oldest {
+			from, err := util.ParseCandleKey(date, tf)
+			if err == nil {
+				histories[tf], err = s.candleSvc.GetTimeframeCandles(ctx, record.Symbol, tf, from, time.Now())
+			}
+			if err != nil {
+				tracker.Fail(record.Symbol, err)
+				failed = true
+				break
+			}
+		}
+		if failed {
 			continue
 		}
 
-		for _, info := range record.Fvg {
-			fvgDate := info.Date
-			count := 0
-			delete := false
-			for _, candle := range history {
+		for _, info := range zones {
+			if !info.Status.IsActive() {
+				continue
+			}
+			next := lifecycle(info, histories[info.Timeframe.OrDefault()])
+			if next == info {
+				continue
+			}
+			if err := update(ctx, record.Symbol, next); err != nil {
+				tracker.Fail(record.Symbol, err)
+				continue
+			}
+			if next.Status != info.Status.OrDefault() {
+				changed[next.Status]++
+			}
+		}
+		tracker.Processed()
+	}
 
-				candleDate, _ := util.ParseNseDate(candle.Timestamp)
-				if fvgDate >= candleDate {
-					break
-				}
+	msg := fmt.Sprintf("%s lifecycle: %d tested, %d mitigated, %d invalidated", kind,
+		changed[model.ZoneTested], changed[model.ZoneMitigated], changed[model.ZoneInvalidated])
+	tracker.SetMessage(msg)
+	log.Println(msg)
+	return nil
+}
 
-				if candle.Close < info.Low || candle.Low < info.Low {
-					delete = true
-					break
-				}
+// lifecycle derives a zone's state from the ascending candles of its timeframe. Candles up to the zone's own
+// date and the candle that confirmed it are ignored; the first candle through the far side invalidates it, and
+// every candle that passes checkValidMitigation counts as a touch, the same rule as the mitigation check.
+func lifecycle(info model.Info, candles []model.Candle) model.Info {
+	tf := info.Timeframe.OrDefault()
+	next := info
+	next.Status = model.ZoneFresh
+	next.TouchCount = 0
+	next.MitigatedOn = ""
+	next.InvalidatedOn = ""
+
+	skipConfirmation := true
+	for _, candle := range candles {
+		date := util.CandleKey(candle.Time, tf)
+		if date <= info.Date {
+			continue
+		}
+		if skipConfirmation {
+			skipConfirmation = false
+			continue
+		}
 
-				if candle.Close > candle.Open && candle.Low < info.High {
-					count++
-				}
-			}
+		if isZoneBroken(candle, info) {
+			next.Status = model.ZoneInvalidated
+			next.InvalidatedOn = date
+			return next
+		}
 
-			if delete || count > 1 {
-				s.priceActionRepo.DeleteFvgByDate(ctx, record.Symbol, fvgDate)
-				cleanCount++
+		if checkValidMitigation(candle, info) {
+			next.TouchCount++
+			if next.MitigatedOn == "" {
+				next.MitigatedOn = date
 			}
 		}
 	}
 
-	log.Printf("Total cleaned fvg %d", cleanCount)
-	return nil
+	switch {
+	case next.TouchCount > 1:
+		next.Status = model.ZoneMitigated
+	case next.TouchCount == 1:
+		next.Status = model.ZoneTested
+	}
+	return next
 }
 
-func (s *PriceActionServiceImpl) checkValidMitigation(candle model.NSEHistoricalData, info model.Info) bool {
+// checkValidMitigation reports whether the candle traded into the zone from the zone's side without breaking it:
+// from above for bullish zones, from below for bearish ones.
+func checkValidMitigation(candle model.Candle, info model.Info) bool {
+	if info.Direction.OrDefault() == model.DirectionBearish {
+		if candle.Close > info.High || candle.High > info.High || candle.High < info.Low {
+			return false
+		}
+		return true
+	}
+
 	if candle.Close < info.Low || candle.Low < info.Low || candle.Low > info.High {
 		return false
 	}
 	return true
 }
 
-func (s *PriceActionServiceImpl) automationReschedule(candle model.NSEHistoricalData) bool {
-	loc, err := time.LoadLocation("Asia/Kolkata")
-	if err != nil {
-		return true
+// isZoneBroken reports whether the candle traded through the far side of the zone.
+func isZoneBroken(candle model.Candle, info model.Info) bool {
+	if info.Direction.OrDefault() == model.DirectionBearish {
+		return candle.Close > info.High || candle.High > info.High
 	}
+	return candle.Close < info.Low || candle.Low < info.Low
+}
 
-	now := time.Now().In(loc)
+// isCandleStale reports whether the latest candle predates today's session.
+func (s *PriceActionServiceImpl) isCandleStale(candle model.Candle) bool {
+	now := time.Now().In(util.MarketLocation())
 	day := now.Weekday()
 	if day == 0 || day == 6 {
 		return false
 	}
 
 	today := now.Format("2006-01-02")
-	candleDate, _ := util.ParseNseDate(candle.Timestamp)
-	return candleDate < today
+	return util.DateKey(candle.Time) < today
 }
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"backend/model"
+)
+
+var zoneDay0 = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+func zoneCandle(day int, open, high, low, close float64) model.Candle {
+	return model.Candle{Time: zoneDay0.AddDate(0, 0, day), Open: open, High: high, Low: low, Close: close}
+}
+
+// replayMitigationChecks runs the scheduled mitigation check once per candle, as the daily job would, and returns
+// the lifecycle it records.
+func replayMitigationChecks(info model.Info, candles []model.Candle) model.Info {
+	for i := range candles {
+		if !info.Status.IsActive() {
+			break
+		}
+		history := slices.Clone(candles[:i+1])
+		slices.Reverse(history)
+
+		broken, touched := checkZone(info, history)
+		if broken || touched {
+			info, _ = markLifecycle(info, history[0], broken)
+		}
+	}
+	return info
+}
+
+func TestLifecycleAgreesWithMitigationCheck(t *testing.T) {
+	bullish := model.Info{Date: "2025-01-06", High: 105, Low: 100, Timeframe: model.Timeframe1D,
+		Direction: model.DirectionBullish}
+	bearish := model.Info{Date: "2025-01-06", High: 105, Low: 100, Timeframe: model.Timeframe1D,
+		Direction: model.DirectionBearish}
+
+	tests := []struct {
+		name    string
+		info    model.Info
+		candles []model.Candle
+		want    model.ZoneStatus
+	}{
+		{
+			name: "untouched",
+			info: bullish,
+			candles: []model.Candle{
+				zoneCandle(0, 104, 105, 100, 101),
+				zoneCandle(1, 101, 110, 100.5, 109),
+				zoneCandle(2, 109, 112, 108, 111),
+			},
+			want: model.ZoneFresh,
+		},
+		{
+			name: "confirmation candle is ignored",
+			info: bullish,
+			candles: []model.Candle{
+				zoneCandle(0, 104, 105, 100, 101),
+				zoneCandle(1, 101, 110, 101, 109),
+				zoneCandle(2, 109, 112, 108, 111),
+			},
+			want: model.ZoneFresh,
+		},
+		{
+			name: "down close into a bullish zone is a touch",
+			info: bullish,
+			candles: []model.Candle{
+				zoneCandle(0, 104, 105, 100, 101),
+				zoneCandle(1, 101, 110, 100.5, 109),
+				zoneCandle(2, 109, 109.5, 103, 103.5),
+				zoneCandle(3, 103.5, 108, 103, 107),
+			},
+			want: model.ZoneMitigated,
+		},
+		{
+			name: "touched once then left",
+			info: bullish,
+			candles: []model.Candle{
+				zoneCandle(0, 104, 105, 100, 101),
+				zoneCandle(1, 101, 110, 100.5, 109),
+				zoneCandle(2, 109, 109.5, 104, 108),
+				zoneCandle(3, 108, 112, 107, 111),
+			},
+			want: model.ZoneTested,
+		},
+		{
+			name: "touched then broken",
+			info: bullish,
+			candles: []model.Candle{
+				zoneCandle(0, 104, 105, 100, 101),
+				zoneCandle(1, 101, 110, 100.5, 109),
+				zoneCandle(2, 109, 109.5, 104, 108),
+				zoneCandle(3, 108, 108.5, 98, 99),
+			},
+			want: model.ZoneInvalidated,
+		},
+		{
+			name: "up close into a bearish zone is a touch",
+			info: bearish,
+			candles: []model.Candle{
+				zoneCandle(0, 101, 105, 100, 104),
+				zoneCandle(1, 104, 104.5, 94, 95),
+				zoneCandle(2, 95, 102, 94.5, 101.5),
+				zoneCandle(3, 99, 99.5, 96, 97),
+			},
+			want: model.ZoneTested,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := lifecycle(tt.info, tt.candles)
+			checked := replayMitigationChecks(tt.info, tt.candles)
+
+			if cleanup.Status != tt.want {
+				t.Errorf("cleanup status = %s, want %s", cleanup.Status, tt.want)
+			}
+			// The check records the first touch; only the cleanup counts the later ones
+			wantChecked := tt.want
+			if wantChecked == model.ZoneMitigated {
+				wantChecked = model.ZoneTested
+			}
+			if checked.Status.OrDefault() != wantChecked {
+				t.Errorf("mitigation check status = %s, want %s", checked.Status.OrDefault(), wantChecked)
+			}
+			if checked.MitigatedOn != cleanup.MitigatedOn {
+				t.Errorf("mitigatedOn: check %q, cleanup %q", checked.MitigatedOn, cleanup.MitigatedOn)
+			}
+			if checked.InvalidatedOn != cleanup.InvalidatedOn {
+				t.Errorf("invalidatedOn: check %q, cleanup %q", checked.InvalidatedOn, cleanup.InvalidatedOn)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/cache"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+)
+
+// confluenceLimit bounds the ChartInk scans a confluence request runs at once
+const confluenceLimit = 4
+
+var (
+	ErrSnapshotNotFound = errors.New("scan snapshot not found")
+	ErrInvalidScanDiff  = errors.New("invalid scan diff")
+)
+
+// ScanService exposes the stored scan snapshots of each strategy and what changed between them. Snapshots of
+// strategies the user cannot see are reported as not found; userID is 0 for anonymous requests.
+type ScanService interface {
+	ListSnapshots(ctx context.Context, strategy string, limit int64, userID int64) ([]model.ScanSnapshot, error)
+	GetSnapshot(ctx context.Context, id string, userID int64) (*model.ScanSnapshot, error)
+	// Diff compares two snapshots of a strategy. Without to it uses the latest snapshot; without from, the latest
+	// snapshot taken before to's trading day, so the default answers "what qualified today that did not yesterday".
+	Diff(ctx context.Context, strategy, fromID, toID string, userID int64) (*model.ScanDiff, error)
+	// ScanAll runs every active public strategy once, which snapshots each result. Private strategies are only
+	// scanned when their users ask.
+	ScanAll(ctx context.Context, tracker *JobTracker) error
+	// Confluence runs several active strategies, every active one the user can see without names, and ranks the
+	// margin stocks returned by at least minMatches of them, most matches first.
+	Confluence(ctx context.Context, names []string, minMatches int, userID int64) (*model.Confluence, error)
+}
+
+type ScanServiceImpl struct {
+	repo            *repository.ScanRepository
+	chartInkSvc     ChartInkService
+	priceActionRepo *repository.PriceActionRepo
+}
+
+func NewScanService(repo *repository.ScanRepository, chartInkSvc ChartInkService,
+	priceActionRepo *repository.PriceActionRepo) ScanService {
+	return &ScanServiceImpl{repo: repo, chartInkSvc: chartInkSvc, priceActionRepo: priceActionRepo}
+}
+
+func (s *ScanServiceImpl) ListSnapshots(ctx context.Context, strategy string, limit int64, userID int64) ([]model.ScanSnapshot, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if _, found := lookupStrategy(strategy, userID); !found {
+		return []model.ScanSnapshot{}, nil
+	}
+	return s.repo.FindByStrategy(ctx, normalizeStrategy(strategy), limit)
+}
+
+func (s *ScanServiceImpl) GetSnapshot(ctx context.Context, id string, userID int64) (*model.ScanSnapshot, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrSnapshotNotFound
+	}
+
+	snapshot, err := s.repo.FindById(ctx, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrSnapshotNotFound
+	}
+	if _, found := lookupStrategy(snapshot.Strategy, userID); !found {
+		return nil, ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+func (s *ScanServiceImpl) Diff(ctx context.Context, strategy, fromID, toID string, userID int64) (*model.ScanDiff, error) {
+	strategy = normalizeStrategy(strategy)
+	if _, found := lookupStrategy(strategy, userID); !found {
+		return nil, fmt.Errorf("%w: no snapshots yet for %s", ErrSnapshotNotFound, strategy)
+	}
+
+	var to *model.ScanSnapshot
+	var err error
+	if toID != "" {
+		to, err = s.GetSnapshot(ctx, toID, userID)
+	} else {
+		to, err = s.latest(ctx, strategy)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var from *model.ScanSnapshot
+	if fromID != "" {
+		from, err = s.GetSnapshot(ctx, fromID, userID)
+	} else {
+		from, err = s.latestBefore(ctx, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Strategy != strategy || to.Strategy != strategy {
+		return nil, fmt.Errorf("%w: both snapshots must belong to %s", ErrInvalidScanDiff, strategy)
+	}
+	return diffSnapshots(from, to), nil
+}
+
+func (s *ScanServiceImpl) latest(ctx context.Context, strategy string) (*model.ScanSnapshot, error) {
+	snapshot, err := s.repo.FindLatest(ctx, strategy, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: no snapshots yet for %s", ErrSnapshotNotFound, strategy)
+	}
+	return snapshot, nil
+}
+
+// latestBefore returns the last snapshot of the trading day before the snapshot's.
+func (s *ScanServiceImpl) latestBefore(ctx context.Context, to *model.ScanSnapshot) (*model.ScanSnapshot, error) {
+	snapshot, err := s.repo.FindLatest(ctx, to.Strategy, util.StartOfDay(to.TakenAt))
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: no snapshot of %s before %s", ErrSnapshotNotFound, to.Strategy,
+			util.DateKey(to.TakenAt))
+	}
+	return snapshot, nil
+}
+
+func (s *ScanServiceImpl) ScanAll(ctx context.Context, tracker *JobTracker) error {
+	strategies := slices.DeleteFunc(activeStrategies(), func(s model.StrategyDto) bool { return !s.VisibleTo(0) })
+	tracker.SetTotal(len(strategies))
+
+	for _, strategy := range strategies {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := s.chartInkSvc.FetchData(strategy); err != nil {
+			tracker.Fail(strategy.Name, err)
+			continue
+		}
+		tracker.Processed()
+	}
+	tracker.SetMessage(fmt.Sprintf("%d strategies scanned", len(strategies)))
+	return nil
+}
+
+func (s *ScanServiceImpl) Confluence(ctx context.Context, names []string, minMatches int, userID int64) (*model.Confluence, error) {
+	strategies, err := selectStrategies(names, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(strategies) < 2 {
+		return nil, fmt.Errorf("%w: confluence needs at least two active strategies", ErrInvalidStrategy)
+	}
+	if minMatches <= 0 {
+		minMatches = 2
+	}
+
+	// Scans share the ChartInk token and its per-strategy cache, so repeated requests within a minute are cheap
+	results := make([][]model.StockMarginDto, len(strategies))
+	errs := make([]error, len(strategies))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(confluenceLimit)
+	for i, strategy := range strategies {
+		g.Go(func() error {
+			if gctx.Err() != nil {
+				errs[i] = gctx.Err()
+				return nil
+			}
+			results[i], errs[i] = s.chartInkSvc.FetchWithMargin(strategy)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	confluence := &model.Confluence{Strategies: make([]string, 0, len(strategies)), Stocks: []model.ConfluenceStock{}}
+	bySymbol := make(map[string]*model.ConfluenceStock)
+	for i, strategy := range strategies {
+		confluence.Strategies = append(confluence.Strategies, strategy.Name)
+		if errs[i] != nil {
+			if confluence.Failed == nil {
+				confluence.Failed = make(map[string]string)
+			}
+			confluence.Failed[strategy.Name] = errs[i].Error()
+			continue
+		}
+		for _, stock := range results[i] {
+			entry, ok := bySymbol[stock.Symbol]
+			if !ok {
+				entry = &model.ConfluenceStock{StockMarginDto: stock}
+				bySymbol[stock.Symbol] = entry
+			}
+			if !slices.Contains(entry.Strategies, strategy.Name) {
+				entry.Strategies = append(entry.Strategies, strategy.Name)
+				entry.Matches++
+			}
+		}
+	}
+	if len(confluence.Failed) == len(strategies) {
+		return nil, fmt.Errorf("all %d strategy scans failed: %w", len(strategies), errs[0])
+	}
+
+	symbols := make([]string, 0, len(bySymbol))
+	for symbol, entry := range bySymbol {
+		if entry.Matches >= minMatches {
+			symbols = append(symbols, symbol)
+		}
+	}
+	records, err := s.priceActionRepo.GetAllPAIn(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	zones := make(map[string]model.StockRecord, len(records))
+	for _, r := range records {
+		zones[r.Symbol] = r
+	}
+
+	for _, symbol := range symbols {
+		entry := bySymbol[symbol]
+		entry.OrderBlocks = filterByStatus(zones[symbol].OrderBlocks, activeStatuses)
+		entry.Fvg = filterByStatus(zones[symbol].Fvg, activeStatuses)
+		confluence.Stocks = append(confluence.Stocks, *entry)
+	}
+	slices.SortFunc(confluence.Stocks, func(a, b model.ConfluenceStock) int {
+		if a.Matches != b.Matches {
+			return b.Matches - a.Matches
+		}
+		if a.Margin != b.Margin {
+			if a.Margin > b.Margin {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+	return confluence, nil
+}
+
+// activeStrategies returns the cached active strategies sorted by name.
+func activeStrategies() []model.StrategyDto {
+	var strategies []model.StrategyDto
+	for _, item := range cache.StrategyCache.Items() {
+		if strategy, ok := item.Object.(model.StrategyDto); ok && strategy.Active {
+			strategies = append(strategies, strategy)
+		}
+	}
+	slices.SortFunc(strategies, func(a, b model.StrategyDto) int { return strings.Compare(a.Name, b.Name) })
+	return strategies
+}
+
+// selectStrategies resolves strategy names against the active strategies the user can see, or returns all of them
+// without names.
+func selectStrategies(names []string, userID int64) ([]model.StrategyDto, error) {
+	active := slices.DeleteFunc(activeStrategies(), func(s model.StrategyDto) bool { return !s.VisibleTo(userID) })
+	if len(names) == 0 {
+		return active, nil
+	}
+
+	var selected []model.StrategyDto
+	for _, name := range names {
+		name = normalizeStrategy(name)
+		if name == "" || slices.ContainsFunc(selected, func(s model.StrategyDto) bool { return s.Name == name }) {
+			continue
+		}
+		i := slices.IndexFunc(active, func(s model.StrategyDto) bool { return s.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s is not an active strategy", ErrInvalidStrategy, name)
+		}
+		selected = append(selected, active[i])
+	}
+	return selected, nil
+}
+
+func diffSnapshots(from, to *model.ScanSnapshot) *model.ScanDiff {
+	diff := &model.ScanDiff{
+		Strategy: to.Strategy,
+		From:     *from,
+		To:       *to,
+		Entered:  []model.StockData{},
+		Exited:   []model.StockData{},
+		Retained: []string{},
+	}
+	diff.From.Stocks = nil
+	diff.To.Stocks = nil
+
+	for _, stock := range to.Stocks {
+		if _, found := slices.BinarySearch(from.Symbols, stock.NSECode); found {
+			diff.Retained = append(diff.Retained, stock.NSECode)
+		} else {
+			diff.Entered = append(diff.Entered, stock)
+		}
+	}
+	for _, stock := range from.Stocks {
+		if _, found := slices.BinarySearch(to.Symbols, stock.NSECode); !found {
+			diff.Exited = append(diff.Exited, stock)
+		}
+	}
+	slices.Sort(diff.Retained)
+	diff.Retained = slices.Compact(diff.Retained)
+	return diff
+}
+
+// normalizeStrategy matches the upper-cased names strategies are stored under.
+func normalizeStrategy(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
@@ -0,0 +1,515 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+)
+
+var (
+	ErrJobNotFound      = errors.New("scheduled job not found")
+	ErrJobAlreadyActive = errors.New("job is already running")
+)
+
+// SchedulerService runs recurring background jobs on cron schedules evaluated in market time.
+type SchedulerService interface {
+	Start(ctx context.Context)
+	ListJobs(ctx context.Context) ([]model.ScheduledJobDto, error)
+	ListRuns(ctx context.Context, job string, limit int64) ([]model.SchedulerRun, error)
+	RunNow(name string) (*model.Job, error)
+	// RunAllNow starts several jobs like RunNow. None is started if any is unknown, already running or cannot be
+	// recorded.
+	RunAllNow(names ...string) ([]*model.Job, error)
+}
+
+// scheduledJob is the static definition of a recurring task.
+type scheduledJob struct {
+	name        string
+	description string
+	spec        string
+	// after names a job whose successful run starts this one, so it works on the data that job produced. Such a
+	// job has no schedule of its own unless config overrides it.
+	after          string
+	tradingDayOnly bool
+	maxRetries     int
+	retryDelay     time.Duration
+	run            JobFunc
+}
+
+type SchedulerServiceImpl struct {
+	repo    *repository.SchedulerRepository
+	jobSvc  JobService
+	cfg     *config.ConfigManager
+	jobs    []scheduledJob
+	running map[string]bool
+	mu      sync.Mutex
+}
+
+// NewSchedulerService registers the candle sync, scan and price action jobs. Call Start to begin firing them.
+func NewSchedulerService(repo *repository.SchedulerRepository, jobSvc JobService, cfg *config.ConfigManager,
+	paService PriceActionService, candleSvc CandleService, detectionSvc DetectionService,
+	scanSvc ScanService) SchedulerService {
+	s := &SchedulerServiceImpl{
+		repo:    repo,
+		jobSvc:  jobSvc,
+		cfg:     cfg,
+		running: make(map[string]bool),
+	}
+
+	s.jobs = []scheduledJob{
+		{
+			name:           "candle-sync",
+			description:    "Store today's daily candles for every tracked symbol",
+			spec:           "50 15 * * 1-5",
+			tradingDayOnly: true,
+			maxRetries:     2,
+			retryDelay:     20 * time.Minute,
+			run:            candleSvc.Sync,
+		},
+		{
+			name:           "strategy-scan",
+			description:    "Snapshot the scan result of every active public strategy after market close",
+			spec:           "35 15 * * 1-5",
+			tradingDayOnly: true,
+			maxRetries:     1,
+			retryDelay:     10 * time.Minute,
+			run:            scanSvc.ScanAll,
+		},
+		{
+			name:           "ob-mitigation-check",
+			description:    "Refresh order block mitigations once the daily candles are synced",
+			after:          "candle-sync",
+			tradingDayOnly: true,
+			run: func(ctx context.Context, tracker *JobTracker) error {
+				data, err := paService.CheckOBMitigation(ctx, true)
+				tracker.SetMessage(fmt.Sprintf("%d order block mitigations", len(data)))
+				return err
+			},
+		},
+		{
+			name:           "fvg-mitigation-check",
+			description:    "Refresh FVG mitigations once the daily candles are synced",
+			after:          "candle-sync",
+			tradingDayOnly: true,
+			run: func(ctx context.Context, tracker *JobTracker) error {
+				data, err := paService.CheckFvgMitigation(ctx, true)
+				tracker.SetMessage(fmt.Sprintf("%d fvg mitigations", len(data)))
+				return err
+			},
+		},
+		{
+			name:           "bearish-ob-mitigation-check",
+			description:    "Refresh bearish order block mitigations once the daily candles are synced",
+			after:          "candle-sync",
+			tradingDayOnly: true,
+			run: func(ctx context.Context, tracker *JobTracker) error {
+				data, err := paService.CheckBearishOBMitigation(ctx, true)
+				tracker.SetMessage(fmt.Sprintf("%d bearish order block mitigations", len(data)))
+				return err
+			},
+		},
+		{
+			name:           "bearish-fvg-mitigation-check",
+			description:    "Refresh bearish FVG mitigations once the daily candles are synced",
+			after:          "candle-sync",
+			tradingDayOnly: true,
+			run: func(ctx context.Context, tracker *JobTracker) error {
+				data, err := paService.CheckBearishFvgMitigation(ctx, true)
+				tracker.SetMessage(fmt.Sprintf("%d bearish fvg mitigations", len(data)))
+				return err
+			},
+		},
+		{
+			name:           "ob-automation",
+			description:    "Detect and store new order blocks from the ChartInk scan",
+			spec:           "0 16 * * 1-5",
+			tradingDayOnly: true,
+			maxRetries:     2,
+			retryDelay:     25 * time.Minute,
+			run:            paService.AutomateOrderBlock,
+		},
+		{
+			name:           "fvg-automation",
+			description:    "Detect and store new fair value gaps from the ChartInk scan",
+			spec:           "5 16 * * 1-5",
+			tradingDayOnly: true,
+			maxRetries:     2,
+			retryDelay:     30 * time.Minute,
+			run:            paService.AutomateFvg,
+		},
+		{
+			name:           "zone-detection",
+			description:    "Detect new order blocks and FVGs from stored candles",
+			spec:           "10 16 * * 1-5",
+			tradingDayOnly: true,
+			maxRetries:     2,
+			retryDelay:     30 * time.Minute,
+			run:            detectionSvc.Scan,
+		},
+		{
+			name:           "fvg-cleanup",
+			description:    "Mark FVGs tested, mitigated or invalidated by recent candles",
+			spec:           "30 18 * * 1-5",
+			tradingDayOnly: true,
+			run:            paService.FvgCleanUp,
+		},
+		{
+			name:           "ob-cleanup",
+			description:    "Mark order blocks tested, mitigated or invalidated by recent candles",
+			spec:           "35 18 * * 1-5",
+			tradingDayOnly: true,
+			run:            paService.OrderBlockCleanUp,
+		},
+	}
+
+	return s
+}
+
+// Start launches one goroutine per job. It also catches up on runs missed while the server was down.
+func (s *SchedulerServiceImpl) Start(ctx context.Context) {
+	if s.cfg.GetConfig().Scheduler.Disabled {
+		log.Println("Scheduler disabled by config")
+		return
+	}
+
+	for _, job := range s.jobs {
+		if s.chained(job) {
+			continue
+		}
+		if _, err := s.schedule(job); err != nil {
+			log.Printf("Scheduler: skipping job %s: %v", job.name, err)
+			continue
+		}
+		go s.loop(ctx, job)
+	}
+	log.Printf("Scheduler started with %d jobs", len(s.jobs))
+}
+
+// ListJobs returns every registered job with its next fire time and latest run.
+func (s *SchedulerServiceImpl) ListJobs(ctx context.Context) ([]model.ScheduledJobDto, error) {
+	now := time.Now().In(util.MarketLocation())
+	result := make([]model.ScheduledJobDto, 0, len(s.jobs))
+
+	for _, job := range s.jobs {
+		dto := model.ScheduledJobDto{
+			Name:           job.name,
+			Description:    job.description,
+			Schedule:       s.spec(job),
+			TradingDayOnly: job.tradingDayOnly,
+			Running:        s.isRunning(job.name),
+		}
+		if s.chained(job) {
+			// Runs when the job it follows next succeeds
+			dto.Schedule = "after " + job.after
+			if upstream, ok := s.findJob(job.after); ok {
+				job = upstream
+			}
+		}
+		if sched, err := s.schedule(job); err == nil {
+			dto.NextRun = s.nextRun(sched, job, now)
+		}
+
+		last, err := s.repo.FindLatest(ctx, job.name)
+		if err != nil {
+			return nil, err
+		}
+		dto.LastRun = last
+		result = append(result, dto)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextRun.Before(result[j].NextRun)
+	})
+	return result, nil
+}
+
+// ListRuns returns the run history, newest first.
+func (s *SchedulerServiceImpl) ListRuns(ctx context.Context, job string, limit int64) ([]model.SchedulerRun, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.FindRecent(ctx, job, limit)
+}
+
+// RunNow executes a job immediately in the background, honouring its retry policy.
+// The returned job tracks the first attempt and can be polled through the jobs API.
+func (s *SchedulerServiceImpl) RunNow(name string) (*model.Job, error) {
+	records, err := s.RunAllNow(name)
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func (s *SchedulerServiceImpl) RunAllNow(names ...string) ([]*model.Job, error) {
+	jobs := make([]scheduledJob, 0, len(names))
+	for _, name := range names {
+		job, ok := s.findJob(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+		}
+		jobs = append(jobs, job)
+	}
+	if busy, ok := s.markAllRunning(names); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobAlreadyActive, busy)
+	}
+
+	// Every job record is created before any job starts, so a failure leaves nothing running
+	records := make([]*model.Job, 0, len(jobs))
+	for _, job := range jobs {
+		record, err := s.jobSvc.Create(context.Background(), job.name, model.TriggerManual)
+		if err != nil {
+			s.abandon(records, fmt.Errorf("not started: creating the %s job failed: %w", job.name, err))
+			for _, name := range names {
+				s.markDone(name)
+			}
+			return nil, fmt.Errorf("%s: %w", job.name, err)
+		}
+		records = append(records, record)
+	}
+
+	snapshots := make([]*model.Job, 0, len(records))
+	for i, job := range jobs {
+		record := records[i]
+		snapshot := *record
+		snapshots = append(snapshots, &snapshot)
+		go func() {
+			defer s.markDone(job.name)
+			s.executeAttempts(context.Background(), job, model.TriggerManual, time.Now(), record)
+		}()
+	}
+	return snapshots, nil
+}
+
+// abandon records jobs that were created but will never run as failed, so they do not stay pending.
+func (s *SchedulerServiceImpl) abandon(records []*model.Job, reason error) {
+	for _, record := range records {
+		err := s.jobSvc.Execute(context.Background(), record, func(context.Context, *JobTracker) error {
+			return reason
+		})
+		log.Printf("Scheduler: job %s (%s) abandoned: %v", record.ID.Hex(), record.Type, err)
+	}
+}
+
+// --- Internal Helpers ---
+
+func (s *SchedulerServiceImpl) loop(ctx context.Context, job scheduledJob) {
+	s.catchUp(ctx, job)
+
+	for {
+		sched, err := s.schedule(job)
+		if err != nil {
+			log.Printf("Scheduler: invalid schedule for %s: %v", job.name, err)
+			return
+		}
+
+		next := s.nextRun(sched, job, time.Now().In(util.MarketLocation()))
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Config can be hot-swapped, so re-check before firing
+		if s.cfg.GetConfig().Scheduler.Disabled {
+			continue
+		}
+		s.execute(ctx, job, model.TriggerScheduled, next)
+	}
+}
+
+// catchUp runs a job once at startup if its most recent scheduled slot today was missed.
+func (s *SchedulerServiceImpl) catchUp(ctx context.Context, job scheduledJob) {
+	sched, err := s.schedule(job)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().In(util.MarketLocation())
+	prev := sched.Prev(now)
+	if prev.IsZero() || prev.Format("2006-01-02") != now.Format("2006-01-02") {
+		return
+	}
+	if job.tradingDayOnly && !util.IsTradingDay(prev, s.cfg.GetConfig().NseHolidays) {
+		return
+	}
+
+	last, err := s.repo.FindLatestSuccess(ctx, job.name)
+	if err != nil || (last != nil && !last.ScheduledFor.Before(prev)) {
+		return
+	}
+
+	log.Printf("Scheduler: catching up missed run of %s scheduled for %s", job.name, prev.Format(time.RFC3339))
+	s.execute(ctx, job, model.TriggerCatchUp, prev)
+}
+
+// execute runs the job unless a previous run is still active.
+func (s *SchedulerServiceImpl) execute(ctx context.Context, job scheduledJob, trigger model.RunTrigger, scheduledFor time.Time) {
+	if !s.markRunning(job.name) {
+		log.Printf("Scheduler: %s still running, skipping this slot", job.name)
+		return
+	}
+	defer s.markDone(job.name)
+
+	s.executeAttempts(ctx, job, trigger, scheduledFor, nil)
+}
+
+// executeAttempts persists each attempt and retries failures after the job's retry delay.
+// first is an optional pre-created job record for the first attempt.
+func (s *SchedulerServiceImpl) executeAttempts(ctx context.Context, job scheduledJob, trigger model.RunTrigger,
+	scheduledFor time.Time, first *model.Job) {
+	record := first
+	for attempt := 0; attempt <= job.maxRetries; attempt++ {
+		if attempt > 0 {
+			trigger = model.TriggerRetry
+			log.Printf("Scheduler: retrying %s in %s (attempt %d)", job.name, job.retryDelay, attempt+1)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(job.retryDelay):
+			}
+		}
+
+		err := s.runOnce(ctx, job, trigger, scheduledFor, attempt+1, record)
+		if err == nil {
+			s.runChained(ctx, job)
+			return
+		}
+		record = nil
+	}
+}
+
+// runChained starts the jobs that run after job in the background.
+func (s *SchedulerServiceImpl) runChained(ctx context.Context, job scheduledJob) {
+	for _, next := range s.jobs {
+		if next.after == job.name && s.chained(next) {
+			go s.execute(ctx, next, model.TriggerChained, time.Now())
+		}
+	}
+}
+
+func (s *SchedulerServiceImpl) runOnce(ctx context.Context, job scheduledJob, trigger model.RunTrigger,
+	scheduledFor time.Time, attempt int, record *model.Job) (err error) {
+	if record == nil {
+		if record, err = s.jobSvc.Create(ctx, job.name, trigger); err != nil {
+			log.Printf("Scheduler: failed to create job record for %s: %v", job.name, err)
+			return err
+		}
+	}
+
+	run := &model.SchedulerRun{
+		Job:          job.name,
+		Trigger:      trigger,
+		Attempt:      attempt,
+		ScheduledFor: scheduledFor,
+		StartedAt:    time.Now(),
+		Status:       model.RunStatusRunning,
+		JobID:        record.ID,
+	}
+	if insertErr := s.repo.Insert(ctx, run); insertErr != nil {
+		log.Printf("Scheduler: failed to record run of %s: %v", job.name, insertErr)
+	}
+
+	defer func() {
+		finished := time.Now()
+		run.FinishedAt = &finished
+		run.Status = model.RunStatusSuccess
+		if err != nil {
+			run.Status = model.RunStatusFailed
+			run.Error = err.Error()
+			log.Printf("Scheduler: %s failed: %v", job.name, err)
+		}
+		if !run.ID.IsZero() {
+			if updateErr := s.repo.Update(context.Background(), run); updateErr != nil {
+				log.Printf("Scheduler: failed to update run of %s: %v", job.name, updateErr)
+			}
+		}
+	}()
+
+	return s.jobSvc.Execute(ctx, record, job.run)
+}
+
+// nextRun finds the next fire time, skipping weekends and NSE holidays for trading-day jobs.
+func (s *SchedulerServiceImpl) nextRun(sched *util.CronSchedule, job scheduledJob, from time.Time) time.Time {
+	next := sched.Next(from)
+	for i := 0; i < 366 && !next.IsZero(); i++ {
+		if !job.tradingDayOnly || util.IsTradingDay(next, s.cfg.GetConfig().NseHolidays) {
+			return next
+		}
+		next = sched.Next(next)
+	}
+	return next
+}
+
+func (s *SchedulerServiceImpl) schedule(job scheduledJob) (*util.CronSchedule, error) {
+	return util.ParseCron(s.spec(job))
+}
+
+// spec returns the configured cron override for a job, or its default.
+func (s *SchedulerServiceImpl) spec(job scheduledJob) string {
+	if override, ok := s.cfg.GetConfig().Scheduler.Schedules[job.name]; ok && override != "" {
+		return override
+	}
+	return job.spec
+}
+
+// chained reports whether a job is started by the job it follows rather than on a schedule.
+func (s *SchedulerServiceImpl) chained(job scheduledJob) bool {
+	return job.after != "" && s.spec(job) == ""
+}
+
+func (s *SchedulerServiceImpl) findJob(name string) (scheduledJob, bool) {
+	for _, job := range s.jobs {
+		if job.name == name {
+			return job, true
+		}
+	}
+	return scheduledJob{}, false
+}
+
+func (s *SchedulerServiceImpl) isRunning(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running[name]
+}
+
+func (s *SchedulerServiceImpl) markRunning(name string) bool {
+	_, ok := s.markAllRunning([]string{name})
+	return ok
+}
+
+// markAllRunning marks every job running, or none of them and the one that already is.
+func (s *SchedulerServiceImpl) markAllRunning(names []string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range names {
+		if s.running[name] {
+			return name, false
+		}
+	}
+	for _, name := range names {
+		s.running[name] = true
+	}
+	return "", true
+}
+
+func (s *SchedulerServiceImpl) markDone(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
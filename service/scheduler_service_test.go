@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/model"
+	"backend/util"
+)
+
+func TestSchedulerNextRunSkipsHolidays(t *testing.T) {
+	loc := util.MarketLocation()
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2025, month, day, hour, minute, 0, 0, loc)
+	}
+	s := &SchedulerServiceImpl{cfg: config.NewConfigManager(&model.MongoEnvConfig{
+		NseHolidays: []string{"2025-02-26", "2025-03-14", "2025-03-31"},
+	})}
+
+	tests := []struct {
+		name string
+		job  scheduledJob
+		from time.Time
+		want time.Time
+	}{
+		{name: "trading day", job: scheduledJob{spec: "30 16 * * 1-5", tradingDayOnly: true},
+			from: at(2, 25, 10, 0), want: at(2, 25, 16, 30)},
+		{name: "midweek holiday", job: scheduledJob{spec: "30 16 * * 1-5", tradingDayOnly: true},
+			from: at(2, 25, 17, 0), want: at(2, 27, 16, 30)},
+		{name: "friday holiday and the weekend", job: scheduledJob{spec: "30 16 * * 1-5", tradingDayOnly: true},
+			from: at(3, 13, 17, 0), want: at(3, 17, 16, 30)},
+		{name: "monday holiday at a month end", job: scheduledJob{spec: "30 16 * * *", tradingDayOnly: true},
+			from: at(3, 28, 17, 0), want: at(4, 1, 16, 30)},
+		{name: "holiday judged in market time", job: scheduledJob{spec: "30 16 * * 1-5", tradingDayOnly: true},
+			from: time.Date(2025, 2, 25, 12, 0, 0, 0, time.UTC).In(loc), want: at(2, 27, 16, 30)},
+		{name: "jobs that also run on holidays", job: scheduledJob{spec: "30 16 * * *"},
+			from: at(2, 25, 17, 0), want: at(2, 26, 16, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := util.ParseCron(tt.job.spec)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if got := s.nextRun(sched, tt.job, tt.from); !got.Equal(tt.want) {
+				t.Errorf("nextRun(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"backend/detection"
+	"backend/model"
+	"backend/repository"
+)
+
+const (
+	defaultSizingCapital = 100000
+	defaultRiskPct       = 1
+)
+
+var (
+	ErrInvalidSizing = errors.New("invalid sizing request")
+	ErrZoneNotFound  = errors.New("zone not found")
+)
+
+// SizingService sizes trades from a risk budget and the symbol's margin.
+type SizingService interface {
+	Size(ctx context.Context, userID int64, req model.SizingRequest) (*model.SizingResult, error)
+}
+
+type SizingServiceImpl struct {
+	userSvc         UserService
+	priceActionRepo *repository.PriceActionRepo
+	candleSvc       CandleService
+	marginSvc       MarginService
+}
+
+func NewSizingService(userSvc UserService, repo *repository.PriceActionRepo, candleSvc CandleService,
+	marginSvc MarginService) SizingService {
+	return &SizingServiceImpl{
+		userSvc:         userSvc,
+		priceActionRepo: repo,
+		candleSvc:       candleSvc,
+		marginSvc:       marginSvc,
+	}
+}
+
+func (s *SizingServiceImpl) Size(ctx context.Context, userID int64, req model.SizingRequest) (*model.SizingResult, error) {
+	side := req.Side.OrDefault()
+	if !side.IsValid() {
+		return nil, fmt.Errorf("%w: side must be LONG or SHORT", ErrInvalidSizing)
+	}
+	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
+
+	result := &model.SizingResult{
+		Symbol:     symbol,
+		Side:       side,
+		Capital:    req.Capital,
+		RiskPct:    req.RiskPct,
+		Entry:      req.Entry,
+		StopLoss:   req.StopLoss,
+		StopSource: model.StopFromRequest,
+		Leverage:   1,
+	}
+	s.applyDefaults(ctx, userID, result)
+
+	if result.Entry == 0 {
+		entry, err := s.latestClose(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		result.Entry = entry
+	}
+
+	if result.StopLoss == 0 {
+		zone, info, err := s.findZone(ctx, symbol, side, req.Zone)
+		if err != nil {
+			return nil, err
+		}
+		result.Zone = zone
+		result.StopSource = model.StopFromZone
+		result.StopLoss = info.Low
+		if side == model.SideShort {
+			result.StopLoss = info.High
+		}
+	}
+
+	result.RiskPerShare = side.Sign() * (result.Entry - result.StopLoss)
+	if result.RiskPerShare <= 0 {
+		return nil, fmt.Errorf("%w: stop loss %.2f is on the wrong side of entry %.2f", ErrInvalidSizing,
+			result.StopLoss, result.Entry)
+	}
+
+	if m, found := s.marginSvc.GetMargin(symbol); found && m.Margin > 0 {
+		result.Leverage = float64(m.Margin)
+	}
+
+	result.RiskAmount = result.Capital * result.RiskPct / 100
+	result.Quantity = int(result.RiskAmount / result.RiskPerShare)
+	// A tight stop can ask for more than the capital can fund even with leverage
+	if affordable := int(result.Capital * result.Leverage / result.Entry); result.Quantity > affordable {
+		result.Quantity = affordable
+		result.CappedByCapital = true
+	}
+
+	qty := float64(result.Quantity)
+	result.PositionValue = round2(qty * result.Entry)
+	result.CapitalRequired = round2(qty * result.Entry / result.Leverage)
+	result.MaxLoss = round2(qty * result.RiskPerShare)
+	result.RiskAmount = round2(result.RiskAmount)
+	result.RiskPerShare = round2(result.RiskPerShare)
+	return result, nil
+}
+
+// applyDefaults fills capital and risk from the user's profile, then from the package defaults.
+func (s *SizingServiceImpl) applyDefaults(ctx context.Context, userID int64, result *model.SizingResult) {
+	if result.Capital == 0 || result.RiskPct == 0 {
+		user, err := s.userSvc.FindUser(ctx, 0, "", userID)
+		if err != nil {
+			log.Printf("Sizing: could not load defaults of user %d: %v", userID, err)
+		} else {
+			if result.Capital == 0 {
+				result.Capital = user.DefaultCapital
+			}
+			if result.RiskPct == 0 {
+				result.RiskPct = user.DefaultRiskPct
+			}
+		}
+	}
+
+	if result.Capital == 0 {
+		result.Capital = defaultSizingCapital
+	}
+	if result.RiskPct == 0 {
+		result.RiskPct = defaultRiskPct
+	}
+}
+
+func (s *SizingServiceImpl) latestClose(ctx context.Context, symbol string) (float64, error) {
+	now := time.Now()
+	candles, err := s.candleSvc.GetCandles(ctx, symbol, now.Add(-priceLookback), now)
+	if err != nil {
+		return 0, err
+	}
+	if len(candles) == 0 {
+		return 0, fmt.Errorf("%w: no recent price for %s, entry is required", ErrInvalidSizing, symbol)
+	}
+	return candles[len(candles)-1].Close, nil
+}
+
+// findZone resolves the zone a stop is taken from. With a date it must match exactly; without one the newest
+// active zone of the side's direction is used.
+func (s *SizingServiceImpl) findZone(ctx context.Context, symbol string, side model.TradeSide,
+	ref *model.TradeZone) (*model.TradeZone, model.Info, error) {
+	zone := model.TradeZone{Kind: detection.KindOrderBlock}
+	if ref != nil {
+		zone = *ref
+		if zone.Kind == "" {
+			zone.Kind = detection.KindOrderBlock
+		}
+	}
+	zone.Timeframe = zone.Timeframe.OrDefault()
+	if zone.Kind != detection.KindOrderBlock && zone.Kind != detection.KindFvg {
+		return nil, model.Info{}, fmt.Errorf("%w: zone kind must be OB or FVG", ErrInvalidSizing)
+	}
+	if !zone.Timeframe.IsValid() {
+		return nil, model.Info{}, fmt.Errorf("%w: invalid zone timeframe %q", ErrInvalidSizing, zone.Timeframe)
+	}
+
+	records, err := s.priceActionRepo.GetAllPAIn(ctx, []string{symbol})
+	if err != nil {
+		return nil, model.Info{}, err
+	}
+	var infos []model.Info
+	for _, record := range records {
+		infos = record.OrderBlocks
+		if zone.Kind == detection.KindFvg {
+			infos = record.Fvg
+		}
+	}
+
+	direction := model.DirectionBullish
+	if side == model.SideShort {
+		direction = model.DirectionBearish
+	}
+
+	var best *model.Info
+	for i, info := range infos {
+		if info.Timeframe.OrDefault() != zone.Timeframe {
+			continue
+		}
+		if zone.Date != "" {
+			if info.Date == zone.Date {
+				best = &infos[i]
+				break
+			}
+			continue
+		}
+		if info.Direction.OrDefault() != direction || !info.Status.IsActive() {
+			continue
+		}
+		if best == nil || info.Date > best.Date {
+			best = &infos[i]
+		}
+	}
+	if best == nil {
+		return nil, model.Info{}, fmt.Errorf("%w: no %s %s zone for %s; pass a stop loss", ErrZoneNotFound,
+			zone.Timeframe, zone.Kind, symbol)
+	}
+
+	zone.Date = best.Date
+	return &zone, *best, nil
+}
@@ -2,32 +2,84 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"backend/cache"
+	"backend/customerrors"
 	"backend/model"
 	"backend/repository"
 )
 
+var (
+	ErrInvalidStrategy         = errors.New("invalid strategy")
+	ErrStrategyNotFound        = errors.New("strategy not found")
+	ErrStrategyVersionNotFound = errors.New("strategy version not found")
+	ErrStrategyExists          = errors.New("strategy name already taken")
+	ErrScanClauseUnchecked     = errors.New("could not check scan clause")
+)
+
+// ScanClauseError rejects activating a strategy whose scan clause failed its dry run.
+type ScanClauseError struct {
+	Preview *model.ScanPreview
+}
+
+func (e *ScanClauseError) Error() string {
+	return fmt.Sprintf("%v: scan clause failed its dry run: %s", ErrInvalidStrategy, e.Preview.Error.Message)
+}
+
+func (e *ScanClauseError) Unwrap() error {
+	return ErrInvalidStrategy
+}
+
 // StrategyService defines the contract for managing trading strategies.
 type StrategyService interface {
 	ReloadAllStrategies(ctx context.Context) error
-	GetAllStrategies() []model.StrategyDto
-	CreateStrategy(ctx context.Context, request model.StrategyDto) (model.StrategyDto, error)
-	UpdateStrategy(ctx context.Context, request model.StrategyDto) (model.StrategyDto, error)
+	// GetAllStrategies returns the active strategies a user can see; userID is 0 for anonymous requests.
+	GetAllStrategies(userID int64) []model.StrategyDto
+	// GetStrategy returns an active strategy by name if the user can see it.
+	GetStrategy(name string, userID int64) (model.StrategyDto, bool)
+	// CreateStrategy and UpdateStrategy save any strategy as an admin, recording a new version whenever its scan
+	// clause changes. New strategies are public unless the request says otherwise. With validate the clause is dry
+	// run first and its preview returned; an active strategy whose clause fails is refused with a ScanClauseError.
+	CreateStrategy(ctx context.Context, request model.StrategyDto, userID int64, validate bool) (model.StrategyDto, error)
+	UpdateStrategy(ctx context.Context, request model.StrategyDto, userID int64, validate bool) (model.StrategyDto, error)
 	DeleteStrategy(ctx context.Context, id string) error
 	GetAllStrategiesAdmin() []model.StrategyDto
+	// ListVersions returns a strategy's scan clause history, newest first.
+	ListVersions(ctx context.Context, id string) ([]model.StrategyVersion, error)
+	// RestoreVersion makes an earlier scan clause current again, recorded as a new version. The clause of an active
+	// strategy is dry run first, as with validate in CreateStrategy.
+	RestoreVersion(ctx context.Context, id string, version int, userID int64) (model.StrategyDto, error)
+
+	// GetUserStrategies returns the strategies a user owns, including inactive ones.
+	GetUserStrategies(userID int64) []model.StrategyDto
+	// SaveUserStrategy creates or updates a strategy the user owns. New ones are private. validate works as in
+	// CreateStrategy.
+	SaveUserStrategy(ctx context.Context, request model.StrategyDto, userID int64, validate bool) (model.StrategyDto, error)
+	// PreviewScanClause dry runs a scan clause without saving anything.
+	PreviewScanClause(ctx context.Context, scanClause string) (*model.ScanPreview, error)
+	DeleteUserStrategy(ctx context.Context, id string, userID int64) error
+	// ShareStrategy sets a strategy's visibility. Only its owner, or an admin, may change it.
+	ShareStrategy(ctx context.Context, id string, req model.StrategySharingRequest, userID int64, admin bool) (model.StrategyDto, error)
 }
 
 // StrategyServiceImpl implements StrategyService using a repository and a global cache.
 type StrategyServiceImpl struct {
-	repo *repository.StrategyRepository
+	repo        *repository.StrategyRepository
+	userSvc     UserService
+	chartInkSvc ChartInkService
 }
 
 // NewStrategyService initializes the service and performs an initial data load into the cache.
-func NewStrategyService(repo *repository.StrategyRepository) StrategyService {
+func NewStrategyService(repo *repository.StrategyRepository, userSvc UserService, chartInkSvc ChartInkService) StrategyService {
 	s := &StrategyServiceImpl{
-		repo: repo,
+		repo:        repo,
+		userSvc:     userSvc,
+		chartInkSvc: chartInkSvc,
 	}
 
 	// Initial load to populate cache on startup
@@ -47,11 +99,7 @@ func (s *StrategyServiceImpl) ReloadAllStrategies(ctx context.Context) error {
 	cache.StrategyCache.Flush()
 
 	for _, strategy := range strategies {
-		dto := model.StrategyDto{
-			Name:       strategy.Name,
-			ScanClause: strategy.ScanClause,
-			Active:     strategy.Active,
-		}
+		dto := strategy.ToDto()
 
 		// Set with NoExpiration (-1)
 		cache.StrategyCache.Set(dto.Name, dto, -1)
@@ -60,38 +108,315 @@ func (s *StrategyServiceImpl) ReloadAllStrategies(ctx context.Context) error {
 	return nil
 }
 
-// GetAllStrategies returns only active strategies for public consumption.
-func (s *StrategyServiceImpl) GetAllStrategies() []model.StrategyDto {
-	return s.filterStrategies(false)
+// GetAllStrategies returns only active strategies visible to the user.
+func (s *StrategyServiceImpl) GetAllStrategies(userID int64) []model.StrategyDto {
+	list := s.filterStrategies(func(strategy model.StrategyDto) bool {
+		return strategy.Active && strategy.VisibleTo(userID)
+	})
+	for i := range list {
+		// Who else a strategy is shared with is the owner's business
+		if list[i].Owner != userID {
+			list[i].SharedWith = nil
+		}
+	}
+	return list
+}
+
+func (s *StrategyServiceImpl) GetStrategy(name string, userID int64) (model.StrategyDto, bool) {
+	strategy, found := lookupStrategy(name, userID)
+	if !found || !strategy.Active {
+		return model.StrategyDto{}, false
+	}
+	return strategy, true
 }
 
 // GetAllStrategiesAdmin returns all strategies (including inactive) for administrative use.
 func (s *StrategyServiceImpl) GetAllStrategiesAdmin() []model.StrategyDto {
-	return s.filterStrategies(true)
+	return s.filterStrategies(func(model.StrategyDto) bool { return true })
 }
 
 // CreateStrategy persists a new strategy and updates the cache immediately.
-func (s *StrategyServiceImpl) CreateStrategy(ctx context.Context, request model.StrategyDto) (model.StrategyDto, error) {
-	entity := request.ToEntity()
+func (s *StrategyServiceImpl) CreateStrategy(ctx context.Context, request model.StrategyDto, userID int64,
+	validate bool) (model.StrategyDto, error) {
+	entity, err := prepareStrategy(request)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	if entity.Visibility != "" && !entity.Visibility.IsValid() {
+		return model.StrategyDto{}, fmt.Errorf("%w: unknown visibility %q", ErrInvalidStrategy, entity.Visibility)
+	}
+	preview, err := s.dryRun(ctx, entity, validate)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+
+	existing, err := s.repo.FindById(ctx, entity.Name)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	// Sharing lists are only managed through ShareStrategy
+	entity.SharedWith = nil
+	if existing != nil {
+		entity.SharedWith = existing.SharedWith
+		if entity.Visibility == "" {
+			entity.Visibility = existing.Visibility
+		}
+	}
+	if entity.Visibility == "" {
+		entity.Visibility = model.StrategyPublic
+	}
+	if entity.Visibility != model.StrategyShared {
+		entity.SharedWith = nil
+	}
+	dto, err := s.save(ctx, entity, existing, userID, 0)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	dto.Preview = preview
+	return dto, nil
+}
+
+// UpdateStrategy reuses the creation logic to ensure identical persistence/caching behavior.
+func (s *StrategyServiceImpl) UpdateStrategy(ctx context.Context, request model.StrategyDto, userID int64,
+	validate bool) (model.StrategyDto, error) {
+	return s.CreateStrategy(ctx, request, userID, validate)
+}
+
+func (s *StrategyServiceImpl) ListVersions(ctx context.Context, id string) ([]model.StrategyVersion, error) {
+	existing, err := s.repo.FindById(ctx, normalizeStrategy(id))
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrStrategyNotFound
+	}
+
+	versions, err := s.repo.FindVersions(ctx, existing.Name)
+	if err != nil {
+		return nil, err
+	}
+	// Strategies not edited since versioning only have their current clause
+	if len(versions) == 0 {
+		versions = append(versions, legacyVersion(existing))
+	}
+	return versions, nil
+}
+
+func (s *StrategyServiceImpl) RestoreVersion(ctx context.Context, id string, version int, userID int64) (model.StrategyDto, error) {
+	existing, err := s.repo.FindById(ctx, normalizeStrategy(id))
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	if existing == nil {
+		return model.StrategyDto{}, ErrStrategyNotFound
+	}
+
+	target, err := s.repo.FindVersion(ctx, existing.Name, version)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	if target == nil {
+		return model.StrategyDto{}, fmt.Errorf("%w: %s has no version %d", ErrStrategyVersionNotFound, existing.Name, version)
+	}
+
+	entity := *existing
+	entity.ScanClause = target.ScanClause
+	preview, err := s.dryRun(ctx, entity, entity.Active)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	dto, err := s.save(ctx, entity, existing, userID, target.Version)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	dto.Preview = preview
+	return dto, nil
+}
+
+func (s *StrategyServiceImpl) GetUserStrategies(userID int64) []model.StrategyDto {
+	return s.filterStrategies(func(strategy model.StrategyDto) bool { return strategy.Owner == userID })
+}
+
+func (s *StrategyServiceImpl) SaveUserStrategy(ctx context.Context, request model.StrategyDto, userID int64,
+	validate bool) (model.StrategyDto, error) {
+	entity, err := prepareStrategy(request)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+
+	existing, err := s.repo.FindById(ctx, entity.Name)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	// Names are shared by every user, and strategies from before ownership belong to no one
+	if existing != nil && existing.Owner != userID {
+		return model.StrategyDto{}, fmt.Errorf("%w: %s", ErrStrategyExists, entity.Name)
+	}
+
+	preview, err := s.dryRun(ctx, entity, validate)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+
+	entity.Visibility = model.StrategyPrivate
+	entity.SharedWith = nil
+	if existing != nil {
+		entity.Visibility = existing.Visibility
+		entity.SharedWith = existing.SharedWith
+	}
+	dto, err := s.save(ctx, entity, existing, userID, 0)
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	dto.Preview = preview
+	return dto, nil
+}
+
+func (s *StrategyServiceImpl) PreviewScanClause(ctx context.Context, scanClause string) (*model.ScanPreview, error) {
+	if strings.TrimSpace(scanClause) == "" {
+		return nil, fmt.Errorf("%w: scan clause is required", ErrInvalidStrategy)
+	}
+	preview, err := s.chartInkSvc.Preview(ctx, scanClause)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScanClauseUnchecked, err)
+	}
+	return preview, nil
+}
+
+// dryRun previews a strategy's clause when validation was asked for, refusing an active strategy whose clause fails.
+// An inactive strategy is still saved, so a draft can be fixed later.
+func (s *StrategyServiceImpl) dryRun(ctx context.Context, entity model.Strategy, validate bool) (*model.ScanPreview, error) {
+	if !validate {
+		return nil, nil
+	}
+	preview, err := s.PreviewScanClause(ctx, entity.ScanClause)
+	if err != nil {
+		return nil, err
+	}
+	if !preview.Valid && entity.Active {
+		return nil, &ScanClauseError{Preview: preview}
+	}
+	return preview, nil
+}
+
+func (s *StrategyServiceImpl) DeleteUserStrategy(ctx context.Context, id string, userID int64) error {
+	existing, err := s.repo.FindById(ctx, normalizeStrategy(id))
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.Owner != userID {
+		return ErrStrategyNotFound
+	}
+	return s.DeleteStrategy(ctx, existing.Name)
+}
+
+func (s *StrategyServiceImpl) ShareStrategy(ctx context.Context, id string, req model.StrategySharingRequest, userID int64,
+	admin bool) (model.StrategyDto, error) {
+	if !req.Visibility.IsValid() {
+		return model.StrategyDto{}, fmt.Errorf("%w: unknown visibility %q", ErrInvalidStrategy, req.Visibility)
+	}
+
+	existing, err := s.repo.FindById(ctx, normalizeStrategy(id))
+	if err != nil {
+		return model.StrategyDto{}, err
+	}
+	if existing == nil || (!admin && existing.Owner != userID) {
+		return model.StrategyDto{}, ErrStrategyNotFound
+	}
+
+	entity := *existing
+	entity.Visibility = req.Visibility
+	entity.SharedWith = nil
+	if req.Visibility == model.StrategyShared {
+		for _, email := range req.SharedWith {
+			email = strings.TrimSpace(email)
+			if email == "" {
+				continue
+			}
+			user, err := s.userSvc.FindUser(ctx, 0, email, 0)
+			if errors.Is(err, customerrors.ErrUserNotFound) {
+				return model.StrategyDto{}, fmt.Errorf("%w: no user with email %s", ErrInvalidStrategy, email)
+			}
+			if err != nil {
+				return model.StrategyDto{}, err
+			}
+			if user.UserID != existing.Owner && !slices.Contains(entity.SharedWith, user.UserID) {
+				entity.SharedWith = append(entity.SharedWith, user.UserID)
+			}
+		}
+		if len(entity.SharedWith) == 0 {
+			return model.StrategyDto{}, fmt.Errorf("%w: a shared strategy needs at least one other user", ErrInvalidStrategy)
+		}
+	}
+	return s.save(ctx, entity, existing, userID, 0)
+}
+
+// save persists a strategy over its existing state, keeping its owner and creation time and recording a version
+// when the scan clause changed.
+func (s *StrategyServiceImpl) save(ctx context.Context, entity model.Strategy, existing *model.Strategy, userID int64,
+	restoredFrom int) (model.StrategyDto, error) {
+	now := time.Now()
+	entity.UpdatedAt = now
+	entity.Version = 0
+	if existing != nil {
+		entity.Owner = existing.Owner
+		entity.CreatedAt = existing.CreatedAt
+		entity.Version = existing.Version
+	} else {
+		entity.Owner = userID
+		entity.CreatedAt = now
+	}
+
+	if existing == nil || existing.ScanClause != entity.ScanClause {
+		// Numbered after the latest recorded version, in case an earlier save failed after recording its own
+		latest, err := s.repo.FindLatestVersion(ctx, entity.Name)
+		if err != nil {
+			return model.StrategyDto{}, err
+		}
+		if latest == nil && existing != nil {
+			// Keep the clause from before versioning so the edit can be rolled back
+			first := legacyVersion(existing)
+			if err := s.repo.InsertVersion(ctx, &first); err != nil {
+				return model.StrategyDto{}, err
+			}
+			latest = &first
+		}
+		if latest != nil {
+			entity.Version = max(entity.Version, latest.Version)
+		}
+		entity.Version++
+
+		if err := s.repo.InsertVersion(ctx, &model.StrategyVersion{
+			Strategy:     entity.Name,
+			Version:      entity.Version,
+			ScanClause:   entity.ScanClause,
+			EditedBy:     userID,
+			RestoredFrom: restoredFrom,
+			CreatedAt:    now,
+		}); err != nil {
+			return model.StrategyDto{}, err
+		}
+	}
+
 	if err := s.repo.Save(ctx, entity); err != nil {
 		return model.StrategyDto{}, err
 	}
+	// Scan results are cached by name, so drop those of the old clause or of a deleted strategy with the same name
+	if existing == nil || existing.ScanClause != entity.ScanClause {
+		cache.ChartInkResponseCache.Delete(entity.Name)
+	}
 
 	// Optimistic Cache Update: Update cache immediately so the user sees changes without delay
-	cache.StrategyCache.Set(request.Name, request, -1)
+	dto := entity.ToDto()
+	cache.StrategyCache.Set(dto.Name, dto, -1)
 
 	// Trigger a background full sync to ensure consistency
 	go s.backgroundReload()
 
-	return request, nil
+	return dto, nil
 }
 
-// UpdateStrategy reuses the creation logic to ensure identical persistence/caching behavior.
-func (s *StrategyServiceImpl) UpdateStrategy(ctx context.Context, request model.StrategyDto) (model.StrategyDto, error) {
-	return s.CreateStrategy(ctx, request)
-}
-
-// DeleteStrategy removes a strategy from the repository and the cache.
+// DeleteStrategy removes a strategy and its cached scan results.
 func (s *StrategyServiceImpl) DeleteStrategy(ctx context.Context, id string) error {
 	if err := s.repo.DeleteById(ctx, id); err != nil {
 		return err
@@ -99,6 +424,7 @@ func (s *StrategyServiceImpl) DeleteStrategy(ctx context.Context, id string) err
 
 	// Remove from cache using the ID (which acts as the Key)
 	cache.StrategyCache.Delete(id)
+	cache.ChartInkResponseCache.Delete(id)
 
 	return nil
 }
@@ -106,13 +432,13 @@ func (s *StrategyServiceImpl) DeleteStrategy(ctx context.Context, id string) err
 // --- Internal Helper Methods ---
 
 // filterStrategies handles the repetitive logic of iterating through the cache.
-func (s *StrategyServiceImpl) filterStrategies(includeInactive bool) []model.StrategyDto {
+func (s *StrategyServiceImpl) filterStrategies(keep func(model.StrategyDto) bool) []model.StrategyDto {
 	items := cache.StrategyCache.Items()
 	list := make([]model.StrategyDto, 0, len(items))
 
 	for _, item := range items {
 		if strategy, ok := item.Object.(model.StrategyDto); ok {
-			if includeInactive || strategy.Active {
+			if keep(strategy) {
 				list = append(list, strategy)
 			}
 		}
@@ -120,6 +446,56 @@ func (s *StrategyServiceImpl) filterStrategies(includeInactive bool) []model.Str
 	return list
 }
 
+// lookupStrategy returns a cached strategy by name, active or not, if the user can see it.
+func lookupStrategy(name string, userID int64) (model.StrategyDto, bool) {
+	raw, found := cache.StrategyCache.Get(normalizeStrategy(name))
+	if !found {
+		return model.StrategyDto{}, false
+	}
+	strategy, ok := raw.(model.StrategyDto)
+	if !ok || !strategy.VisibleTo(userID) {
+		return model.StrategyDto{}, false
+	}
+	return strategy, true
+}
+
+// prepareStrategy maps a request to an entity with normalized metadata, rejecting missing or unknown fields.
+func prepareStrategy(request model.StrategyDto) (model.Strategy, error) {
+	entity := request.ToEntity()
+	entity.Name = strings.TrimSpace(entity.Name)
+	entity.Category = strings.TrimSpace(entity.Category)
+	entity.Tags = normalizeTags(entity.Tags)
+	if entity.Name == "" || strings.TrimSpace(entity.ScanClause) == "" {
+		return model.Strategy{}, fmt.Errorf("%w: name and scan clause are required", ErrInvalidStrategy)
+	}
+	if entity.Timeframe != "" && !entity.Timeframe.IsValid() {
+		return model.Strategy{}, fmt.Errorf("%w: unsupported timeframe %q", ErrInvalidStrategy, entity.Timeframe)
+	}
+	return entity, nil
+}
+
+// legacyVersion is the first version of a strategy saved before versioning, holding its current clause.
+func legacyVersion(strategy *model.Strategy) model.StrategyVersion {
+	return model.StrategyVersion{
+		Strategy:   strategy.Name,
+		Version:    max(strategy.Version, 1),
+		ScanClause: strategy.ScanClause,
+		CreatedAt:  strategy.UpdatedAt,
+	}
+}
+
+// normalizeTags lower-cases and trims tags, dropping blanks and duplicates.
+func normalizeTags(tags []string) []string {
+	var out []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
 // backgroundReload provides a safe way to refresh the cache in a separate goroutine.
 func (s *StrategyServiceImpl) backgroundReload() {
 	bgCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
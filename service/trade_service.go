@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"backend/cache"
+	"backend/detection"
+	"backend/model"
+	"backend/repository"
+	"backend/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// priceLookback is how far back the latest candle of an open position is searched for
+const priceLookback = 10 * 24 * time.Hour
+
+var (
+	ErrTradeNotFound = errors.New("trade not found")
+	ErrTradeClosed   = errors.New("trade is already closed")
+	ErrInvalidTrade  = errors.New("invalid trade")
+)
+
+// TradeService keeps each user's trade journal and values their open positions.
+type TradeService interface {
+	ListTrades(ctx context.Context, userID int64, status model.TradeStatus) ([]model.Trade, error)
+	GetTrade(ctx context.Context, userID int64, id string) (*model.Trade, error)
+	CreateTrade(ctx context.Context, userID int64, req model.TradeRequest) (*model.Trade, error)
+	UpdateTrade(ctx context.Context, userID int64, id string, req model.TradeRequest) (*model.Trade, error)
+	CloseTrade(ctx context.Context, userID int64, id string, req model.CloseTradeRequest) (*model.Trade, error)
+	DeleteTrade(ctx context.Context, userID int64, id string) error
+	// GetPositions values the user's open trades at the latest candle.
+	GetPositions(ctx context.Context, userID int64) ([]model.Position, error)
+	// GetPnL totals realised P&L of closed trades and unrealised P&L of open ones.
+	GetPnL(ctx context.Context, userID int64) (*model.PnLSummary, error)
+	// GetStrategyStats groups the user's trades by linked strategy.
+	GetStrategyStats(ctx context.Context, userID int64) ([]model.StrategyStats, error)
+}
+
+type TradeServiceImpl struct {
+	repo      *repository.TradeRepository
+	candleSvc CandleService
+	marginSvc MarginService
+}
+
+func NewTradeService(repo *repository.TradeRepository, candleSvc CandleService, marginSvc MarginService) TradeService {
+	return &TradeServiceImpl{
+		repo:      repo,
+		candleSvc: candleSvc,
+		marginSvc: marginSvc,
+	}
+}
+
+func (s *TradeServiceImpl) ListTrades(ctx context.Context, userID int64, status model.TradeStatus) ([]model.Trade, error) {
+	return s.repo.FindByUser(ctx, userID, status)
+}
+
+func (s *TradeServiceImpl) GetTrade(ctx context.Context, userID int64, id string) (*model.Trade, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrTradeNotFound
+	}
+
+	trade, err := s.repo.FindById(ctx, userID, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if trade == nil {
+		return nil, ErrTradeNotFound
+	}
+	return trade, nil
+}
+
+func (s *TradeServiceImpl) CreateTrade(ctx context.Context, userID int64, req model.TradeRequest) (*model.Trade, error) {
+	now := time.Now()
+	trade := &model.Trade{
+		UserID:    userID,
+		Status:    model.TradeOpen,
+		CreatedAt: now,
+	}
+	if err := s.apply(trade, req, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Insert(ctx, trade); err != nil {
+		return nil, fmt.Errorf("failed to save trade: %w", err)
+	}
+	return trade, nil
+}
+
+// UpdateTrade edits the entry of a trade. A closed trade keeps its exit, exit charges and exit notes, and has its
+// realised P&L recomputed.
+func (s *TradeServiceImpl) UpdateTrade(ctx context.Context, userID int64, id string, req model.TradeRequest) (*model.Trade, error) {
+	trade, err := s.GetTrade(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(trade, req, time.Now()); err != nil {
+		return nil, err
+	}
+	if trade.Status == model.TradeClosed {
+		trade.RealisedPnL = netPnL(*trade, trade.ExitPrice)
+	}
+	return trade, s.save(ctx, trade)
+}
+
+func (s *TradeServiceImpl) CloseTrade(ctx context.Context, userID int64, id string, req model.CloseTradeRequest) (*model.Trade, error) {
+	trade, err := s.GetTrade(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if trade.Status == model.TradeClosed {
+		return nil, ErrTradeClosed
+	}
+
+	exitDate := util.DateKey(time.Now())
+	if req.ExitDate != "" {
+		if _, err := util.ParseDateKey(req.ExitDate); err != nil {
+			return nil, fmt.Errorf("%w: exit date must be YYYY-MM-DD", ErrInvalidTrade)
+		}
+		exitDate = req.ExitDate
+	}
+	if exitDate < trade.EntryDate {
+		return nil, fmt.Errorf("%w: exit date is before entry date", ErrInvalidTrade)
+	}
+
+	trade.Status = model.TradeClosed
+	trade.ExitPrice = req.ExitPrice
+	trade.ExitDate = exitDate
+	trade.ExitCharges = req.Charges
+	trade.Charges += req.Charges
+	trade.ExitNotes = strings.TrimSpace(req.Notes)
+	trade.RealisedPnL = netPnL(*trade, trade.ExitPrice)
+	trade.UpdatedAt = time.Now()
+	return trade, s.save(ctx, trade)
+}
+
+func (s *TradeServiceImpl) DeleteTrade(ctx context.Context, userID int64, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrTradeNotFound
+	}
+
+	found, err := s.repo.Delete(ctx, userID, objectId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrTradeNotFound
+	}
+	return nil
+}
+
+func (s *TradeServiceImpl) GetPositions(ctx context.Context, userID int64) ([]model.Position, error) {
+	trades, err := s.repo.FindByUser(ctx, userID, model.TradeOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	prices := make(map[string]*model.Candle)
+	positions := make([]model.Position, 0, len(trades))
+	for _, trade := range trades {
+		last, loaded := prices[trade.Symbol]
+		if !loaded {
+			candles, err := s.candleSvc.GetCandles(ctx, trade.Symbol, now.Add(-priceLookback), now)
+			if err != nil {
+				log.Printf("Positions: no candles for %s: %v", trade.Symbol, err)
+			}
+			if len(candles) > 0 {
+				last = &candles[len(candles)-1]
+			}
+			prices[trade.Symbol] = last
+		}
+
+		position := model.Position{
+			Trade:       trade,
+			CapitalUsed: round2(trade.EntryPrice * float64(trade.Quantity) / trade.Leverage),
+		}
+		if last != nil {
+			position.LastPrice = last.Close
+			position.PriceDate = util.DateKey(last.Time)
+			position.UnrealisedPnL = netPnL(trade, last.Close)
+			if position.CapitalUsed > 0 {
+				position.UnrealisedPct = round2(position.UnrealisedPnL / position.CapitalUsed * 100)
+			}
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+func (s *TradeServiceImpl) GetPnL(ctx context.Context, userID int64) (*model.PnLSummary, error) {
+	closed, err := s.repo.FindByUser(ctx, userID, model.TradeClosed)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.GetPositions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.PnLSummary{ClosedTrades: len(closed), Positions: positions}
+	for _, t := range closed {
+		summary.RealisedPnL += t.RealisedPnL
+		summary.Charges += t.Charges
+	}
+	for _, p := range positions {
+		summary.UnrealisedPnL += p.UnrealisedPnL
+		summary.Charges += p.Charges
+	}
+	summary.RealisedPnL = round2(summary.RealisedPnL)
+	summary.UnrealisedPnL = round2(summary.UnrealisedPnL)
+	summary.TotalPnL = round2(summary.RealisedPnL + summary.UnrealisedPnL)
+	summary.Charges = round2(summary.Charges)
+	return summary, nil
+}
+
+func (s *TradeServiceImpl) GetStrategyStats(ctx context.Context, userID int64) ([]model.StrategyStats, error) {
+	trades, err := s.repo.FindByUser(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byStrategy := make(map[string]*model.StrategyStats)
+	wins, losses := make(map[string]float64), make(map[string]float64)
+	for _, t := range trades {
+		stats, ok := byStrategy[t.Strategy]
+		if !ok {
+			stats = &model.StrategyStats{Strategy: t.Strategy}
+			byStrategy[t.Strategy] = stats
+		}
+		if t.Status == model.TradeOpen {
+			stats.OpenTrades++
+			continue
+		}
+
+		stats.Trades++
+		stats.RealisedPnL += t.RealisedPnL
+		switch {
+		case t.RealisedPnL > 0:
+			stats.Wins++
+			wins[t.Strategy] += t.RealisedPnL
+		case t.RealisedPnL < 0:
+			stats.Losses++
+			losses[t.Strategy] += t.RealisedPnL
+		default:
+			stats.Breakevens++
+		}
+	}
+
+	result := make([]model.StrategyStats, 0, len(byStrategy))
+	for name, stats := range byStrategy {
+		if stats.Trades > 0 {
+			stats.WinRate = round2(float64(stats.Wins) / float64(stats.Trades) * 100)
+			stats.AvgPnL = round2(stats.RealisedPnL / float64(stats.Trades))
+		}
+		if stats.Wins > 0 {
+			stats.AvgWin = round2(wins[name] / float64(stats.Wins))
+		}
+		if stats.Losses > 0 {
+			stats.AvgLoss = round2(losses[name] / float64(stats.Losses))
+		}
+		stats.RealisedPnL = round2(stats.RealisedPnL)
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].RealisedPnL > result[j].RealisedPnL })
+	return result, nil
+}
+
+// apply validates a request and copies it onto the trade. Leverage falls back to the symbol's margin.
+func (s *TradeServiceImpl) apply(trade *model.Trade, req model.TradeRequest, now time.Time) error {
+	side := req.Side.OrDefault()
+	if !side.IsValid() {
+		return fmt.Errorf("%w: side must be LONG or SHORT", ErrInvalidTrade)
+	}
+
+	entryDate := util.DateKey(now)
+	if req.EntryDate != "" {
+		if _, err := util.ParseDateKey(req.EntryDate); err != nil {
+			return fmt.Errorf("%w: entry date must be YYYY-MM-DD", ErrInvalidTrade)
+		}
+		entryDate = req.EntryDate
+	}
+	if trade.Status == model.TradeClosed && trade.ExitDate < entryDate {
+		return fmt.Errorf("%w: entry date is after exit date", ErrInvalidTrade)
+	}
+
+	if req.Strategy != "" {
+		if _, found := cache.StrategyCache.Get(req.Strategy); !found {
+			return fmt.Errorf("%w: unknown strategy %q", ErrInvalidTrade, req.Strategy)
+		}
+	}
+	if req.Zone != nil {
+		if req.Zone.Kind != detection.KindOrderBlock && req.Zone.Kind != detection.KindFvg {
+			return fmt.Errorf("%w: zone kind must be OB or FVG", ErrInvalidTrade)
+		}
+		req.Zone.Timeframe = req.Zone.Timeframe.OrDefault()
+		if !req.Zone.Timeframe.IsValid() {
+			return fmt.Errorf("%w: invalid zone timeframe %q", ErrInvalidTrade, req.Zone.Timeframe)
+		}
+	}
+
+	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
+	leverage := req.Leverage
+	if leverage <= 0 {
+		leverage = 1
+		if m, found := s.marginSvc.GetMargin(symbol); found && m.Margin > 0 {
+			leverage = float64(m.Margin)
+		}
+	}
+
+	trade.Symbol = symbol
+	trade.Side = side
+	trade.Quantity = req.Quantity
+	trade.EntryPrice = req.EntryPrice
+	trade.EntryDate = entryDate
+	trade.Leverage = leverage
+	trade.Charges = req.Charges + trade.ExitCharges
+	trade.Strategy = req.Strategy
+	trade.Zone = req.Zone
+	trade.Notes = req.Notes
+	trade.UpdatedAt = now
+	return nil
+}
+
+func (s *TradeServiceImpl) save(ctx context.Context, trade *model.Trade) error {
+	found, err := s.repo.Update(ctx, trade)
+	if err != nil {
+		return fmt.Errorf("failed to save trade: %w", err)
+	}
+	if !found {
+		return ErrTradeNotFound
+	}
+	return nil
+}
+
+// netPnL is the trade's profit at price, less the charges recorded on it.
+func netPnL(trade model.Trade, price float64) float64 {
+	gross := trade.Side.Sign() * (price - trade.EntryPrice) * float64(trade.Quantity)
+	return round2(gross - trade.Charges)
+}
@@ -19,6 +19,11 @@ type UserService interface {
 	CreateUser(ctx context.Context, request model.UserDto) (*model.User, error)
 	UpdateUserTheme(ctx context.Context, userId int64, theme model.UserTheme) (*model.User, error)
 	UpdateUsername(ctx context.Context, userId int64, username string) (*model.User, error)
+	UpdateTradingDefaults(ctx context.Context, userId int64, capital, riskPct float64) (*model.User, error)
+	UpdateTelegramChatID(ctx context.Context, userId int64, chatID string) (*model.User, error)
+	UpdateNotificationPrefs(ctx context.Context, userId int64, prefs model.NotificationPrefs) (*model.User, error)
+	// FindNotificationSubscribers returns the users who route an event to at least one channel.
+	FindNotificationSubscribers(ctx context.Context, event model.NotificationEvent) ([]model.User, error)
 	GetNextSequence(ctx context.Context, sequenceName string) (int, error)
 	FindUser(ctx context.Context, mobile int64, email string, userId int64) (*model.User, error)
 }
@@ -90,6 +95,36 @@ func (s *UserServiceImpl) UpdateUsername(ctx context.Context, userId int64, user
 	return s.repo.UpdateUser(ctx, filter, updateData)
 }
 
+// UpdateTradingDefaults updates the capital and risk used by the position sizing calculator
+func (s *UserServiceImpl) UpdateTradingDefaults(ctx context.Context, userId int64, capital, riskPct float64) (*model.User, error) {
+	filter := bson.M{"_id": userId}
+	updateData := bson.M{"defaultCapital": capital, "defaultRiskPct": riskPct}
+
+	return s.repo.UpdateUser(ctx, filter, updateData)
+}
+
+// UpdateTelegramChatID links a Telegram chat for notifications; an empty chat ID unlinks it
+func (s *UserServiceImpl) UpdateTelegramChatID(ctx context.Context, userId int64, chatID string) (*model.User, error) {
+	filter := bson.M{"_id": userId}
+	updateData := bson.M{"telegramChatId": chatID}
+
+	return s.repo.UpdateUser(ctx, filter, updateData)
+}
+
+// UpdateNotificationPrefs replaces the routing of notification events to channels
+func (s *UserServiceImpl) UpdateNotificationPrefs(ctx context.Context, userId int64, prefs model.NotificationPrefs) (*model.User, error) {
+	filter := bson.M{"_id": userId}
+	updateData := bson.M{"notifications": prefs}
+
+	return s.repo.UpdateUser(ctx, filter, updateData)
+}
+
+func (s *UserServiceImpl) FindNotificationSubscribers(ctx context.Context, event model.NotificationEvent) ([]model.User, error) {
+	// Only alerts have a default channel, and alerts are always sent to a single user
+	filter := bson.M{"notifications." + string(event) + ".0": bson.M{"$exists": true}}
+	return s.repo.Find(ctx, filter)
+}
+
 func (s *UserServiceImpl) GetNextSequence(ctx context.Context, sequenceName string) (int, error) {
 	return s.repo.GetNextSequence(ctx, "userid")
 }
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/model"
+	"backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	maxWatchlists      = 20
+	maxWatchlistItems  = 100
+	watchlistLoadLimit = 8
+)
+
+// activeStatuses are the zone statuses that can still be traded
+var activeStatuses = []model.ZoneStatus{model.ZoneFresh, model.ZoneTested}
+
+var (
+	ErrWatchlistNotFound = errors.New("watchlist not found")
+	ErrInvalidWatchlist  = errors.New("invalid watchlist")
+)
+
+// WatchlistService manages each user's named watchlists.
+type WatchlistService interface {
+	ListWatchlists(ctx context.Context, userID int64) ([]model.Watchlist, error)
+	// GetWatchlist returns a watchlist with margin, latest candle and active zones for every symbol.
+	GetWatchlist(ctx context.Context, userID int64, id string) (*model.WatchlistView, error)
+	CreateWatchlist(ctx context.Context, userID int64, name string) (*model.Watchlist, error)
+	RenameWatchlist(ctx context.Context, userID int64, id, name string) (*model.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, userID int64, id string) error
+	// ReorderWatchlists sets the order of the user's watchlists; lists left out keep their relative order after them.
+	ReorderWatchlists(ctx context.Context, userID int64, ids []string) ([]model.Watchlist, error)
+
+	AddItem(ctx context.Context, userID int64, id string, req model.WatchlistItemRequest) (*model.Watchlist, error)
+	UpdateItem(ctx context.Context, userID int64, id, symbol, note string) (*model.Watchlist, error)
+	RemoveItem(ctx context.Context, userID int64, id, symbol string) (*model.Watchlist, error)
+	// ReorderItems sets the order of a watchlist's symbols; it must name every symbol exactly once.
+	ReorderItems(ctx context.Context, userID int64, id string, symbols []string) (*model.Watchlist, error)
+}
+
+type WatchlistServiceImpl struct {
+	repo            *repository.WatchlistRepository
+	priceActionRepo *repository.PriceActionRepo
+	candleSvc       CandleService
+	marginSvc       MarginService
+}
+
+func NewWatchlistService(repo *repository.WatchlistRepository, priceActionRepo *repository.PriceActionRepo,
+	candleSvc CandleService, marginSvc MarginService) WatchlistService {
+	return &WatchlistServiceImpl{
+		repo:            repo,
+		priceActionRepo: priceActionRepo,
+		candleSvc:       candleSvc,
+		marginSvc:       marginSvc,
+	}
+}
+
+func (s *WatchlistServiceImpl) ListWatchlists(ctx context.Context, userID int64) ([]model.Watchlist, error) {
+	return s.repo.FindByUser(ctx, userID)
+}
+
+func (s *WatchlistServiceImpl) GetWatchlist(ctx context.Context, userID int64, id string) (*model.WatchlistView, error) {
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		symbols = append(symbols, item.Symbol)
+	}
+	records, err := s.priceActionRepo.GetAllPAIn(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+	zones := make(map[string]model.StockRecord, len(records))
+	for _, r := range records {
+		zones[r.Symbol] = r
+	}
+
+	view := &model.WatchlistView{
+		ID:       list.ID,
+		Name:     list.Name,
+		Position: list.Position,
+		Items:    make([]model.WatchlistEntry, len(list.Items)),
+	}
+
+	now := time.Now()
+	var g errgroup.Group
+	g.SetLimit(watchlistLoadLimit)
+	for i, item := range list.Items {
+		entry := model.WatchlistEntry{
+			WatchlistItem: item,
+			OrderBlocks:   filterByStatus(zones[item.Symbol].OrderBlocks, activeStatuses),
+			Fvg:           filterByStatus(zones[item.Symbol].Fvg, activeStatuses),
+		}
+		if m, found := s.marginSvc.GetMargin(item.Symbol); found {
+			entry.Name = m.Name
+			entry.Margin = m.Margin
+		}
+
+		g.Go(func() error {
+			candles, err := s.candleSvc.GetCandles(ctx, item.Symbol, now.Add(-priceLookback), now)
+			if err != nil {
+				log.Printf("Watchlist: no candles for %s: %v", item.Symbol, err)
+			}
+			if n := len(candles); n > 0 {
+				entry.Candle = &candles[n-1]
+				if n > 1 && candles[n-2].Close != 0 {
+					entry.Change = round2(candles[n-1].Close - candles[n-2].Close)
+					entry.PChange = round2(entry.Change / candles[n-2].Close * 100)
+				}
+			}
+
+			view.Items[i] = entry
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return view, nil
+}
+
+func (s *WatchlistServiceImpl) CreateWatchlist(ctx context.Context, userID int64, name string) (*model.Watchlist, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWatchlist)
+	}
+
+	existing, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWatchlists {
+		return nil, fmt.Errorf("%w: at most %d watchlists are allowed", ErrInvalidWatchlist, maxWatchlists)
+	}
+
+	now := time.Now()
+	list := &model.Watchlist{
+		UserID:    userID,
+		Name:      name,
+		Items:     []model.WatchlistItem{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if n := len(existing); n > 0 {
+		list.Position = existing[n-1].Position + 1
+	}
+
+	if err := s.repo.Insert(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *WatchlistServiceImpl) RenameWatchlist(ctx context.Context, userID int64, id, name string) (*model.Watchlist, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWatchlist)
+	}
+
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	list.Name = name
+	return list, s.save(ctx, list)
+}
+
+func (s *WatchlistServiceImpl) DeleteWatchlist(ctx context.Context, userID int64, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrWatchlistNotFound
+	}
+
+	found, err := s.repo.Delete(ctx, userID, objectId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWatchlistNotFound
+	}
+	return nil
+}
+
+func (s *WatchlistServiceImpl) ReorderWatchlists(ctx context.Context, userID int64, ids []string) ([]model.Watchlist, error) {
+	lists, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]model.Watchlist, 0, len(lists))
+	for _, id := range ids {
+		i := slices.IndexFunc(lists, func(l model.Watchlist) bool { return l.ID.Hex() == id })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown watchlist %s", ErrInvalidWatchlist, id)
+		}
+		ordered = append(ordered, lists[i])
+		lists = slices.Delete(lists, i, i+1)
+	}
+	ordered = append(ordered, lists...)
+
+	for i := range ordered {
+		if ordered[i].Position == i {
+			continue
+		}
+		ordered[i].Position = i
+		if err := s.repo.SetPosition(ctx, userID, ordered[i].ID, i); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func (s *WatchlistServiceImpl) AddItem(ctx context.Context, userID int64, id string, req model.WatchlistItemRequest) (*model.Watchlist, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
+	if symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", ErrInvalidWatchlist)
+	}
+
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if indexOfItem(list.Items, symbol) >= 0 {
+		return nil, fmt.Errorf("%w: %s is already on the watchlist", ErrInvalidWatchlist, symbol)
+	}
+	if len(list.Items) >= maxWatchlistItems {
+		return nil, fmt.Errorf("%w: at most %d symbols per watchlist", ErrInvalidWatchlist, maxWatchlistItems)
+	}
+
+	list.Items = append(list.Items, model.WatchlistItem{Symbol: symbol, Note: req.Note, AddedAt: time.Now()})
+	return list, s.save(ctx, list)
+}
+
+func (s *WatchlistServiceImpl) UpdateItem(ctx context.Context, userID int64, id, symbol, note string) (*model.Watchlist, error) {
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexOfItem(list.Items, strings.ToUpper(symbol))
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s is not on the watchlist", ErrWatchlistNotFound, symbol)
+	}
+	list.Items[i].Note = note
+	return list, s.save(ctx, list)
+}
+
+func (s *WatchlistServiceImpl) RemoveItem(ctx context.Context, userID int64, id, symbol string) (*model.Watchlist, error) {
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	i := indexOfItem(list.Items, strings.ToUpper(symbol))
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s is not on the watchlist", ErrWatchlistNotFound, symbol)
+	}
+	list.Items = slices.Delete(list.Items, i, i+1)
+	return list, s.save(ctx, list)
+}
+
+func (s *WatchlistServiceImpl) ReorderItems(ctx context.Context, userID int64, id string, symbols []string) (*model.Watchlist, error) {
+	list, err := s.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(symbols) != len(list.Items) {
+		return nil, fmt.Errorf("%w: order must list all %d symbols", ErrInvalidWatchlist, len(list.Items))
+	}
+
+	items := make([]model.WatchlistItem, 0, len(symbols))
+	for _, symbol := range symbols {
+		i := indexOfItem(list.Items, strings.ToUpper(strings.TrimSpace(symbol)))
+		if i < 0 || indexOfItem(items, list.Items[i].Symbol) >= 0 {
+			return nil, fmt.Errorf("%w: order must list every symbol exactly once", ErrInvalidWatchlist)
+		}
+		items = append(items, list.Items[i])
+	}
+	list.Items = items
+	return list, s.save(ctx, list)
+}
+
+func (s *WatchlistServiceImpl) find(ctx context.Context, userID int64, id string) (*model.Watchlist, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrWatchlistNotFound
+	}
+
+	list, err := s.repo.FindById(ctx, userID, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, ErrWatchlistNotFound
+	}
+	return list, nil
+}
+
+func (s *WatchlistServiceImpl) save(ctx context.Context, list *model.Watchlist) error {
+	list.UpdatedAt = time.Now()
+	found, err := s.repo.Update(ctx, list)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWatchlistNotFound
+	}
+	return nil
+}
+
+func indexOfItem(items []model.WatchlistItem, symbol string) int {
+	return slices.IndexFunc(items, func(item model.WatchlistItem) bool { return item.Symbol == symbol })
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"backend/client"
+	"backend/detection"
+	"backend/events"
+	"backend/model"
+	"backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	webhookMaxAttempts = 5
+	// webhookBackoff is the wait before the first retry; it doubles for every retry after that
+	webhookBackoff = 30 * time.Second
+	webhookTimeout = 30 * time.Second
+	// webhookRetryInterval is how often the delivery log is polled for due retries
+	webhookRetryInterval = 10 * time.Second
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+// WebhookService manages outbound webhooks and delivers bus events to them.
+type WebhookService interface {
+	ListWebhooks(ctx context.Context) ([]model.Webhook, error)
+	CreateWebhook(ctx context.Context, userID int64, req model.WebhookRequest) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, id string, req model.WebhookRequest) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, id string, limit int64) ([]model.WebhookDelivery, error)
+	// TestWebhook sends a webhook.test event and returns the delivery as it stood after the first attempt.
+	TestWebhook(ctx context.Context, id string) (*model.WebhookDelivery, error)
+	// Start subscribes the webhooks to scan, zone and automation events on the bus, and starts the worker that
+	// retries failed deliveries from the delivery log.
+	Start()
+}
+
+type WebhookServiceImpl struct {
+	repo   *repository.WebhookRepository
+	client *client.WebhookClient
+	bus    *events.Bus
+}
+
+func NewWebhookService(repo *repository.WebhookRepository, c *client.WebhookClient, bus *events.Bus) WebhookService {
+	return &WebhookServiceImpl{
+		repo:   repo,
+		client: c,
+		bus:    bus,
+	}
+}
+
+func (s *WebhookServiceImpl) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, userID int64, req model.WebhookRequest) (*model.Webhook, error) {
+	if req.Secret == "" {
+		return nil, fmt.Errorf("%w: secret is required", ErrInvalidWebhook)
+	}
+
+	now := time.Now()
+	hook := &model.Webhook{
+		Active:    true,
+		CreatedBy: userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := applyWebhookRequest(hook, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Insert(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to save webhook: %w", err)
+	}
+	return hook, nil
+}
+
+func (s *WebhookServiceImpl) UpdateWebhook(ctx context.Context, id string, req model.WebhookRequest) (*model.Webhook, error) {
+	hook, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhookRequest(hook, req); err != nil {
+		return nil, err
+	}
+	hook.UpdatedAt = time.Now()
+
+	found, err := s.repo.Update(ctx, hook)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrWebhookNotFound
+	}
+	return hook, nil
+}
+
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrWebhookNotFound
+	}
+
+	found, err := s.repo.Delete(ctx, objectId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, id string, limit int64) ([]model.WebhookDelivery, error) {
+	hook, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.repo.FindDeliveries(ctx, hook.ID, limit)
+}
+
+func (s *WebhookServiceImpl) TestWebhook(ctx context.Context, id string) (*model.WebhookDelivery, error) {
+	hook, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, body, err := s.record(ctx, hook, model.WebhookTest, events.Event{Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	result := s.deliver(hook, delivery, body)
+	return &result, nil
+}
+
+func (s *WebhookServiceImpl) Start() {
+	s.bus.Subscribe("webhooks", 0, s.handle)
+	go s.retryLoop()
+}
+
+func (s *WebhookServiceImpl) handle(e events.Event) {
+	event, ok := webhookEvent(e)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	hooks, err := s.repo.FindSubscribed(ctx, event)
+	if err != nil {
+		log.Printf("Webhooks: failed to load subscribers of %s: %v", event, err)
+		return
+	}
+	for i := range hooks {
+		hook := &hooks[i]
+		delivery, body, err := s.record(ctx, hook, event, e)
+		if err != nil {
+			log.Printf("Webhooks: failed to log %s for %s: %v", event, hook.ID.Hex(), err)
+			continue
+		}
+		go s.deliver(hook, delivery, body)
+	}
+}
+
+// webhookEvent maps a bus event to the webhook event it is published as.
+func webhookEvent(e events.Event) (model.WebhookEvent, bool) {
+	switch e.Type {
+	case events.ScanCompleted:
+		// Webhooks are not tied to a user, so only public strategies' scans go out
+		if scan, ok := e.Data.(model.ScanEvent); !ok || !scan.VisibleTo(0) {
+			return "", false
+		}
+		return model.WebhookScanCompleted, true
+	case events.AutomationFinished:
+		return model.WebhookAutomationFinished, true
+	case events.ZoneDetected, events.ZoneMitigated:
+		zone, ok := e.Data.(model.ZoneEvent)
+		if !ok {
+			return "", false
+		}
+		created := e.Type == events.ZoneDetected
+		switch {
+		case zone.Kind == detection.KindOrderBlock && created:
+			return model.WebhookOBCreated, true
+		case zone.Kind == detection.KindOrderBlock:
+			return model.WebhookOBMitigated, true
+		case created:
+			return model.WebhookFvgCreated, true
+		default:
+			return model.WebhookFvgMitigated, true
+		}
+	}
+	return "", false
+}
+
+// record builds the payload of an event and logs it as a pending delivery.
+func (s *WebhookServiceImpl) record(ctx context.Context, hook *model.Webhook, event model.WebhookEvent,
+	e events.Event) (*model.WebhookDelivery, []byte, error) {
+	delivery := &model.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: hook.ID,
+		Event:     event,
+		CreatedAt: time.Now(),
+	}
+
+	body, err := json.Marshal(model.WebhookPayload{
+		ID: delivery.ID.Hex(), Event: event, Symbol: e.Symbol, Time: e.Time, Data: e.Data,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	delivery.Payload = string(body)
+
+	if err := s.repo.InsertDelivery(ctx, delivery); err != nil {
+		return nil, nil, err
+	}
+	return delivery, body, nil
+}
+
+// deliver makes the first attempt. A transient failure leaves NextAttemptAt set in the delivery log, where the
+// retry worker picks it up, so pending retries survive a restart.
+func (s *WebhookServiceImpl) deliver(hook *model.Webhook, delivery *model.WebhookDelivery, body []byte) model.WebhookDelivery {
+	s.attempt(hook, delivery, body)
+	return *delivery
+}
+
+// retryLoop attempts every delivery whose retry is due, one at a time.
+func (s *WebhookServiceImpl) retryLoop() {
+	ticker := time.NewTicker(webhookRetryInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for s.retryDue() {
+		}
+	}
+}
+
+// retryDue claims one due delivery and attempts it again, reporting whether there was one.
+func (s *WebhookServiceImpl) retryDue() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	now := time.Now()
+	delivery, err := s.repo.ClaimDueDelivery(ctx, now, now.Add(2*webhookTimeout))
+	if err != nil {
+		log.Printf("Webhooks: failed to load due retries: %v", err)
+		return false
+	}
+	if delivery == nil {
+		return false
+	}
+
+	hook, err := s.repo.FindById(ctx, delivery.WebhookID)
+	if err != nil {
+		log.Printf("Webhooks: failed to load webhook of %s: %v", delivery.ID.Hex(), err)
+		return true
+	}
+	if hook == nil || !hook.Active {
+		delivery.NextAttemptAt = nil
+		delivery.Error = "webhook deleted or disabled before retry"
+		if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+			log.Printf("Webhooks: failed to log %s: %v", delivery.ID.Hex(), err)
+		}
+		return true
+	}
+
+	s.attempt(hook, delivery, []byte(delivery.Payload))
+	return true
+}
+
+// attempt POSTs the payload once, logs the outcome and reports whether another attempt is due.
+func (s *WebhookServiceImpl) attempt(hook *model.Webhook, delivery *model.WebhookDelivery, body []byte) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	delivery.Attempts++
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"X-Webhook-Event":     string(delivery.Event),
+		"X-Webhook-Delivery":  delivery.ID.Hex(),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + signWebhook(hook.Secret, timestamp, body),
+	}
+
+	transient := true
+	resp, err := s.client.Post(ctx, hook.URL, headers, body)
+	switch {
+	case err != nil:
+		delivery.StatusCode = 0
+		delivery.Error = err.Error()
+	case resp.IsSuccess():
+		now := time.Now()
+		delivery.StatusCode = resp.StatusCode()
+		delivery.Success = true
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+	default:
+		code := resp.StatusCode()
+		delivery.StatusCode = code
+		delivery.Error = fmt.Sprintf("subscriber responded %d", code)
+		// Other client errors will not go away by sending the same request again
+		transient = code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+	}
+
+	retry := !delivery.Success && transient && delivery.Attempts < webhookMaxAttempts
+	delivery.NextAttemptAt = nil
+	if retry {
+		next := time.Now().Add(webhookBackoff << (delivery.Attempts - 1))
+		delivery.NextAttemptAt = &next
+	}
+
+	if err := s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Printf("Webhooks: failed to log attempt %d of %s: %v", delivery.Attempts, delivery.ID.Hex(), err)
+	}
+	if !delivery.Success && !retry {
+		log.Printf("Webhooks: giving up on %s to %s after %d attempts: %s", delivery.Event, hook.URL,
+			delivery.Attempts, delivery.Error)
+	}
+	return retry
+}
+
+// signWebhook is the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook's secret. Receivers
+// recompute it from the X-Webhook-Timestamp header and the raw body.
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func applyWebhookRequest(hook *model.Webhook, req model.WebhookRequest) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be http or https", ErrInvalidWebhook)
+	}
+
+	subscribed := make([]model.WebhookEvent, 0, len(req.Events))
+	for _, event := range req.Events {
+		if !slices.Contains(model.WebhookEvents, event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !slices.Contains(subscribed, event) {
+			subscribed = append(subscribed, event)
+		}
+	}
+
+	hook.URL = req.URL
+	hook.Description = req.Description
+	hook.Events = subscribed
+	if req.Secret != "" {
+		hook.Secret = req.Secret
+	}
+	if req.Active != nil {
+		hook.Active = *req.Active
+	}
+	return nil
+}
+
+func (s *WebhookServiceImpl) find(ctx context.Context, id string) (*model.Webhook, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	hook, err := s.repo.FindById(ctx, objectId)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return hook, nil
+}
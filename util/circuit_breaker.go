@@ -0,0 +1,111 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	CircuitClosed   = "CLOSED"
+	CircuitOpen     = "OPEN"
+	CircuitHalfOpen = "HALF_OPEN"
+)
+
+// CircuitBreaker trips after a number of consecutive failures and rejects calls until a cooldown passes.
+// After the cooldown a single trial call is let through (half-open); its outcome closes or re-opens the circuit.
+// Thresholds are passed per call so they can follow hot-swapped configuration.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	failures    int
+	openedAt    time.Time
+	trialActive bool
+	lastError   string
+	lastFailure time.Time
+	lastSuccess time.Time
+}
+
+// Allow reports whether a call may proceed.
+func (b *CircuitBreaker) Allow(threshold int, cooldown time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state(threshold, cooldown) {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if b.trialActive {
+			return false
+		}
+		b.trialActive = true
+	}
+	return true
+}
+
+// Success records a successful call and closes the circuit.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trialActive = false
+	b.lastSuccess = time.Now()
+}
+
+// Failure records a failed call, opening the circuit once threshold consecutive failures are reached.
+func (b *CircuitBreaker) Failure(err error, threshold int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trialActive = false
+	b.lastFailure = time.Now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.failures >= threshold {
+		b.openedAt = b.lastFailure
+	}
+}
+
+// Release ends a half-open trial whose outcome says nothing about health (cancelled, unsupported).
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialActive = false
+}
+
+// CircuitSnapshot is a point-in-time view of a breaker for health reporting.
+type CircuitSnapshot struct {
+	State       string
+	Failures    int
+	LastError   string
+	LastFailure time.Time
+	LastSuccess time.Time
+	OpenedAt    time.Time
+}
+
+// Snapshot returns the breaker's current state.
+func (b *CircuitBreaker) Snapshot(threshold int, cooldown time.Duration) CircuitSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return CircuitSnapshot{
+		State:       b.state(threshold, cooldown),
+		Failures:    b.failures,
+		LastError:   b.lastError,
+		LastFailure: b.lastFailure,
+		LastSuccess: b.lastSuccess,
+		OpenedAt:    b.openedAt,
+	}
+}
+
+func (b *CircuitBreaker) state(threshold int, cooldown time.Duration) string {
+	if threshold <= 0 || b.failures < threshold {
+		return CircuitClosed
+	}
+	if time.Since(b.openedAt) < cooldown {
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
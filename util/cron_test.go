@@ -0,0 +1,182 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func bitsOf(values ...int) uint64 {
+	var bits uint64
+	for _, v := range values {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+func bitsRange(lo, hi, step int) uint64 {
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+func TestParseCron(t *testing.T) {
+	everyDom, everyMonth := bitsRange(1, 31, 1), bitsRange(1, 12, 1)
+	tests := []struct {
+		expr string
+		want CronSchedule
+	}{
+		{
+			expr: "30 16 * * 1-5",
+			want: CronSchedule{minute: bitsOf(30), hour: bitsOf(16), dom: everyDom, month: everyMonth,
+				dow: bitsRange(1, 5, 1), domStar: true},
+		},
+		{
+			expr: "*/15 9-15 * * MON-FRI",
+			want: CronSchedule{minute: bitsOf(0, 15, 30, 45), hour: bitsRange(9, 15, 1), dom: everyDom,
+				month: everyMonth, dow: bitsRange(1, 5, 1), domStar: true},
+		},
+		{
+			expr: "0 18 * * *",
+			want: CronSchedule{minute: bitsOf(0), hour: bitsOf(18), dom: everyDom, month: everyMonth,
+				dow: bitsRange(0, 6, 1), domStar: true, dowStar: true},
+		},
+		{
+			expr: "5,35 9 1 */3 0",
+			want: CronSchedule{minute: bitsOf(5, 35), hour: bitsOf(9), dom: bitsOf(1), month: bitsOf(1, 4, 7, 10),
+				dow: bitsOf(0)},
+		},
+		{
+			expr: "0 10/4 * * 7",
+			want: CronSchedule{minute: bitsOf(0), hour: bitsOf(10, 14, 18, 22), dom: everyDom, month: everyMonth,
+				dow: bitsOf(0, 7), domStar: true},
+		},
+		{
+			expr: "0-10/5 0 * * sat,SUN",
+			want: CronSchedule{minute: bitsOf(0, 5, 10), hour: bitsOf(0), dom: everyDom, month: everyMonth,
+				dow: bitsOf(0, 6), domStar: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseCron = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"1-x * * * *",
+		"a * * * *",
+		"* * * * FUNDAY",
+		"* * * JAN *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseCron(expr); err == nil {
+				t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	loc := MarketLocation()
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{name: "later the same day", expr: "30 16 * * 1-5", from: at(2025, 1, 6, 10, 0), want: at(2025, 1, 6, 16, 30)},
+		{name: "strictly after", expr: "30 16 * * 1-5", from: at(2025, 1, 6, 16, 30), want: at(2025, 1, 7, 16, 30)},
+		{name: "seconds are dropped", expr: "30 16 * * 1-5", from: at(2025, 1, 6, 16, 29).Add(30 * time.Second),
+			want: at(2025, 1, 6, 16, 30)},
+		{name: "over a weekend into the next month", expr: "30 16 * * 1-5", from: at(2025, 1, 31, 17, 0),
+			want: at(2025, 2, 3, 16, 30)},
+		{name: "first of the next month", expr: "0 9 1 * *", from: at(2025, 1, 15, 12, 0), want: at(2025, 2, 1, 9, 0)},
+		{name: "skips a month without the day", expr: "0 0 31 * *", from: at(2025, 1, 31, 0, 0),
+			want: at(2025, 3, 31, 0, 0)},
+		{name: "into the next year", expr: "59 23 * * *", from: at(2025, 12, 31, 23, 59), want: at(2026, 1, 1, 23, 59)},
+		{name: "after the last step of the day", expr: "*/15 9-15 * * MON-FRI", from: at(2025, 1, 6, 15, 50),
+			want: at(2025, 1, 7, 9, 0)},
+		{name: "day of month or day of week", expr: "0 12 13 * 5", from: at(2025, 1, 6, 0, 0),
+			want: at(2025, 1, 10, 12, 0)},
+		{name: "from another time zone", expr: "30 16 * * 1-5",
+			from: time.Date(2025, 1, 6, 11, 30, 0, 0, time.UTC).In(loc), want: at(2025, 1, 7, 16, 30)},
+		{name: "never matches", expr: "0 0 30 2 *", from: at(2025, 1, 1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			got := sched.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != loc {
+				t.Errorf("Next returned location %v, want %v", got.Location(), loc)
+			}
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	loc := MarketLocation()
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		at   time.Time
+		want time.Time
+	}{
+		{name: "at a slot", expr: "30 16 * * 1-5", at: at(2025, 1, 6, 16, 30), want: at(2025, 1, 6, 16, 30)},
+		{name: "over a weekend", expr: "30 16 * * 1-5", at: at(2025, 1, 6, 10, 0), want: at(2025, 1, 3, 16, 30)},
+		{name: "into the previous month", expr: "30 16 * * 1-5", at: at(2025, 2, 1, 12, 0),
+			want: at(2025, 1, 31, 16, 30)},
+		{name: "into the previous year", expr: "0 9 * * *", at: at(2026, 1, 1, 8, 0), want: at(2025, 12, 31, 9, 0)},
+		{name: "within a week", expr: "0 9 1 * *", at: at(2025, 2, 3, 12, 0), want: at(2025, 2, 1, 9, 0)},
+		{name: "more than a week back", expr: "0 9 1 * *", at: at(2025, 1, 15, 12, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if got := sched.Prev(tt.at); !got.Equal(tt.want) {
+				t.Errorf("Prev(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Read handles the CSV parsing logic
@@ -115,3 +117,57 @@ func ReadCSVReversed(r io.Reader, stopDate string) ([]model.ObRequest, error) {
 
 	return results, nil
 }
+
+// ReadCandles parses a date,open,high,low,close,volume CSV into ascending daily candles.
+// Dates may be YYYY-MM-DD or DD-MM-YYYY; rows that fail to parse are skipped.
+func ReadCandles(r io.Reader, symbol string) ([]model.Candle, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	var candles []model.Candle
+	for _, record := range records {
+		if len(record) < 6 {
+			continue
+		}
+
+		t, err := time.ParseInLocation("2006-01-02", record[0], MarketLocation())
+		if err != nil {
+			t, err = time.ParseInLocation("02-01-2006", record[0], MarketLocation())
+		}
+		if err != nil {
+			continue // header or malformed row
+		}
+
+		var values [4]float64
+		valid := true
+		for j := range values {
+			values[j], err = strconv.ParseFloat(record[j+1], 64)
+			if err != nil {
+				valid = false
+				break
+			}
+		}
+		volume, err := strconv.ParseInt(record[5], 10, 64)
+		if !valid || err != nil {
+			continue
+		}
+
+		candles = append(candles, model.Candle{
+			Symbol: symbol,
+			Time:   t,
+			Open:   values[0],
+			High:   values[1],
+			Low:    values[2],
+			Close:  values[3],
+			Volume: volume,
+		})
+	}
+
+	sort.Slice(candles, func(i, j int) bool { return candles[i].Time.Before(candles[j].Time) })
+	return candles, nil
+}
@@ -8,8 +8,9 @@ import (
 )
 
 var (
-	inputLayout  = "02-Jan-2006"
-	outputLayout = "2006-01-02"
+	inputLayout    = "02-Jan-2006"
+	outputLayout   = "2006-01-02"
+	intradayLayout = "2006-01-02 15:04"
 )
 
 func ParseNseDate(nseDate string) (string, error) {
@@ -37,3 +38,45 @@ func NseCacheExpiryTime() time.Duration {
 
 	return cache.DefaultExpiration
 }
+
+// MarketLocation returns the Asia/Kolkata location, falling back to a fixed IST offset
+// when the tz database is unavailable in the container.
+func MarketLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		return time.FixedZone("IST", 5*60*60+30*60)
+	}
+	return loc
+}
+
+// IsTradingDay reports whether t falls on an NSE session, i.e. a weekday that is not listed
+// in holidays (YYYY-MM-DD).
+func IsTradingDay(t time.Time, holidays []string) bool {
+	t = t.In(MarketLocation())
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	date := t.Format(outputLayout)
+	for _, h := range holidays {
+		if strings.TrimSpace(h) == date {
+			return false
+		}
+	}
+	return true
+}
+
+// StartOfDay truncates t to midnight in market time. Daily candles are keyed on this value.
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(MarketLocation())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DateKey formats t as YYYY-MM-DD in market time, the format zones and requests are keyed on.
+func DateKey(t time.Time) string {
+	return t.In(MarketLocation()).Format(outputLayout)
+}
+
+// ParseDateKey parses a YYYY-MM-DD date as midnight in market time.
+func ParseDateKey(date string) (time.Time, error) {
+	return time.ParseInLocation(outputLayout, strings.TrimSpace(date), MarketLocation())
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"time"
+
+	"backend/model"
+)
+
+// sessionOpen is when the NSE cash session starts; hourly bars are aligned to it (09:15, 10:15, ...).
+const sessionOpen = 9*time.Hour + 15*time.Minute
+
+// PeriodStart returns the start of the tf candle that contains t, in market time.
+func PeriodStart(t time.Time, tf model.Timeframe) time.Time {
+	t = t.In(MarketLocation())
+	day := StartOfDay(t)
+
+	switch tf {
+	case model.Timeframe15m:
+		return t.Truncate(15 * time.Minute)
+	case model.Timeframe1h:
+		since := t.Sub(day.Add(sessionOpen))
+		if since < 0 {
+			return day.Add(sessionOpen)
+		}
+		return day.Add(sessionOpen + since.Truncate(time.Hour))
+	case model.Timeframe1W:
+		offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+		return day.AddDate(0, 0, -offset)
+	case model.Timeframe1M:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	default:
+		return day
+	}
+}
+
+// Resample aggregates ascending candles into tf candles, oldest first.
+// The input must be of a finer timeframe than tf (15m for 1h, daily for 1W and 1M).
+func Resample(candles []model.Candle, tf model.Timeframe) []model.Candle {
+	out := make([]model.Candle, 0, len(candles))
+	for _, c := range candles {
+		start := PeriodStart(c.Time, tf)
+		if n := len(out); n > 0 && out[n-1].Time.Equal(start) {
+			bar := &out[n-1]
+			bar.High = max(bar.High, c.High)
+			bar.Low = min(bar.Low, c.Low)
+			bar.Close = c.Close
+			bar.Volume += c.Volume
+			continue
+		}
+		c.Time = start
+		out = append(out, c)
+	}
+	return out
+}
+
+// CandleKey formats a candle time the way zones store their date: YYYY-MM-DD for daily and longer
+// timeframes, YYYY-MM-DD HH:mm for intraday ones.
+func CandleKey(t time.Time, tf model.Timeframe) string {
+	if tf.IsIntraday() {
+		return t.In(MarketLocation()).Format(intradayLayout)
+	}
+	return DateKey(t)
+}
+
+// ParseCandleKey is the inverse of CandleKey.
+func ParseCandleKey(key string, tf model.Timeframe) (time.Time, error) {
+	if tf.IsIntraday() {
+		return time.ParseInLocation(intradayLayout, key, MarketLocation())
+	}
+	return ParseDateKey(key)
+}